@@ -0,0 +1,36 @@
+/* Exemplo de uso da interface C; compilado e executado por `make capi-test`. */
+#include <stdio.h>
+#include <string.h>
+
+#include "../cnpj.h"
+
+int main(void) {
+    char dv[CNPJ_DV_BUFFER_SIZE];
+    int err;
+    char *formatted;
+    char *generated;
+
+    if (!cnpj_is_valid("12.ABC.345/01DE-35") || cnpj_is_valid("12.ABC.345/01DE-36")) {
+        fprintf(stderr, "cnpj_is_valid: resultado inesperado\n");
+        return 1;
+    }
+
+    err = cnpj_calculate_dv("12ABC34501DE", dv, sizeof dv);
+    if (err != CNPJ_OK || strcmp(dv, "35") != 0) {
+        fprintf(stderr, "cnpj_calculate_dv: %s\n", cnpj_error_message(err));
+        return 1;
+    }
+
+    err = cnpj_calculate_dv("000000000000", dv, sizeof dv);
+    if (err != CNPJ_ERR_INVALID || strcmp(cnpj_error_code(err), "CNPJ_DV_INVALIDO") != 0) {
+        fprintf(stderr, "cnpj_calculate_dv deveria falhar para base zerada\n");
+        return 1;
+    }
+
+    formatted = cnpj_format("12ABC34501DE35");
+    generated = cnpj_generate();
+    printf("%s %s %d\n", formatted, generated, cnpj_is_valid(generated));
+    cnpj_free(formatted);
+    cnpj_free(generated);
+    return 0;
+}
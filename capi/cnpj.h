@@ -0,0 +1,64 @@
+/*
+ * cnpj.h - interface C estável de pkg/cnpj.
+ *
+ * Gerada para os builds `make capi` (libcnpj.so, -buildmode=c-shared) e
+ * `make capi-static` (libcnpj.a, -buildmode=c-archive).
+ *
+ * Regras de memória:
+ *   - Strings de entrada pertencem ao chamador, devem terminar em NUL e estar
+ *     em UTF-8; a biblioteca não guarda referências a elas após o retorno.
+ *   - cnpj_format e cnpj_generate devolvem strings alocadas pela biblioteca,
+ *     que pertencem ao chamador e devem ser liberadas com cnpj_free (nunca
+ *     com o free de outro runtime, como o do Delphi ou do Python).
+ *   - cnpj_calculate_dv escreve em um buffer fornecido pelo chamador, com
+ *     pelo menos CNPJ_DV_BUFFER_SIZE bytes.
+ *   - cnpj_error_code e cnpj_error_message devolvem strings estáticas, que
+ *     não devem ser liberadas nem alteradas.
+ *
+ * Todas as funções podem ser chamadas de várias threads simultaneamente.
+ */
+#ifndef ALFANUMERIC_CNPJ_H
+#define ALFANUMERIC_CNPJ_H
+
+#include <stddef.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#define CNPJ_OK 0
+#define CNPJ_ERR_INVALID 1 /* CNPJ_DV_INVALIDO: a base do CNPJ é inválida */
+#define CNPJ_ERR_NULL 2    /* ponteiro nulo recebido */
+#define CNPJ_ERR_BUFFER 3  /* buffer de saída pequeno demais */
+
+#define CNPJ_DV_BUFFER_SIZE 3 /* dois dígitos e o NUL final */
+
+/* Retorna 1 se o CNPJ (com ou sem máscara) for válido e 0 caso contrário. */
+int cnpj_is_valid(const char *value);
+
+/* Calcula os dois dígitos verificadores da base de 12 caracteres (ou do CNPJ
+ * completo, ignorando o DV informado) e os escreve em out. */
+int cnpj_calculate_dv(const char *value, char *out, size_t out_len);
+
+/* Aplica a máscara ##.###.###/####-##. Retorna NULL se o valor não tiver
+ * 14 caracteres. O resultado deve ser liberado com cnpj_free. */
+char *cnpj_format(const char *value);
+
+/* Gera um CNPJ alfanumérico válido, sem máscara. O resultado deve ser
+ * liberado com cnpj_free. */
+char *cnpj_generate(void);
+
+/* Libera uma string devolvida pela biblioteca. Aceita NULL. */
+void cnpj_free(char *value);
+
+/* Código estável do erro, por exemplo "CNPJ_DV_INVALIDO". */
+const char *cnpj_error_code(int err);
+
+/* Mensagem do erro em pt-BR. */
+const char *cnpj_error_message(int err);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* ALFANUMERIC_CNPJ_H */
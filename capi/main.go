@@ -0,0 +1,115 @@
+// Comando capi expõe pkg/cnpj como biblioteca C (-buildmode=c-shared ou
+// -buildmode=c-archive). A interface pública é a declarada em cnpj.h; as
+// funções exportadas aqui devem acompanhar exatamente aquele header.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Códigos de erro; espelham os #define de cnpj.h
+const (
+	errOK      = 0
+	errInvalid = 1
+	errNull    = 2
+	errBuffer  = 3
+)
+
+type errorInfo struct {
+	code    *C.char
+	message *C.char
+}
+
+// errorInfos guarda strings C estáticas, alocadas uma única vez e nunca liberadas
+var errorInfos = map[C.int]errorInfo{
+	errOK:      {C.CString("CNPJ_OK"), C.CString("sucesso")},
+	errInvalid: {C.CString(dvInvalido.Code), C.CString(dvInvalido.Message)},
+	errNull:    {C.CString("CNPJ_PONTEIRO_NULO"), C.CString("ponteiro nulo recebido")},
+	errBuffer:  {C.CString("CNPJ_BUFFER_PEQUENO"), C.CString("buffer de saída pequeno demais")},
+}
+
+// dvInvalido é o *cnpj.Error por trás de cnpj.ErroDVInvalido
+var dvInvalido = func() *cnpj.Error {
+	var e *cnpj.Error
+	errors.As(cnpj.ErroDVInvalido, &e)
+	return e
+}()
+
+var unknownError = errorInfo{C.CString("CNPJ_ERRO_DESCONHECIDO"), C.CString("erro desconhecido")}
+
+func main() {}
+
+//export cnpj_is_valid
+func cnpj_is_valid(value *C.char) C.int {
+	if value == nil || !cnpj.IsValid(C.GoString(value)) {
+		return 0
+	}
+	return 1
+}
+
+//export cnpj_calculate_dv
+func cnpj_calculate_dv(value *C.char, out *C.char, outLen C.size_t) C.int {
+	if value == nil || out == nil {
+		return errNull
+	}
+	if outLen < 3 {
+		return errBuffer
+	}
+
+	dv, err := cnpj.CalculateDV(C.GoString(value))
+	if err != nil {
+		return errInvalid
+	}
+
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(out)), 3)
+	copy(buf, dv)
+	buf[2] = 0
+	return errOK
+}
+
+//export cnpj_format
+func cnpj_format(value *C.char) *C.char {
+	if value == nil {
+		return nil
+	}
+
+	formatado := cnpj.FormatCNPJ(C.GoString(value))
+	if formatado == cnpj.MensagemFormatoInvalido {
+		return nil
+	}
+	return C.CString(formatado)
+}
+
+//export cnpj_generate
+func cnpj_generate() *C.char {
+	return C.CString(cnpj.GenerateCNPJ())
+}
+
+//export cnpj_free
+func cnpj_free(value *C.char) {
+	C.free(unsafe.Pointer(value))
+}
+
+//export cnpj_error_code
+func cnpj_error_code(err C.int) *C.char {
+	if info, ok := errorInfos[err]; ok {
+		return info.code
+	}
+	return unknownError.code
+}
+
+//export cnpj_error_message
+func cnpj_error_message(err C.int) *C.char {
+	if info, ok := errorInfos[err]; ok {
+		return info.message
+	}
+	return unknownError.message
+}
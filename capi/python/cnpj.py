@@ -0,0 +1,81 @@
+"""Módulo ctypes mínimo sobre libcnpj.so (ver capi/cnpj.h).
+
+A biblioteca é procurada em $CNPJ_LIB ou ao lado do diretório capi/.
+Strings devolvidas pela biblioteca são copiadas para str do Python e
+liberadas imediatamente com cnpj_free.
+"""
+
+import ctypes
+import os
+
+CNPJ_OK = 0
+CNPJ_ERR_INVALID = 1
+CNPJ_ERR_NULL = 2
+CNPJ_ERR_BUFFER = 3
+CNPJ_DV_BUFFER_SIZE = 3
+
+_DEFAULT_PATH = os.path.join(os.path.dirname(os.path.abspath(__file__)), "..", "libcnpj.so")
+
+_lib = ctypes.CDLL(os.environ.get("CNPJ_LIB", _DEFAULT_PATH))
+
+_lib.cnpj_is_valid.argtypes = [ctypes.c_char_p]
+_lib.cnpj_is_valid.restype = ctypes.c_int
+
+_lib.cnpj_calculate_dv.argtypes = [ctypes.c_char_p, ctypes.c_char_p, ctypes.c_size_t]
+_lib.cnpj_calculate_dv.restype = ctypes.c_int
+
+# c_void_p (e não c_char_p) para que o ponteiro possa ser devolvido a cnpj_free
+_lib.cnpj_format.argtypes = [ctypes.c_char_p]
+_lib.cnpj_format.restype = ctypes.c_void_p
+
+_lib.cnpj_generate.argtypes = []
+_lib.cnpj_generate.restype = ctypes.c_void_p
+
+_lib.cnpj_free.argtypes = [ctypes.c_void_p]
+_lib.cnpj_free.restype = None
+
+_lib.cnpj_error_code.argtypes = [ctypes.c_int]
+_lib.cnpj_error_code.restype = ctypes.c_char_p
+
+_lib.cnpj_error_message.argtypes = [ctypes.c_int]
+_lib.cnpj_error_message.restype = ctypes.c_char_p
+
+
+class CNPJError(ValueError):
+    """Erro da biblioteca, com o código estável em ``code``."""
+
+    def __init__(self, err):
+        self.code = _lib.cnpj_error_code(err).decode()
+        super().__init__(_lib.cnpj_error_message(err).decode())
+
+
+def _take(ptr):
+    if not ptr:
+        return None
+    try:
+        return ctypes.string_at(ptr).decode()
+    finally:
+        _lib.cnpj_free(ptr)
+
+
+def is_valid(value):
+    return _lib.cnpj_is_valid(value.encode()) == 1
+
+
+def calculate_dv(value):
+    out = ctypes.create_string_buffer(CNPJ_DV_BUFFER_SIZE)
+    err = _lib.cnpj_calculate_dv(value.encode(), out, len(out))
+    if err != CNPJ_OK:
+        raise CNPJError(err)
+    return out.value.decode()
+
+
+def format(value):  # noqa: A001 - mesmo nome da função em Go
+    result = _take(_lib.cnpj_format(value.encode()))
+    if result is None:
+        raise CNPJError(CNPJ_ERR_INVALID)
+    return result
+
+
+def generate():
+    return _take(_lib.cnpj_generate())
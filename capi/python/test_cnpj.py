@@ -0,0 +1,33 @@
+import unittest
+
+import cnpj
+
+
+class TestCNPJ(unittest.TestCase):
+    def test_is_valid(self):
+        self.assertTrue(cnpj.is_valid("12.ABC.345/01DE-35"))
+        self.assertTrue(cnpj.is_valid("11222333000181"))
+        self.assertFalse(cnpj.is_valid("12.ABC.345/01DE-36"))
+        self.assertFalse(cnpj.is_valid(""))
+
+    def test_calculate_dv(self):
+        self.assertEqual(cnpj.calculate_dv("12ABC34501DE"), "35")
+        self.assertEqual(cnpj.calculate_dv("TK.10B.O3I/H1GA"), "13")
+
+    def test_calculate_dv_error(self):
+        with self.assertRaises(cnpj.CNPJError) as ctx:
+            cnpj.calculate_dv("000000000000")
+        self.assertEqual(ctx.exception.code, "CNPJ_DV_INVALIDO")
+
+    def test_format(self):
+        self.assertEqual(cnpj.format("12ABC34501DE35"), "12.ABC.345/01DE-35")
+        with self.assertRaises(cnpj.CNPJError):
+            cnpj.format("123")
+
+    def test_generate(self):
+        for _ in range(100):
+            self.assertTrue(cnpj.is_valid(cnpj.generate()))
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -25,14 +25,18 @@ package cmd
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/receita"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlitecnpj"
 	_ "github.com/lib/pq"
 	"github.com/spf13/cobra"
 	"io"
 	"log"
 	"net/http"
-	"os"
 )
 
 // CNPJRequest estrutura de requisição esperada
@@ -47,6 +51,7 @@ type CNPJResponse struct {
 	DV           string `json:"dv,omitempty"`
 	Valido       bool   `json:"valido"`
 	Erro         string `json:"erro,omitempty"`
+	Codigo       string `json:"codigo,omitempty"`
 }
 
 func NewCNPJResponse(value string) *CNPJResponse {
@@ -63,6 +68,7 @@ var (
 	pgUser     string
 	pgPassword string
 	pgDatabase string
+	rfSQLite   string
 )
 
 var apiCmd = &cobra.Command{
@@ -73,12 +79,19 @@ para validar, formatar e calcular DV de CNPJs alfanuméricos.
 
 Exemplo de chamada com curl:
 curl -X POST http://localhost:4400/api/cnpj/validate -H "Content-Type: application/json" -d '{"cnpj":"GIFZXOWDNZYM58"}'
+
+A rota GET /api/empresa/{cnpj} consulta a empresa nos Dados Abertos importados pelo
+comando import-rf, no mesmo PostgreSQL ou, com --rf-sqlite, em um arquivo SQLite. O CNPJ
+pode vir com ou sem máscara, pois a barra faz parte do caminho:
+curl http://localhost:4400/api/empresa/12.ABC.345/01DE-35
+
+A rota GET /api/cnpj/{cnpj}/empresa responde o mesmo, mas ali o CNPJ com máscara precisa
+da barra codificada como %2F (12.ABC.345%2F01DE-35).
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if pgHost == "" || pgUser == "" || pgPassword == "" || pgDatabase == "" {
-			cmd.Println("Erro: é necessário informar --pg-host, --pg-user, --pg-password e --pg-database")
 			_ = cmd.Usage()
-			os.Exit(1)
+			return errors.New(msg("api.flags"))
 		}
 
 		connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
@@ -86,26 +99,41 @@ curl -X POST http://localhost:4400/api/cnpj/validate -H "Content-Type: applicati
 
 		db, err := sql.Open("postgres", connStr)
 		if err != nil {
-			log.Fatal("Erro ao conectar no banco:", err)
+			return errors.New(msg("db.conectar", err))
 		}
 		defer func(db *sql.DB) {
 			_ = db.Close()
 		}(db)
 
 		if err := db.Ping(); err != nil {
-			log.Fatal("Não foi possível pingar o banco:", err)
+			return errors.New(msg("db.ping", err))
 		}
 
 		// Crear tabla si no existe
 		if _, err = db.Exec(`CREATE TABLE IF NOT EXISTS cnpjs (id SERIAL PRIMARY KEY,cnpj TEXT NOT NULL UNIQUE);`); err != nil {
-			log.Fatal(err)
+			return err
+		}
+
+		// Os dados de import-rf ficam no mesmo banco, a menos que --rf-sqlite aponte outro
+		rfDB, rfDialect := db, sqlddl.Postgres
+		if rfSQLite != "" {
+			if rfDB, err = sqlitecnpj.Open(rfSQLite); err != nil {
+				return errors.New(msg("db.conectar", err))
+			}
+			defer func(db *sql.DB) {
+				_ = db.Close()
+			}(rfDB)
+			rfDialect = sqlddl.SQLite
 		}
 
 		http.HandleFunc("GET /api/cnpj/generate", generateHandler(db))
+		http.HandleFunc("GET /api/cnpj/{cnpj}/empresa", empresaHandler(rfDB, rfDialect))
+		// o curinga no fim do caminho aceita a barra da máscara sem codificação
+		http.HandleFunc("GET /api/empresa/{cnpj...}", empresaHandler(rfDB, rfDialect))
 		http.HandleFunc("POST /api/cnpj/validate", validateHandler)
 
-		log.Println("🚀 Servidor iniciado em http://localhost:4400")
-		log.Fatal(http.ListenAndServe(":4400", nil))
+		log.Println(msg("api.iniciado"))
+		return http.ListenAndServe(":4400", nil)
 	},
 }
 
@@ -117,6 +145,7 @@ func init() {
 	apiCmd.Flags().StringVar(&pgUser, "pg-user", "", "PostgreSQL user")
 	apiCmd.Flags().StringVar(&pgPassword, "pg-password", "", "PostgreSQL password")
 	apiCmd.Flags().StringVar(&pgDatabase, "pg-database", "", "PostgreSQL database")
+	apiCmd.Flags().StringVar(&rfSQLite, "rf-sqlite", "", "arquivo SQLite com os Dados Abertos importados por import-rf")
 }
 
 func generateHandler(db *sql.DB) func(http.ResponseWriter, *http.Request) {
@@ -136,15 +165,13 @@ func generateHandler(db *sql.DB) func(http.ResponseWriter, *http.Request) {
 			cnpjValue = cnpj.GenerateCNPJ()
 
 			if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM cnpjs WHERE cnpj = $1)", cnpjValue).Scan(&exists); err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: "erro ao consultar o banco"})
+				writeError(w, r, http.StatusInternalServerError, "BANCO_CONSULTA")
 				return
 			}
 
 			if !exists {
 				if _, err := db.Exec("INSERT INTO cnpjs (cnpj) VALUES ($1)", cnpjValue); err != nil {
-					w.WriteHeader(http.StatusInternalServerError)
-					_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: "erro ao salvar no banco"})
+					writeError(w, r, http.StatusInternalServerError, "BANCO_GRAVACAO")
 					return
 				}
 				break
@@ -152,8 +179,7 @@ func generateHandler(db *sql.DB) func(http.ResponseWriter, *http.Request) {
 		}
 
 		if exists {
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: "não foi possível gerar um CNPJ único após várias tentativas"})
+			writeError(w, r, http.StatusInternalServerError, "CNPJ_UNICO_ESGOTADO")
 			return
 		}
 
@@ -177,14 +203,12 @@ func validateHandler(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: "request inválido"})
+		writeError(w, r, http.StatusInternalServerError, "REQUEST_INVALIDO")
 		return
 	}
 
 	if err := json.Unmarshal(body, &req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: "JSON inválido"})
+		writeError(w, r, http.StatusBadRequest, "JSON_INVALIDO")
 		return
 	}
 
@@ -192,9 +216,41 @@ func validateHandler(w http.ResponseWriter, r *http.Request) {
 	if newCNPJResponse.Valido {
 		newCNPJResponse.DV, err = cnpj.CalculateDV(newCNPJResponse.CNPJOriginal)
 		if err != nil {
-			newCNPJResponse.Erro = err.Error()
+			newCNPJResponse.Erro = i18n.Error(requestLang(r), err)
+			newCNPJResponse.Codigo = i18n.Code(err)
 		}
 	}
 
 	_ = json.NewEncoder(w).Encode(newCNPJResponse)
 }
+
+// empresaHandler responde com a empresa do CNPJ nos Dados Abertos importados:
+// 400 para um CNPJ inválido e 404 para um CNPJ que não está na base
+func empresaHandler(db *sql.DB, d sqlddl.Dialect) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		e, err := receita.Find(r.Context(), db, d, r.PathValue("cnpj"))
+		switch {
+		case errors.Is(err, receita.ErrNaoEncontrado):
+			writeError(w, r, http.StatusNotFound, i18n.Code(err))
+		case i18n.Code(err) != "":
+			writeError(w, r, http.StatusBadRequest, i18n.Code(err))
+		case err != nil:
+			writeError(w, r, http.StatusInternalServerError, "BANCO_CONSULTA")
+		default:
+			_ = json.NewEncoder(w).Encode(e)
+		}
+	}
+}
+
+// requestLang escolhe o idioma da resposta pelo cabeçalho Accept-Language
+func requestLang(r *http.Request) i18n.Lang {
+	return i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// writeError responde com o código de erro estável e a mensagem traduzida
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(CNPJResponse{Erro: i18n.T(requestLang(r), code), Codigo: code})
+}
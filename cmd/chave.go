@@ -0,0 +1,268 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/chave"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/spf13/cobra"
+)
+
+var chaveOpts batchOptions
+
+var chaveGenOpts struct {
+	count       int
+	seed        int64
+	uf          string
+	mes         string
+	cnpj        string
+	numeric     bool
+	modelo      string
+	serie       int
+	tipoEmissao string
+	masked      bool
+}
+
+// chaveRecord é o resultado de chave e chave generate nos formatos estruturados;
+// os campos ficam vazios quando a chave não tem 44 caracteres válidos
+type chaveRecord struct {
+	Indice         int    `json:"indice"`
+	Original       string `json:"original"`
+	Formatada      string `json:"formatada"`
+	Valida         bool   `json:"valida"`
+	Motivo         string `json:"motivo,omitempty"`
+	UF             string `json:"uf"`
+	Ano            int    `json:"ano"`
+	Mes            int    `json:"mes"`
+	CNPJ           string `json:"cnpj"`
+	Modelo         string `json:"modelo"`
+	Documento      string `json:"documento"`
+	Serie          string `json:"serie"`
+	Numero         string `json:"numero"`
+	TipoEmissao    string `json:"tipo_emissao"`
+	CodigoNumerico string `json:"codigo_numerico"`
+	DV             string `json:"dv"`
+}
+
+func newChaveRecord(valor string) chaveRecord {
+	c, err := chave.Parse(valor)
+	rec := chaveRecord{
+		Original:       valor,
+		Valida:         err == nil,
+		UF:             c.UF,
+		Ano:            c.Ano,
+		Mes:            c.Mes,
+		CNPJ:           c.CNPJ,
+		Modelo:         c.Modelo,
+		Documento:      c.Documento(),
+		Serie:          c.Serie,
+		Numero:         c.Numero,
+		TipoEmissao:    c.TipoEmissao,
+		CodigoNumerico: c.CodigoNumerico,
+		DV:             c.DV,
+	}
+	if c.Chave != "" {
+		rec.Formatada = chave.Format(c.Chave)
+	}
+	if err != nil {
+		rec.Motivo = i18n.Error(currentLang(), err)
+	}
+	return rec
+}
+
+// chaveText é a representação de uma chave no formato text
+func chaveText(rec chaveRecord) string {
+	if !rec.Valida {
+		shown := rec.Formatada
+		if shown == "" {
+			shown = rec.Original
+		}
+		return msg("chave.invalida", rec.Indice, shown, rec.Motivo)
+	}
+	return strings.Join([]string{
+		msg("chave.valida", rec.Indice, rec.Formatada),
+		msg("chave.documento", rec.Documento, number(rec.Numero), number(rec.Serie)),
+		msg("chave.emissao", rec.UF, rec.Mes, rec.Ano, chave.TiposEmissao[rec.TipoEmissao]),
+		msg("chave.emitente", cnpj.FormatCNPJ(rec.CNPJ)),
+	}, "\n")
+}
+
+// number remove os zeros à esquerda da série e do número, mantendo a série 0
+func number(s string) string {
+	if n, err := strconv.Atoi(s); err == nil {
+		return strconv.Itoa(n)
+	}
+	return s
+}
+
+// chaveCmd representa o comando 'chave'
+var chaveCmd = &cobra.Command{
+	Use:   "chave [CHAVE...]",
+	Short: "Valida e interpreta chaves de acesso de NF-e, CT-e e MDF-e",
+	Long: `Valida chaves de acesso de 44 posições de documentos fiscais eletrônicos (NF-e, NFC-e,
+CT-e, MDF-e e outros do mesmo leiaute) e separa os seus campos: UF, ano e mês de emissão,
+CNPJ do emitente (validado, inclusive alfanumérico), modelo, série, número, tipo de emissão
+e código numérico. As chaves podem vir com espaços ou com o prefixo do XML (NFe, CTe...).
+
+O comando termina com código 2 com --quiet ou --fail-on-invalid se alguma chave for inválida.
+
+Exemplos de uso:
+  ./app chave "3526 0712 ABC3 4500 0188 5500 1000 0123 4518 7654 3210"
+  ./app chave --file chaves.txt --output csv
+  ./app chave generate --count 10 --uf SP --modelo 65 --seed 42`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := openValues(cmd, args, chaveOpts.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = src.Close()
+		}()
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		total, invalidas := 0, 0
+
+		err = processOrdered(src, chaveOpts.workers, newChaveRecord, func(i int, _ string, rec chaveRecord) error {
+			total++
+			if !rec.Valida {
+				invalidas++
+			}
+			if chaveOpts.quiet {
+				return nil
+			}
+
+			rec.Indice = i + 1
+			return out.Write(rec, chaveText(rec))
+		})
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		if total == 0 && !chaveOpts.quiet {
+			cmd.PrintErrln(plain(msg("chave.nenhuma")))
+		}
+		if invalidas > 0 && (chaveOpts.quiet || chaveOpts.failOnInvalid) {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+// chaveGenerateCmd representa o comando 'chave generate'
+var chaveGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Gera chaves de acesso válidas para testes",
+	Long: `Gera chaves de acesso válidas, com DV correto e emitente com CNPJ válido. Os campos não
+informados são sorteados (UF, série, número, código numérico e CNPJ) ou recebem o padrão:
+modelo 55 (NF-e), emissão normal e o mês atual. Com --seed a saída é sempre a mesma.
+
+Exemplos de uso:
+  ./app chave generate
+  ./app chave generate --count 100 --seed 42 --uf SP --mes 2026-07 --masked
+  ./app chave generate --cnpj 12.ABC.345/0001-88 --modelo 57 --serie 1 --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o := chaveGenOpts
+		if o.count < 1 {
+			return errors.New(msg("flag.count"))
+		}
+		opts := chave.GenerateOptions{
+			UF:          o.uf,
+			CNPJ:        o.cnpj,
+			Numeric:     o.numeric,
+			Modelo:      o.modelo,
+			Serie:       o.serie,
+			TipoEmissao: o.tipoEmissao,
+		}
+		if o.mes != "" {
+			emissao, err := time.Parse("2006-01", o.mes)
+			if err != nil {
+				return errors.New(msg("chave.mes", o.mes))
+			}
+			opts.Emissao = emissao
+		}
+
+		seed := o.seed
+		if !cmd.Flags().Changed("seed") {
+			seed = time.Now().UnixNano()
+		}
+		r := rand.New(rand.NewSource(seed))
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < o.count; i++ {
+			valor, err := chave.Generate(r, opts)
+			if err != nil {
+				_ = out.Close()
+				return errors.New(i18n.Error(currentLang(), err))
+			}
+
+			rec := newChaveRecord(valor)
+			rec.Indice = i + 1
+			text := valor
+			if o.masked {
+				text = rec.Formatada
+			}
+			if err := out.Write(rec, text); err != nil {
+				return err
+			}
+		}
+		return out.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(chaveCmd)
+	chaveCmd.AddCommand(chaveGenerateCmd)
+
+	addBatchFlags(chaveCmd, &chaveOpts)
+
+	f := chaveGenerateCmd.Flags()
+	f.IntVar(&chaveGenOpts.count, "count", 1, "quantidade de chaves a gerar")
+	f.Int64Var(&chaveGenOpts.seed, "seed", 0, "semente para uma geração reprodutível")
+	f.StringVar(&chaveGenOpts.uf, "uf", "", "sigla da UF do emitente (padrão: sorteada)")
+	f.StringVar(&chaveGenOpts.mes, "mes", "", "ano e mês de emissão, no formato AAAA-MM (padrão: mês atual)")
+	f.StringVar(&chaveGenOpts.cnpj, "cnpj", "", "CNPJ do emitente (padrão: um CNPJ de matriz sorteado)")
+	f.BoolVar(&chaveGenOpts.numeric, "numeric", false, "sorteia emitentes com CNPJ numérico")
+	f.StringVar(&chaveGenOpts.modelo, "modelo", "55", "modelo do documento: 55 NF-e, 57 CT-e, 58 MDF-e, 65 NFC-e...")
+	f.IntVar(&chaveGenOpts.serie, "serie", -1, "série do documento, de 0 a 999 (padrão: sorteada)")
+	f.StringVar(&chaveGenOpts.tipoEmissao, "tipo-emissao", "1", "tipo de emissão (tpEmis), de 1 a 9")
+	f.BoolVar(&chaveGenOpts.masked, "masked", false, "escreve as chaves em grupos de 4 caracteres")
+	chaveGenerateCmd.MarkFlagsMutuallyExclusive("cnpj", "numeric")
+}
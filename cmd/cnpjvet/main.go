@@ -0,0 +1,15 @@
+// Comando cnpjvet executa o analisador de pkg/cnpjvet sobre pacotes Go, apontando
+// código que trata o CNPJ como número. Use-o como ferramenta do go vet:
+//
+//	go vet -vettool=$(which cnpjvet) ./...
+//	go vet -vettool=$(which cnpjvet) -fix ./...
+package main
+
+import (
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpjvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(cnpjvet.Analyzer)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/conformance"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conformanceVectors []string
+	conformanceExec    string
+	conformanceServe   bool
+	conformanceDump    bool
+	conformanceVerbose bool
+)
+
+// conformanceCmd representa o comando 'conformance'
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "Verifica o cálculo de DV contra vetores de referência",
+	Long: `Executa os vetores de referência do CNPJ alfanumérico contra esta biblioteca
+ou contra uma implementação externa que fale o protocolo de linhas JSON em stdin/stdout.
+
+Exemplos de uso:
+  ./app conformance
+  ./app conformance --vectors vetores.json
+  ./app conformance --exec "node cnpj-impl.js"
+  ./app conformance --serve     # atua como implementação de referência
+  ./app conformance --dump      # exporta os vetores embutidos
+  ./app conformance --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if conformanceServe {
+			return conformance.Serve(cmd.InOrStdin(), cmd.OutOrStdout(), conformance.Library)
+		}
+
+		suites := []*conformance.Suite{conformance.Default()}
+		if len(conformanceVectors) > 0 {
+			suites = suites[:0]
+			for _, path := range conformanceVectors {
+				suite, err := loadSuite(path)
+				if err != nil {
+					return err
+				}
+				suites = append(suites, suite)
+			}
+		}
+
+		if conformanceDump {
+			for _, suite := range suites {
+				if err := suite.Encode(cmd.OutOrStdout()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		impl := conformance.Library
+		if cmd.Flags().Changed("exec") {
+			fields := strings.Fields(conformanceExec)
+			if len(fields) == 0 {
+				return errors.New(msg("conformance.exec_vazio"))
+			}
+			proc, err := conformance.StartProcess(fields[0], fields[1:]...)
+			if err != nil {
+				return errors.New(msg("conformance.exec", err))
+			}
+			defer func() {
+				_ = proc.Close()
+			}()
+			impl = proc
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, suite := range suites {
+			report := conformance.Run(suite, impl)
+			for i, r := range report.Results {
+				rec := conformanceRecord{
+					Suite:          report.Suite,
+					Indice:         i + 1,
+					Input:          r.Vector.Input,
+					DV:             r.GotDV,
+					Valido:         r.GotValid,
+					EsperadoDV:     r.Vector.DV,
+					EsperadoValido: r.Vector.Valid,
+					Aprovado:       r.Pass(),
+					Notas:          r.Vector.Notes,
+				}
+				if r.Err != nil {
+					rec.Erro = r.Err.Error()
+				}
+
+				if out.Text() && r.Pass() && !conformanceVerbose {
+					continue
+				}
+				if err := out.Write(rec, rec.text()); err != nil {
+					return err
+				}
+			}
+
+			if out.Text() {
+				if err := out.Write(nil, msg("conformance.resumo", report.Suite, suite.Version, report.Passed, report.Failed)); err != nil {
+					return err
+				}
+			}
+			failed += report.Failed
+		}
+
+		if err := out.Close(); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(conformanceCmd)
+
+	conformanceCmd.Flags().StringSliceVar(&conformanceVectors, "vectors", nil, "arquivo(s) de vetores no formato JSON versionado")
+	conformanceCmd.Flags().StringVar(&conformanceExec, "exec", "", "comando de uma implementação externa que fala o protocolo em stdin/stdout")
+	conformanceCmd.Flags().BoolVar(&conformanceServe, "serve", false, "atende o protocolo em stdin/stdout usando esta biblioteca")
+	conformanceCmd.Flags().BoolVar(&conformanceDump, "dump", false, "escreve os vetores em stdout em vez de executá-los")
+	conformanceCmd.Flags().BoolVar(&conformanceVerbose, "verbose", false, "mostra também os vetores aprovados")
+}
+
+// conformanceRecord é o resultado de um vetor nos formatos estruturados
+type conformanceRecord struct {
+	Suite          string `json:"suite"`
+	Indice         int    `json:"indice"`
+	Input          string `json:"input"`
+	DV             string `json:"dv"`
+	Valido         bool   `json:"valido"`
+	EsperadoDV     string `json:"esperado_dv"`
+	EsperadoValido bool   `json:"esperado_valido"`
+	Aprovado       bool   `json:"aprovado"`
+	Notas          string `json:"notas"`
+	Erro           string `json:"erro"`
+}
+
+func (r conformanceRecord) text() string {
+	if r.Aprovado {
+		return msg("conformance.aprovado", r.Indice, r.Input, r.DV, r.Valido)
+	}
+
+	text := msg("conformance.reprovado", r.Indice, r.Input, r.DV, r.Valido, r.EsperadoDV, r.EsperadoValido, r.Notas)
+	if r.Erro != "" {
+		text += "\n    ⚠️  " + r.Erro
+	}
+	return text
+}
+
+func loadSuite(path string) (*conformance.Suite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	return conformance.Load(f)
+}
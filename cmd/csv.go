@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/csvcnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/spf13/cobra"
+)
+
+var csvOpts struct {
+	column    string
+	delimiter string
+	encoding  string
+	noHeader  bool
+	inPlace   bool
+	out       string
+	summary   bool
+}
+
+// csvCmd representa o comando 'csv'
+var csvCmd = &cobra.Command{
+	Use:   "csv [arquivo]",
+	Short: "Valida a coluna de CNPJ de um arquivo CSV/TSV",
+	Long: `Localiza a coluna de CNPJ de um arquivo CSV ou TSV e reescreve o arquivo com as colunas
+cnpj_normalizado, cnpj_formatado, cnpj_valido, cnpj_erro e cnpj_sugestao.
+
+A coluna, o delimitador e a codificação (UTF-8 ou Latin-1) são detectados automaticamente
+quando não informados. Com --in-place o arquivo é reescrito com a coluna normalizada.
+
+Exemplos de uso:
+  ./app csv fornecedores.csv > fornecedores-validados.csv
+  ./app csv --column documento --delimiter ';' fornecedores.csv
+  ./app csv --column 3 --no-header --encoding latin1 export.txt
+  ./app csv --in-place --summary fornecedores.csv
+  cat fornecedores.csv | ./app csv --summary --output json > /dev/null`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := csvOptions()
+		if err != nil {
+			return err
+		}
+
+		path := "-"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if csvOpts.inPlace && path == "-" {
+			return errors.New(msg("flag.in_place"))
+		}
+		if csvOpts.inPlace && csvOpts.out != "" {
+			return errors.New(msg("csv.in_place_out"))
+		}
+
+		var in io.Reader = cmd.InOrStdin()
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			in = f
+		}
+
+		var summary csvcnpj.Summary
+		switch {
+		case csvOpts.inPlace:
+			summary, err = csvRewrite(path, in, opts)
+		case csvOpts.out != "":
+			summary, err = csvRewrite(csvOpts.out, in, opts)
+		default:
+			summary, err = csvcnpj.Process(in, cmd.OutOrStdout(), opts)
+		}
+		if err != nil {
+			return err
+		}
+
+		if csvOpts.summary {
+			return writeCSVSummary(cmd, summary)
+		}
+		return nil
+	},
+}
+
+// csvOptions converte as flags do comando nas opções do processamento
+func csvOptions() (csvcnpj.Options, error) {
+	opts := csvcnpj.Options{
+		Column:    csvOpts.column,
+		NoHeader:  csvOpts.noHeader,
+		Normalize: csvOpts.inPlace,
+		ErrorText: func(err error) string { return i18n.Error(currentLang(), err) },
+	}
+
+	enc, ok := csvcnpj.ParseEncoding(csvOpts.encoding)
+	if !ok {
+		return opts, errors.New(msg("csv.codificacao", csvOpts.encoding))
+	}
+	opts.Encoding = enc
+
+	delim, err := parseDelimiter(csvOpts.delimiter)
+	opts.Delimiter = delim
+	return opts, err
+}
+
+// parseDelimiter converte --delimiter em rune; zero (vazio ou auto) pede a detecção automática
+func parseDelimiter(d string) (rune, error) {
+	switch {
+	case d == "" || d == "auto":
+		return 0, nil
+	case d == `\t` || strings.EqualFold(d, "tab"):
+		return '\t', nil
+	case utf8.RuneCountInString(d) == 1:
+		r, _ := utf8.DecodeRuneInString(d)
+		return r, nil
+	}
+	return 0, errors.New(msg("csv.delimitador", d))
+}
+
+// csvRewrite grava o resultado do processamento em dest sem deixá-lo pela metade em caso de erro
+func csvRewrite(dest string, in io.Reader, opts csvcnpj.Options) (csvcnpj.Summary, error) {
+	var summary csvcnpj.Summary
+	err := replaceFile(dest, func(w io.Writer) error {
+		var err error
+		summary, err = csvcnpj.Process(in, w, opts)
+		return err
+	})
+	return summary, err
+}
+
+// writeCSVSummary imprime o resumo na saída de erro, para não se misturar ao CSV gerado
+func writeCSVSummary(cmd *cobra.Command, summary csvcnpj.Summary) error {
+	out, err := newRecordWriterTo(cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	delim := summary.Delimitador
+	if delim == "\t" {
+		delim = `\t`
+	}
+	text := msg("csv.resumo", summary.Linhas, summary.Validos, summary.Invalidos, summary.Corrigiveis) + "\n" +
+		msg("csv.deteccao", summary.Coluna, delim, summary.Codificacao)
+
+	codes := make([]string, 0, len(summary.Motivos))
+	for code := range summary.Motivos {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		text += "\n" + msg("csv.motivo", summary.Motivos[code], i18n.T(currentLang(), code))
+	}
+
+	if err := out.Write(summary, text); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(csvCmd)
+
+	csvCmd.Flags().StringVarP(&csvOpts.column, "column", "c", "", "nome ou índice (a partir de 1) da coluna de CNPJ; detectada automaticamente se omitido")
+	csvCmd.Flags().StringVar(&csvOpts.delimiter, "delimiter", "auto", "delimitador dos campos (auto, ',', ';', tab, '|')")
+	csvCmd.Flags().StringVar(&csvOpts.encoding, "encoding", "auto", "codificação do arquivo (auto, utf-8, latin1)")
+	csvCmd.Flags().BoolVar(&csvOpts.noHeader, "no-header", false, "indica que o arquivo não tem linha de cabeçalho")
+	csvCmd.Flags().BoolVar(&csvOpts.inPlace, "in-place", false, "reescreve o arquivo com a coluna de CNPJ normalizada")
+	csvCmd.Flags().StringVar(&csvOpts.out, "out", "", "grava o resultado neste arquivo em vez da saída padrão")
+	csvCmd.Flags().BoolVar(&csvOpts.summary, "summary", false, "imprime um resumo da análise na saída de erro")
+}
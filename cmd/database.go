@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlitecnpj"
+	"github.com/spf13/cobra"
+)
+
+// dbOptions são as flags de conexão dos comandos que acessam um banco: as
+// mesmas --pg-* do comando api ou o caminho de um arquivo SQLite
+type dbOptions struct {
+	pgHost     string
+	pgPort     int
+	pgUser     string
+	pgPassword string
+	pgDatabase string
+	sqlite     string
+}
+
+func addDBFlags(cmd *cobra.Command, o *dbOptions) {
+	cmd.Flags().StringVar(&o.pgHost, "pg-host", "", "PostgreSQL host")
+	cmd.Flags().IntVar(&o.pgPort, "pg-port", 5432, "PostgreSQL port")
+	cmd.Flags().StringVar(&o.pgUser, "pg-user", "", "PostgreSQL user")
+	cmd.Flags().StringVar(&o.pgPassword, "pg-password", "", "PostgreSQL password")
+	cmd.Flags().StringVar(&o.pgDatabase, "pg-database", "", "PostgreSQL database")
+	cmd.Flags().StringVar(&o.sqlite, "sqlite", "", "arquivo do banco SQLite")
+}
+
+// configured indica se alguma conexão foi informada
+func (o *dbOptions) configured() bool {
+	return o.sqlite != "" || o.pgHost != ""
+}
+
+// open conecta ao banco informado e retorna também o dialeto correspondente. No
+// SQLite as funções de pkg/sqlitecnpj (cnpj_valid, cnpj_dv...) ficam disponíveis.
+func (o *dbOptions) open(ctx context.Context) (*sql.DB, sqlddl.Dialect, error) {
+	var (
+		db      *sql.DB
+		dialect sqlddl.Dialect
+		err     error
+	)
+	switch {
+	case o.sqlite != "":
+		dialect = sqlddl.SQLite
+		db, err = sqlitecnpj.Open(o.sqlite)
+	case o.pgHost != "" && o.pgUser != "" && o.pgPassword != "" && o.pgDatabase != "":
+		dialect = sqlddl.Postgres
+		db, err = sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+			o.pgHost, o.pgPort, o.pgUser, o.pgPassword, o.pgDatabase))
+	default:
+		return nil, "", errors.New(msg("db.flags"))
+	}
+	if err != nil {
+		return nil, "", errors.New(msg("db.conectar", err))
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, "", errors.New(msg("db.ping", err))
+	}
+	return db, dialect, nil
+}
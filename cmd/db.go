@@ -0,0 +1,438 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/dbaudit"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/readiness"
+	"github.com/spf13/cobra"
+)
+
+var dbOpts struct {
+	db         dbOptions
+	table      string
+	column     string
+	key        string
+	batch      int
+	dryRun     bool
+	checkpoint string
+	resume     bool
+	log        string
+	tables     []string
+	sample     int
+	plan       string
+}
+
+// dbCheckpoint é o arquivo que permite retomar uma normalização interrompida
+type dbCheckpoint struct {
+	Tabela      string `json:"tabela"`
+	Coluna      string `json:"coluna"`
+	ChaveColuna string `json:"chave_coluna"`
+	UltimaChave string `json:"ultima_chave"`
+}
+
+// auditRecord é uma linha inválida encontrada por 'db audit'
+type auditRecord struct {
+	Chave        string `json:"chave"`
+	Valor        string `json:"valor"`
+	Codigo       string `json:"codigo"`
+	Motivo       string `json:"motivo"`
+	Normalizavel bool   `json:"normalizavel"`
+	Sugestao     string `json:"sugestao"`
+}
+
+// readinessRecord é uma coluna de CNPJ examinada por 'db readiness'
+type readinessRecord struct {
+	Tabela    string   `json:"tabela"`
+	Coluna    string   `json:"coluna"`
+	Tipo      string   `json:"tipo"`
+	Deteccao  string   `json:"deteccao"`
+	Pronta    bool     `json:"pronta"`
+	Problemas []string `json:"problemas"`
+	Detalhes  []string `json:"detalhes"`
+}
+
+// dbCmd representa o comando 'db', que agrupa as operações sobre bancos
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Audita e normaliza colunas de CNPJ em bancos PostgreSQL e SQLite",
+	Long: `Operações sobre uma coluna de CNPJ em uma tabela do PostgreSQL (flags --pg-*, as mesmas
+do comando api) ou de um arquivo SQLite (--sqlite). A tabela é lida em lotes ordenados
+pela coluna --key (padrão: id no PostgreSQL e rowid no SQLite).
+
+  • audit     → lista as linhas com CNPJ inválido e o motivo
+  • normalize → grava a forma canônica (sem máscara, em maiúsculas), um lote por transação
+  • rollback  → desfaz uma normalização a partir do seu log
+  • readiness → verifica se o schema aceita CNPJs alfanuméricos e gera o plano de migração
+
+Exemplos de uso:
+  ./app db audit --sqlite dados.db --table fornecedores --column doc
+  ./app db audit --pg-host localhost --pg-user u --pg-password p --pg-database d --table clientes --column cnpj --output csv
+  ./app db normalize --sqlite dados.db --table fornecedores --column doc --dry-run
+  ./app db normalize --sqlite dados.db --table fornecedores --column doc --checkpoint norm.json --log norm.ndjson
+  ./app db normalize --sqlite dados.db --table fornecedores --column doc --checkpoint norm.json --log norm.ndjson --resume
+  ./app db rollback --sqlite dados.db --log norm.ndjson
+  ./app db readiness --sqlite dados.db --plan migracao.sql`,
+}
+
+var dbAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Lista as linhas com CNPJ inválido e o motivo",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dbOpts.table == "" || dbOpts.column == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("sql.tabela"))
+		}
+		db, dialect, err := dbOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		target := dbaudit.Target{Dialect: dialect, Table: dbOpts.table, Column: dbOpts.column, Key: dbOpts.key}
+		summary, err := dbaudit.Audit(cmd.Context(), db, target, dbOpts.batch, func(f dbaudit.Finding) error {
+			rec := auditRecord{
+				Chave: f.Chave, Valor: f.Valor, Codigo: f.Codigo, Motivo: i18n.Error(currentLang(), f.Erro),
+				Normalizavel: f.Normalizavel, Sugestao: f.Sugestao,
+			}
+			return out.Write(rec, msg("db.invalido", rec.Chave, rec.Valor, rec.Motivo))
+		})
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := writeAuditSummary(cmd, summary); err != nil {
+			return err
+		}
+		if summary.Invalidos > 0 {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+var dbNormalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Grava a forma canônica dos CNPJs, com checkpoint e log de rollback",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dbOpts.table == "" || dbOpts.column == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("sql.tabela"))
+		}
+		if !dbOpts.dryRun && dbOpts.log == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("db.log"))
+		}
+		if dbOpts.resume && dbOpts.checkpoint == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("db.checkpoint"))
+		}
+
+		db, dialect, err := dbOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		target := dbaudit.Target{Dialect: dialect, Table: dbOpts.table, Column: dbOpts.column, Key: dbOpts.key}
+		opts := dbaudit.NormalizeOptions{Batch: dbOpts.batch, DryRun: dbOpts.dryRun}
+
+		checkpoint := dbCheckpoint{Tabela: dbOpts.table, Coluna: dbOpts.column, ChaveColuna: dbOpts.key}
+		if dbOpts.resume {
+			if checkpoint, err = loadCheckpoint(dbOpts.checkpoint); err != nil {
+				return err
+			}
+			if checkpoint.Tabela != dbOpts.table || checkpoint.Coluna != dbOpts.column || checkpoint.ChaveColuna != dbOpts.key {
+				return errors.New(msg("db.checkpoint.outro", dbOpts.checkpoint, checkpoint.Tabela, checkpoint.Coluna))
+			}
+			opts.After = checkpoint.UltimaChave
+		}
+		if dbOpts.checkpoint != "" && !dbOpts.dryRun {
+			opts.Checkpoint = func(last string) error {
+				checkpoint.UltimaChave = last
+				return replaceFile(dbOpts.checkpoint, func(w io.Writer) error {
+					return json.NewEncoder(w).Encode(checkpoint)
+				})
+			}
+		}
+
+		if !dbOpts.dryRun {
+			// O log é aberto para acréscimo: uma normalização retomada continua o mesmo arquivo
+			f, err := os.OpenFile(dbOpts.log, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			opts.Log = f
+		}
+
+		// Só o --dry-run lista as alterações; fora dele elas vão para o log
+		var report func(dbaudit.Change) error
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if dbOpts.dryRun {
+			report = func(c dbaudit.Change) error {
+				return out.Write(c, msg("db.alteracao", c.Chave, c.Antes, c.Depois))
+			}
+		}
+		summary, err := dbaudit.Normalize(cmd.Context(), db, target, opts, report)
+		if dbOpts.dryRun {
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			if summary.UltimaChave != "" && dbOpts.checkpoint != "" && !dbOpts.dryRun {
+				return fmt.Errorf("%w (%s)", err, msg("db.retomar", dbOpts.checkpoint))
+			}
+			return err
+		}
+
+		result, err := newRecordWriterTo(cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		key := "db.normalizado"
+		if dbOpts.dryRun {
+			key = "db.normalizado.dryrun"
+		}
+		if err := result.Write(summary, msg(key, summary.Lidos, summary.Alterados, summary.Ignorados, summary.Nulos)); err != nil {
+			return err
+		}
+		return result.Close()
+	},
+}
+
+var dbRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Desfaz uma normalização a partir do log gravado por 'db normalize'",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dbOpts.log == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("db.log"))
+		}
+		f, err := os.Open(dbOpts.log)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		db, _, err := dbOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		n, err := dbaudit.Rollback(cmd.Context(), db, f)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), plain(msg("db.restaurados", n)))
+		return err
+	},
+}
+
+var dbReadinessCmd = &cobra.Command{
+	Use:   "readiness",
+	Short: "Verifica se o schema aceita CNPJs alfanuméricos e gera o plano de migração",
+	Long: `Examina os metadados do banco (information_schema e pg_catalog no PostgreSQL, pragma e
+sqlite_master no SQLite) e localiza as colunas de CNPJ pelo nome (cnpj, cgc) ou por
+amostragem do conteúdo. Para cada uma aponta tipos numéricos, tamanhos menores que 14,
+restrições CHECK, domínios e gatilhos que só aceitam dígitos e índices que convertem o valor
+para número. O plano de migração sai no terminal ou, com --plan, em um arquivo SQL.
+
+O comando termina com código 2 se alguma coluna não estiver pronta.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, dialect, err := dbOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		report, err := readiness.Inspect(cmd.Context(), db, dialect, readiness.Options{Sample: dbOpts.sample, Tables: dbOpts.tables})
+		if err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		for _, c := range report.Colunas {
+			rec := readinessRecord{
+				Tabela: c.QualifiedTable(), Coluna: c.Coluna, Tipo: c.Tipo, Deteccao: "nome", Pronta: c.Ready(),
+				Problemas: []string{}, Detalhes: []string{},
+			}
+			if !c.PorNome {
+				rec.Deteccao = "conteudo"
+			}
+			for _, p := range c.Problemas {
+				rec.Problemas = append(rec.Problemas, p.Codigo)
+				rec.Detalhes = append(rec.Detalhes, p.Detalhe)
+			}
+			if err := out.Write(rec, readinessText(c)); err != nil {
+				return err
+			}
+		}
+
+		plan := strings.Join(report.Plano, "\n")
+		switch {
+		case len(report.Colunas) == 0 && out.Text():
+			if err := out.Write(nil, msg("db.readiness.nenhuma")); err != nil {
+				return err
+			}
+		case dbOpts.plan != "":
+			err := replaceFile(dbOpts.plan, func(w io.Writer) error {
+				_, err := io.WriteString(w, plan)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		case plan != "" && out.Text():
+			if err := out.Write(nil, msg("db.readiness.plano")+"\n\n"+plan); err != nil {
+				return err
+			}
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+
+		if !report.Ready() {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+// readinessText descreve uma coluna e seus problemas no formato text
+func readinessText(c readiness.Column) string {
+	name := c.QualifiedTable() + "." + c.Coluna
+	detection := msg("db.readiness.nome")
+	if !c.PorNome {
+		detection = msg("db.readiness.conteudo", c.ParecemCNPJ, c.Amostra)
+	}
+	if c.Ready() {
+		return msg("db.readiness.pronta", name, c.Tipo, detection)
+	}
+
+	text := msg("db.readiness.problemas", name, c.Tipo, detection)
+	for _, p := range c.Problemas {
+		text += fmt.Sprintf("\n   %s  %s", p.Codigo, p.Detalhe)
+	}
+	return text
+}
+
+func loadCheckpoint(path string) (dbCheckpoint, error) {
+	var c dbCheckpoint
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("checkpoint %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// writeAuditSummary imprime o resumo na saída de erro, para não se misturar às linhas
+func writeAuditSummary(cmd *cobra.Command, s dbaudit.Summary) error {
+	out, err := newRecordWriterTo(cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	text := msg("db.resumo", s.Total, s.Validos, s.Nulos, s.Invalidos, s.Normalizaveis)
+	codes := make([]string, 0, len(s.Motivos))
+	for code := range s.Motivos {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		text += "\n" + msg("csv.motivo", s.Motivos[code], i18n.T(currentLang(), code))
+	}
+
+	if err := out.Write(s, text); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbAuditCmd, dbNormalizeCmd, dbRollbackCmd, dbReadinessCmd)
+
+	for _, c := range []*cobra.Command{dbAuditCmd, dbNormalizeCmd, dbRollbackCmd, dbReadinessCmd} {
+		addDBFlags(c, &dbOpts.db)
+	}
+	for _, c := range []*cobra.Command{dbAuditCmd, dbNormalizeCmd} {
+		c.Flags().StringVar(&dbOpts.table, "table", "", "tabela que contém a coluna")
+		c.Flags().StringVar(&dbOpts.column, "column", "", "coluna de CNPJ")
+		c.Flags().StringVar(&dbOpts.key, "key", "", "coluna que ordena os lotes (padrão: id no PostgreSQL, rowid no SQLite)")
+		c.Flags().IntVar(&dbOpts.batch, "batch", dbaudit.DefaultBatch, "linhas por lote")
+	}
+
+	dbNormalizeCmd.Flags().BoolVar(&dbOpts.dryRun, "dry-run", false, "apenas lista as alterações, sem gravar")
+	dbNormalizeCmd.Flags().StringVar(&dbOpts.checkpoint, "checkpoint", "", "arquivo com a última chave confirmada, atualizado a cada lote")
+	dbNormalizeCmd.Flags().BoolVar(&dbOpts.resume, "resume", false, "retoma a partir do --checkpoint")
+	dbNormalizeCmd.Flags().StringVar(&dbOpts.log, "log", "", "log de rollback (NDJSON), obrigatório fora do --dry-run")
+	dbRollbackCmd.Flags().StringVar(&dbOpts.log, "log", "", "log gravado por 'db normalize --log'")
+
+	dbReadinessCmd.Flags().StringSliceVar(&dbOpts.tables, "table", nil, "examina apenas estas tabelas (pode ser repetida)")
+	dbReadinessCmd.Flags().IntVar(&dbOpts.sample, "sample", readiness.DefaultSample, "valores lidos por coluna para a detecção pelo conteúdo (0 desliga)")
+	dbReadinessCmd.Flags().StringVar(&dbOpts.plan, "plan", "", "grava o plano de migração neste arquivo SQL")
+}
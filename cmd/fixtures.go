@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/fixtures"
+	"github.com/spf13/cobra"
+)
+
+var fixturesOpts struct {
+	count   int
+	seed    int64
+	numeric bool
+	filiais int
+	socios  int
+	sql     bool
+	out     string
+}
+
+// fixtureRow é um estabelecimento (matriz ou filial) nos formatos csv e tsv
+type fixtureRow struct {
+	CNPJ              string   `json:"cnpj"`
+	CNPJMatriz        string   `json:"cnpj_matriz"`
+	Matriz            bool     `json:"matriz"`
+	RazaoSocial       string   `json:"razao_social"`
+	NomeFantasia      string   `json:"nome_fantasia"`
+	NaturezaJuridica  string   `json:"natureza_juridica"`
+	DataAbertura      string   `json:"data_abertura"`
+	CNAEPrincipal     string   `json:"cnae_principal"`
+	CNAESecundarios   []string `json:"cnaes_secundarios"`
+	InscricaoEstadual string   `json:"inscricao_estadual"`
+	Logradouro        string   `json:"logradouro"`
+	Numero            string   `json:"numero"`
+	Bairro            string   `json:"bairro"`
+	Municipio         string   `json:"municipio"`
+	UF                string   `json:"uf"`
+	CEP               string   `json:"cep"`
+	Socios            []string `json:"socios"`
+}
+
+// fixturesCmd representa o comando 'fixtures'
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures",
+	Short: "Gera empresas fictícias completas para popular ambientes de teste",
+	Long: `Gera empresas fictícias consistentes entre si: CNPJ da matriz e das filiais (mesma raiz),
+razão social e nome fantasia, endereço com CEP da faixa do estado, CNAE principal e secundários,
+Inscrição Estadual, data de abertura (a partir de 07/2026 para raízes alfanuméricas) e sócios com
+CPFs válidos. Com --seed a saída é sempre a mesma.
+
+Formatos: --output json/ndjson (empresas com sócios e filiais aninhados), csv/tsv (um
+estabelecimento por linha) ou --sql (CREATE TABLE e INSERTs para PostgreSQL ou SQLite).
+
+Exemplos de uso:
+  ./app fixtures --count 5 --seed 42
+  ./app fixtures --count 100 --seed 42 --output json --out empresas.json
+  ./app fixtures --count 1000 --filiais 5 --output csv > estabelecimentos.csv
+  ./app fixtures --count 500 --numeric --sql | sqlite3 qa.db`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fixturesOpts.count < 1 {
+			return errors.New(msg("flag.count"))
+		}
+		if fixturesOpts.filiais < 0 || fixturesOpts.filiais > fixtures.MaxFiliais {
+			return errors.New(msg("fixtures.filiais", fixtures.MaxFiliais))
+		}
+		if fixturesOpts.socios < 1 {
+			return errors.New(msg("fixtures.socios_flag"))
+		}
+
+		opts := fixtures.Options{
+			Seed:       fixturesOpts.seed,
+			Numeric:    fixturesOpts.numeric,
+			MaxFiliais: fixturesOpts.filiais,
+			MaxSocios:  fixturesOpts.socios,
+		}
+		if !cmd.Flags().Changed("seed") {
+			opts.Seed = time.Now().UnixNano()
+		}
+
+		if fixturesOpts.out == "" {
+			return writeFixtures(cmd.OutOrStdout(), opts)
+		}
+		return replaceFile(fixturesOpts.out, func(w io.Writer) error {
+			return writeFixtures(w, opts)
+		})
+	},
+}
+
+// writeFixtures gera --count empresas e as escreve em w no formato escolhido
+func writeFixtures(w io.Writer, opts fixtures.Options) error {
+	g := fixtures.New(opts)
+
+	if fixturesOpts.sql {
+		out := fixtures.NewSQLWriter(w)
+		for i := 0; i < fixturesOpts.count; i++ {
+			if err := out.Write(g.Next()); err != nil {
+				return err
+			}
+		}
+		return out.Close()
+	}
+
+	out, err := newRecordWriterTo(w)
+	if err != nil {
+		return err
+	}
+	// em csv e tsv cada estabelecimento é uma linha; nos demais formatos a empresa vai inteira
+	rows := outputTemplate == "" && (outputFormat == "csv" || outputFormat == "tsv")
+	for i := 0; i < fixturesOpts.count; i++ {
+		e := g.Next()
+		if !rows {
+			if err := out.Write(e, fixtureText(e)); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, r := range fixtureRows(e) {
+			if err := out.Write(r, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return out.Close()
+}
+
+// fixtureRows achata a empresa em um registro por estabelecimento, a matriz primeiro
+func fixtureRows(e fixtures.Empresa) []fixtureRow {
+	cnaes := make([]string, len(e.CNAESecundarios))
+	for i, c := range e.CNAESecundarios {
+		cnaes[i] = c.Codigo
+	}
+	socios := make([]string, len(e.Socios))
+	for i, s := range e.Socios {
+		socios[i] = s.CPF + ":" + s.Nome
+	}
+
+	row := func(cnpj, fantasia, abertura, ie string, a fixtures.Endereco) fixtureRow {
+		return fixtureRow{
+			CNPJ: cnpj, CNPJMatriz: e.CNPJ, Matriz: cnpj == e.CNPJ, RazaoSocial: e.RazaoSocial,
+			NomeFantasia: fantasia, NaturezaJuridica: e.NaturezaJuridica, DataAbertura: abertura,
+			CNAEPrincipal: e.CNAEPrincipal.Codigo, CNAESecundarios: cnaes, InscricaoEstadual: ie,
+			Logradouro: a.Logradouro, Numero: a.Numero, Bairro: a.Bairro, Municipio: a.Municipio,
+			UF: a.UF, CEP: a.CEP, Socios: socios,
+		}
+	}
+
+	out := []fixtureRow{row(e.CNPJ, e.NomeFantasia, e.DataAbertura, e.InscricaoEstadual, e.Endereco)}
+	for _, f := range e.Filiais {
+		out = append(out, row(f.CNPJ, f.NomeFantasia, f.DataAbertura, f.InscricaoEstadual, f.Endereco))
+	}
+	return out
+}
+
+// fixtureText é a representação de uma empresa no formato text
+func fixtureText(e fixtures.Empresa) string {
+	endereco := func(a fixtures.Endereco) string {
+		return fmt.Sprintf("%s, %s - %s, %s/%s, CEP %s", a.Logradouro, a.Numero, a.Bairro, a.Municipio, a.UF, a.CEP)
+	}
+
+	ie := e.InscricaoEstadual
+	if ie == "" {
+		ie = "—"
+	}
+
+	lines := []string{
+		msg("fixtures.empresa", e.RazaoSocial, cnpj.FormatCNPJ(e.CNPJ)),
+		"   " + endereco(e.Endereco),
+		msg("fixtures.detalhes", e.CNAEPrincipal.Codigo, e.DataAbertura, ie),
+	}
+	socios := make([]string, len(e.Socios))
+	for i, s := range e.Socios {
+		socios[i] = fmt.Sprintf("%s (%s)", s.Nome, fixtures.FormatCPF(s.CPF))
+	}
+	lines = append(lines, msg("fixtures.socios", strings.Join(socios, ", ")))
+	for _, f := range e.Filiais {
+		lines = append(lines, msg("fixtures.filial", cnpj.FormatCNPJ(f.CNPJ), endereco(f.Endereco)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	rootCmd.AddCommand(fixturesCmd)
+
+	fixturesCmd.Flags().IntVar(&fixturesOpts.count, "count", 10, "quantidade de empresas")
+	fixturesCmd.Flags().Int64Var(&fixturesOpts.seed, "seed", 0, "semente para uma geração reprodutível")
+	fixturesCmd.Flags().BoolVar(&fixturesOpts.numeric, "numeric", false, "gera apenas CNPJs numéricos")
+	fixturesCmd.Flags().IntVar(&fixturesOpts.filiais, "filiais", 2, "máximo de filiais por empresa")
+	fixturesCmd.Flags().IntVar(&fixturesOpts.socios, "socios", 3, "máximo de sócios por empresa")
+	fixturesCmd.Flags().BoolVar(&fixturesOpts.sql, "sql", false, "escreve CREATE TABLE e INSERTs em vez de --output")
+	fixturesCmd.Flags().StringVar(&fixturesOpts.out, "out", "", "grava o resultado neste arquivo em vez da saída padrão")
+}
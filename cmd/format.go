@@ -23,11 +23,11 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
-
 	"github.com/spf13/cobra"
 )
 
+var formatOpts batchOptions
+
 // formatCmd representa o comando 'format'
 var formatCmd = &cobra.Command{
 	Use:   "format [CNPJ...]",
@@ -36,20 +36,55 @@ var formatCmd = &cobra.Command{
 
 Exemplos de uso:
   ./app format OTWXQENJDKC620
-  ./app format RZYYOMTNOLSV26 D6RJ1CUTQQAA22`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 0 {
-			cmd.Println("⚠️  Nenhum CNPJ foi informado. Informe pelo menos um valor para formatar.")
-			return
+  ./app format RZYYOMTNOLSV26 D6RJ1CUTQQAA22
+  ./app format --file cnpjs.txt --fail-on-invalid
+  ./app format --output csv RZYYOMTNOLSV26 D6RJ1CUTQQAA22`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := openValues(cmd, args, formatOpts.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = src.Close()
+		}()
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		total, invalidos := 0, 0
+
+		err = processOrdered(src, formatOpts.workers, newCNPJRecord, func(i int, valor string, rec cnpjRecord) error {
+			total++
+			if rec.Formatado == "" {
+				invalidos++
+			}
+			if formatOpts.quiet {
+				return nil
+			}
+
+			rec.Indice = i + 1
+			return out.Write(rec, msg("format.resultado", rec.Indice, valor, rec.displayFormatted()))
+		})
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
 		}
 
-		for i, valor := range args {
-			formatado := cnpj.FormatCNPJ(valor)
-			cmd.Printf("[%d] 🧾 Original:  %s\n    📎 Formatado: %s\n", i+1, valor, formatado)
+		if total == 0 && !formatOpts.quiet {
+			cmd.PrintErrln(plain(msg("format.nenhum")))
 		}
+		if invalidos > 0 && (formatOpts.quiet || formatOpts.failOnInvalid) {
+			return errInvalidFound
+		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(formatCmd)
+
+	addBatchFlags(formatCmd, &formatOpts)
 }
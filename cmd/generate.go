@@ -23,12 +23,53 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	genCount     int
+	genUnique    bool
+	genSeed      int64
+	genNumeric   bool
+	genAlnum     bool
+	genMatriz    bool
+	genMasked    bool
+	genSQL       string
+	genTable     string
+	genColumn    string
+	genOut       string
+	genShardSize int
+)
+
+// bulkFlags são as flags que só generateBulk interpreta
+var bulkFlags = []string{"count", "unique", "seed", "numeric", "alnum", "matriz", "masked", "sql", "table", "column", "out", "shard-size"}
+
+// maxUniqueAttempts limita as tentativas de gerar um valor inédito com --unique
+const maxUniqueAttempts = 100
+
+var regexSQLIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// generateRecord é o resultado de generate nos formatos estruturados
+type generateRecord struct {
+	CNPJ      string `json:"cnpj"`
+	Formatado string `json:"formatado"`
+	DV        string `json:"dv"`
+	Valido    bool   `json:"valido"`
+}
+
 // generateCmd representa o comando generate
 var generateCmd = &cobra.Command{
 	Use:   "generate",
@@ -37,29 +78,275 @@ var generateCmd = &cobra.Command{
 
 Exemplo de uso:
   ./app generate
+  ./app generate --output json
+
+Este comando também mostra um exemplo de CNPJ inválido com DV alterado.
 
-Este comando também mostra um exemplo de CNPJ inválido com DV alterado.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		generate()
+Geração em lote (um valor por linha, ou no formato de --output). As flags abaixo também
+valem sem --count, para um único valor:
+  ./app generate --numeric --seed 42
+  ./app generate --count 1000000 --unique --seed 42 --numeric --matriz --masked
+  ./app generate --count 1000 --output csv --out cnpjs.csv
+  ./app generate --count 100000 --sql copy --table fornecedores --column cnpj --out seed.sql
+  ./app generate --count 1000000 --out cnpjs.txt --shard-size 250000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// qualquer opção de geração usa o caminho de generateBulk, que as respeita
+		for _, name := range bulkFlags {
+			if cmd.Flags().Changed(name) {
+				return generateBulk(cmd)
+			}
+		}
+		return generate(cmd)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(generateCmd)
+
+	generateCmd.Flags().IntVar(&genCount, "count", 1, "quantidade de CNPJs a gerar")
+	generateCmd.Flags().BoolVar(&genUnique, "unique", false, "garante que não há valores repetidos")
+	generateCmd.Flags().Int64Var(&genSeed, "seed", 0, "semente para uma sequência reprodutível")
+	generateCmd.Flags().BoolVar(&genNumeric, "numeric", false, "gera apenas CNPJs numéricos")
+	generateCmd.Flags().BoolVar(&genAlnum, "alnum", false, "gera CNPJs alfanuméricos (padrão)")
+	generateCmd.Flags().BoolVar(&genMatriz, "matriz", false, "gera apenas matrizes (ordem 0001)")
+	generateCmd.Flags().BoolVar(&genMasked, "masked", false, "escreve os valores com máscara")
+	generateCmd.Flags().StringVar(&genSQL, "sql", "", "escreve comandos SQL: insert ou copy (PostgreSQL)")
+	generateCmd.Flags().StringVar(&genTable, "table", "cnpjs", "tabela usada por --sql")
+	generateCmd.Flags().StringVar(&genColumn, "column", "cnpj", "coluna usada por --sql")
+	generateCmd.Flags().StringVar(&genOut, "out", "", "arquivo de saída (padrão: stdout)")
+	generateCmd.Flags().IntVar(&genShardSize, "shard-size", 0, "divide a saída em arquivos com até N registros (requer --out)")
+	generateCmd.MarkFlagsMutuallyExclusive("numeric", "alnum")
 }
 
-func generate() {
+func generate(cmd *cobra.Command) error {
+	out, err := newRecordWriter(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Gerando CNPJ válido
 	valor := cnpj.GenerateCNPJ()
-	fmt.Println("✅  CNPJ Gerado:", valor)
+	rec := newGenerateRecord(valor)
 
-	// Formatando CNPJ
-	fmt.Println("📎 CNPJ Formatado:", cnpj.FormatCNPJ(valor))
+	linhas := []string{
+		msg("generate.gerado", rec.CNPJ),
+		// Formatando CNPJ
+		msg("generate.formatado", rec.Formatado),
+	}
 
 	// Validando CNPJ
-	if cnpj.IsValid(valor) {
-		fmt.Println("🔍 Validação: CNPJ gerado é válido ✅ ")
+	if rec.Valido {
+		linhas = append(linhas, msg("generate.valido"))
 	} else {
-		fmt.Println("🔍 Validação: CNPJ gerado é inválido ❌ ")
+		linhas = append(linhas, msg("generate.invalido"))
 	}
+
+	if err := out.Write(rec, strings.Join(linhas, "\n")); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func newGenerateRecord(valor string) generateRecord {
+	dv, _ := cnpj.CalculateDV(valor)
+	return generateRecord{CNPJ: valor, Formatado: cnpj.FormatCNPJ(valor), DV: dv, Valido: cnpj.IsValid(valor)}
+}
+
+// generateBulk gera --count valores e os escreve no destino escolhido
+func generateBulk(cmd *cobra.Command) error {
+	if genCount < 1 {
+		return errors.New(msg("flag.count"))
+	}
+	if genShardSize > 0 && genOut == "" {
+		return errors.New(msg("generate.shard_out"))
+	}
+
+	newSink, err := generateSinkFactory()
+	if err != nil {
+		return err
+	}
+
+	seed := genSeed
+	if !cmd.Flags().Changed("seed") {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+	opts := cnpj.GenerateOptions{Numeric: genNumeric, Matriz: genMatriz}
+
+	var sink recordSink
+	if genOut == "" {
+		sink, err = newSink(cmd.OutOrStdout())
+	} else {
+		sink, err = newShardedSink(genOut, genShardSize, newSink)
+	}
+	if err != nil {
+		return err
+	}
+
+	var seen map[string]struct{}
+	if genUnique {
+		seen = make(map[string]struct{}, genCount)
+	}
+
+	for i := 0; i < genCount; i++ {
+		valor := cnpj.GenerateWith(r, opts)
+		if seen != nil {
+			attempts := 1
+			for _, dup := seen[valor]; dup; _, dup = seen[valor] {
+				if attempts == maxUniqueAttempts {
+					_ = sink.Close()
+					return errors.New(msg("generate.unico", attempts, i))
+				}
+				valor = cnpj.GenerateWith(r, opts)
+				attempts++
+			}
+			seen[valor] = struct{}{}
+		}
+
+		// GenerateWith só devolve valores válidos, então o registro dispensa nova validação
+		rec := generateRecord{CNPJ: valor, Formatado: cnpj.FormatCNPJ(valor), DV: valor[12:], Valido: true}
+		linha := rec.CNPJ
+		if genMasked {
+			linha = rec.Formatado
+		}
+		if err := sink.Write(rec, linha); err != nil {
+			_ = sink.Close()
+			return err
+		}
+	}
+
+	return sink.Close()
+}
+
+// recordSink é o destino dos registros gerados: um recordWriter ou um sqlWriter
+type recordSink interface {
+	Write(rec any, text string) error
+	Close() error
+}
+
+func generateSinkFactory() (func(io.Writer) (recordSink, error), error) {
+	switch genSQL {
+	case "":
+		return func(w io.Writer) (recordSink, error) {
+			return newRecordWriterTo(w)
+		}, nil
+	case "insert", "copy":
+		if !regexSQLIdent.MatchString(genTable) || !regexSQLIdent.MatchString(genColumn) {
+			return nil, errors.New(msg("generate.identificador", genTable, genColumn))
+		}
+		return func(w io.Writer) (recordSink, error) {
+			return &sqlWriter{out: bufio.NewWriter(w), mode: genSQL, table: genTable, column: genColumn}, nil
+		}, nil
+	default:
+		return nil, errors.New(msg("generate.sql", genSQL))
+	}
+}
+
+// sqlInsertBatch é a quantidade de linhas por comando INSERT
+const sqlInsertBatch = 1000
+
+// sqlWriter escreve os valores como INSERTs em lote ou como um bloco COPY do PostgreSQL
+type sqlWriter struct {
+	out    *bufio.Writer
+	mode   string
+	table  string
+	column string
+	count  int
+}
+
+func (s *sqlWriter) Write(_ any, text string) error {
+	var err error
+	switch {
+	case s.mode == "copy" && s.count == 0:
+		_, err = fmt.Fprintf(s.out, "COPY %s (%s) FROM stdin;\n%s\n", s.table, s.column, text)
+	case s.mode == "copy":
+		_, err = fmt.Fprintln(s.out, text)
+	case s.count%sqlInsertBatch == 0:
+		if s.count > 0 {
+			_, _ = s.out.WriteString(";\n")
+		}
+		_, err = fmt.Fprintf(s.out, "INSERT INTO %s (%s) VALUES\n  ('%s')", s.table, s.column, text)
+	default:
+		_, err = fmt.Fprintf(s.out, ",\n  ('%s')", text)
+	}
+	s.count++
+	return err
+}
+
+func (s *sqlWriter) Close() error {
+	if s.count > 0 {
+		closing := ";\n"
+		if s.mode == "copy" {
+			closing = "\\.\n"
+		}
+		if _, err := s.out.WriteString(closing); err != nil {
+			return err
+		}
+	}
+	return s.out.Flush()
+}
+
+// shardedSink grava em path ou, com shardSize > 0, em arquivos path-0001.ext,
+// path-0002.ext... com até shardSize registros cada, cada um um documento completo
+type shardedSink struct {
+	path      string
+	shardSize int
+	newSink   func(io.Writer) (recordSink, error)
+
+	file  *os.File
+	sink  recordSink
+	shard int
+	count int
+}
+
+func newShardedSink(path string, shardSize int, newSink func(io.Writer) (recordSink, error)) (*shardedSink, error) {
+	s := &shardedSink{path: path, shardSize: shardSize, newSink: newSink}
+	return s, s.open()
+}
+
+func (s *shardedSink) open() error {
+	path := s.path
+	if s.shardSize > 0 {
+		s.shard++
+		ext := filepath.Ext(s.path)
+		path = fmt.Sprintf("%s-%04d%s", strings.TrimSuffix(s.path, ext), s.shard, ext)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	sink, err := s.newSink(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	s.file, s.sink = f, sink
+	return nil
+}
+
+func (s *shardedSink) Write(rec any, text string) error {
+	if s.shardSize > 0 && s.count > 0 && s.count%s.shardSize == 0 {
+		if err := s.closeShard(); err != nil {
+			return err
+		}
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	s.count++
+	return s.sink.Write(rec, text)
+}
+
+func (s *shardedSink) closeShard() error {
+	err := s.sink.Close()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (s *shardedSink) Close() error {
+	return s.closeShard()
 }
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestGenerateSeedNumeric tests that the generation flags are honored without
+// --count: a seeded numeric CNPJ is the same on every run
+func TestGenerateSeedNumeric(t *testing.T) {
+	t.Cleanup(func() {
+		genNumeric, genSeed = false, 0
+	})
+
+	first, err := executeCLI(t, "generate", "--numeric", "--seed", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^\d{14}\n$`).MatchString(first) {
+		t.Errorf("expected a numeric CNPJ, got %q", first)
+	}
+	for range 3 {
+		if out, _ := executeCLI(t, "generate", "--numeric", "--seed", "1"); out != first {
+			t.Errorf("generate --seed 1 = %q, expected %q", out, first)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/receita"
+	"github.com/spf13/cobra"
+)
+
+var importRFOpts struct {
+	db    dbOptions
+	batch int
+	reset bool
+}
+
+// importRFCmd representa o comando 'import-rf'
+var importRFCmd = &cobra.Command{
+	Use:   "import-rf <arquivos ou diretórios>...",
+	Short: "Importa os Dados Abertos do CNPJ da Receita Federal para consultas offline",
+	Long: `Importa os arquivos de Dados Abertos do CNPJ baixados da Receita Federal (Empresas,
+Estabelecimentos, Sócios, Simples e as tabelas de CNAEs, municípios, naturezas jurídicas,
+qualificações, países e motivos) para tabelas rf_* no PostgreSQL (flags --pg-*) ou em um
+arquivo SQLite (--sqlite). Os ZIPs podem ser passados diretamente ou por um diretório; o
+tipo de cada arquivo é reconhecido pelo nome.
+
+Importar a publicação de um novo mês sobre a anterior atualiza as linhas já existentes;
+só os sócios, que não têm chave, se acumulam. Use --reset para começar do zero.
+
+Depois da carga os índices são criados e os dados ficam disponíveis para o comando lookup
+e para a rota GET /api/cnpj/{cnpj}/empresa do comando api.
+
+Exemplos de uso:
+  ./app import-rf --sqlite rf.db ~/Downloads/dados-abertos-cnpj/
+  ./app import-rf --sqlite rf.db Cnaes.zip Municipios.zip Empresas0.zip Estabelecimentos0.zip
+  ./app import-rf --pg-host localhost --pg-user u --pg-password p --pg-database d --reset dados/`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := rfFiles(args)
+		if err != nil {
+			return err
+		}
+
+		db, dialect, err := importRFOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+		if err := receita.Prepare(cmd.Context(), db, importRFOpts.reset); err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		var total int64
+		for _, f := range files {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), plain(msg("importrf.lendo", f)))
+			results, err := receita.ImportFile(cmd.Context(), db, dialect, f, importRFOpts.batch)
+			for _, r := range results {
+				total += r.Linhas
+				if writeErr := out.Write(r, msg("importrf.arquivo", r.Arquivo, r.Linhas, r.Tipo)); writeErr != nil {
+					return writeErr
+				}
+			}
+			if err != nil {
+				_ = out.Close()
+				return err
+			}
+			if err := out.Flush(); err != nil {
+				return err
+			}
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), plain(msg("importrf.indices")))
+		if err := receita.CreateIndexes(cmd.Context(), db); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.ErrOrStderr(), plain(msg("importrf.concluido", total, time.Since(start).Round(time.Second))))
+		return err
+	},
+}
+
+// rfFiles expande os diretórios nos arquivos de Dados Abertos que eles contêm,
+// em ordem alfabética; arquivos informados diretamente são mantidos
+func rfFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		var found []string
+		for _, e := range entries {
+			if _, ok := receita.DetectKind(e.Name()); ok && !e.IsDir() {
+				found = append(found, filepath.Join(arg, e.Name()))
+			}
+		}
+		if len(found) == 0 {
+			return nil, errors.New(msg("importrf.vazio", arg))
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+func init() {
+	rootCmd.AddCommand(importRFCmd)
+
+	addDBFlags(importRFCmd, &importRFOpts.db)
+	importRFCmd.Flags().IntVar(&importRFOpts.batch, "batch", receita.DefaultBatch, "linhas por transação")
+	importRFCmd.Flags().BoolVar(&importRFOpts.reset, "reset", false, "apaga as tabelas rf_* antes de importar")
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// batchSize é a quantidade de valores processados em paralelo antes de
+// escrever os resultados, o que mantém a ordem e limita o uso de memória
+const batchSize = 4096
+
+// batchOptions agrupa as flags de entrada e saída dos comandos que processam listas
+type batchOptions struct {
+	file          string
+	quiet         bool
+	failOnInvalid bool
+	workers       int
+}
+
+func addBatchFlags(cmd *cobra.Command, opts *batchOptions) {
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "arquivo com um valor por linha (aceita gzip; use - para stdin)")
+	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "não escreve resultados; apenas o código de saída indica se há inválidos")
+	cmd.Flags().BoolVar(&opts.failOnInvalid, "fail-on-invalid", false, "termina com código 2 se algum valor for inválido")
+	cmd.Flags().IntVar(&opts.workers, "workers", runtime.NumCPU(), "quantidade de workers para processar a entrada em paralelo")
+}
+
+// valueSource entrega os valores informados como argumentos ou lidos linha a linha de um arquivo ou de stdin
+type valueSource struct {
+	args    []string
+	scanner *bufio.Scanner
+	closers []io.Closer
+}
+
+// openValues escolhe a origem dos valores: argumentos, --file ou stdin redirecionado.
+// Sem nenhuma delas a origem fica vazia.
+func openValues(cmd *cobra.Command, args []string, file string) (*valueSource, error) {
+	if len(args) > 0 {
+		return &valueSource{args: args}, nil
+	}
+
+	r, closers, err := openReader(cmd, file)
+	if err != nil {
+		return nil, err
+	}
+	src := &valueSource{closers: closers}
+	if r == nil {
+		return src, nil
+	}
+
+	src.scanner = bufio.NewScanner(r)
+	src.scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return src, nil
+}
+
+// openReader abre --file ou o stdin redirecionado, descompactando gzip quando
+// necessário. Sem nenhum dos dois o leitor retornado é nil.
+func openReader(cmd *cobra.Command, file string) (io.Reader, []io.Closer, error) {
+	var (
+		r       io.Reader
+		closers []io.Closer
+	)
+	switch {
+	case file != "" && file != "-":
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		closers = append(closers, f)
+		r = f
+	case file == "-" || stdinIsPipe(cmd):
+		r = cmd.InOrStdin()
+	default:
+		return nil, nil, nil
+	}
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			for _, c := range closers {
+				_ = c.Close()
+			}
+			return nil, nil, err
+		}
+		return gz, append(closers, gz), nil
+	}
+	return br, closers, nil
+}
+
+// stdinIsPipe indica se stdin foi redirecionado, para não bloquear esperando um terminal
+func stdinIsPipe(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	if !ok {
+		return true
+	}
+	st, err := f.Stat()
+	return err == nil && st.Mode()&os.ModeCharDevice == 0
+}
+
+// Next retorna o próximo valor não vazio, sem espaços nas pontas
+func (s *valueSource) Next() (string, bool) {
+	if s.scanner == nil {
+		if len(s.args) == 0 {
+			return "", false
+		}
+		v := s.args[0]
+		s.args = s.args[1:]
+		return v, true
+	}
+
+	for s.scanner.Scan() {
+		if v := strings.TrimSpace(s.scanner.Text()); v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (s *valueSource) Err() error {
+	if s.scanner == nil {
+		return nil
+	}
+	return s.scanner.Err()
+}
+
+func (s *valueSource) Close() error {
+	var first error
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if err := s.closers[i].Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// processOrdered aplica fn aos valores da origem usando até workers goroutines
+// e chama emit com os resultados na mesma ordem da entrada
+func processOrdered[T any](src *valueSource, workers int, fn func(string) T, emit func(i int, value string, result T) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	values := make([]string, 0, batchSize)
+	results := make([]T, batchSize)
+	offset := 0
+
+	for {
+		values = values[:0]
+		for len(values) < batchSize {
+			v, ok := src.Next()
+			if !ok {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			break
+		}
+
+		if workers == 1 || len(values) == 1 {
+			for i, v := range values {
+				results[i] = fn(v)
+			}
+		} else {
+			var wg sync.WaitGroup
+			chunk := (len(values) + workers - 1) / workers
+			for start := 0; start < len(values); start += chunk {
+				end := min(start+chunk, len(values))
+				wg.Add(1)
+				go func(start, end int) {
+					defer wg.Done()
+					for i := start; i < end; i++ {
+						results[i] = fn(values[i])
+					}
+				}(start, end)
+			}
+			wg.Wait()
+		}
+
+		for i, v := range values {
+			if err := emit(offset+i, v, results[i]); err != nil {
+				return err
+			}
+		}
+		offset += len(values)
+
+		if len(values) < batchSize {
+			break
+		}
+	}
+
+	return src.Err()
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestProcessOrdered(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < batchSize*2+17; i++ {
+		fmt.Fprintf(&input, "%d\n\n", i)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(input.String()))
+
+	src, err := openValues(cmd, nil, "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := 0
+	err = processOrdered(src, 8, func(v string) string { return v + "!" }, func(i int, v, res string) error {
+		if i != next || v != fmt.Sprint(i) || res != v+"!" {
+			t.Fatalf("out of order result: i=%d v=%s res=%s, expected %d", i, v, res, next)
+		}
+		next++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next != batchSize*2+17 {
+		t.Errorf("processed %d values, expected %d", next, batchSize*2+17)
+	}
+}
+
+func TestOpenValuesGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte("12ABC34501DE35\r\n  11222333000181  \n"))
+	_ = gz.Close()
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(&buf)
+
+	src, err := openValues(cmd, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for v, ok := src.Next(); ok; v, ok = src.Next() {
+		got = append(got, v)
+	}
+	if strings.Join(got, ",") != "12ABC34501DE35,11222333000181" {
+		t.Errorf("unexpected values: %q", got)
+	}
+}
+
+func TestReplaceInputGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nota.json.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(`{"cnpj":"12.ABC.345/01DE-35"}`))
+	_ = gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	in, closers, err := openReader(&cobra.Command{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = replaceInput(path, in, func(w io.Writer) error {
+		_, err := io.Copy(w, in)
+		return err
+	})
+	for _, c := range closers {
+		_ = c.Close()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("the rewritten file should still be gzip: %v", err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != `{"cnpj":"12.ABC.345/01DE-35"}` {
+		t.Errorf("unexpected content %q", got)
+	}
+}
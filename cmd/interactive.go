@@ -0,0 +1,258 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/spf13/cobra"
+)
+
+// interactiveCmd representa o comando 'interactive'
+var interactiveCmd = &cobra.Command{
+	Use:     "interactive",
+	Aliases: []string{"repl"},
+	Short:   "Valida CNPJs à medida que são digitados",
+	Long: `Abre um prompt que valida cada linha digitada: mostra o valor formatado, o DV esperado
+e sugestões de correção para erros de digitação. Tudo roda localmente, no terminal.
+
+Comandos:
+  :gen [n] [num]         gera n CNPJs válidos (num: apenas dígitos)
+  :explain <cnpj>        mostra o cálculo do DV passo a passo
+  :branch <cnpj> [ordem] mostra raiz e ordem, ou o CNPJ de outro estabelecimento
+  :history               lista os valores e comandos digitados na sessão
+  :help                  mostra esta ajuda
+  :q                     encerra
+
+Exemplo de uso:
+  ./app interactive`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := &repl{
+			out:    cmd.OutOrStdout(),
+			rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+			prompt: !stdinIsPipe(cmd),
+		}
+		return r.run(cmd.InOrStdin())
+	},
+}
+
+// repl mantém o estado de uma sessão interativa
+type repl struct {
+	out     io.Writer
+	rng     *rand.Rand
+	prompt  bool
+	history []string
+}
+
+func (r *repl) println(text string) {
+	_, _ = fmt.Fprintln(r.out, plain(text))
+}
+
+func (r *repl) run(in io.Reader) error {
+	sc := bufio.NewScanner(in)
+	if r.prompt {
+		r.println(msg("interactive.bemvindo"))
+	}
+
+	for {
+		if r.prompt {
+			_, _ = fmt.Fprint(r.out, "cnpj> ")
+		}
+		if !sc.Scan() {
+			if r.prompt {
+				_, _ = fmt.Fprintln(r.out)
+			}
+			return sc.Err()
+		}
+
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		r.history = append(r.history, line)
+
+		if !strings.HasPrefix(line, ":") {
+			r.check(line)
+			continue
+		}
+		if quit := r.command(strings.Fields(line[1:])); quit {
+			return nil
+		}
+	}
+}
+
+// check valida um valor completo ou mostra o progresso de um valor parcial
+func (r *repl) check(line string) {
+	v := cnpj.UnformattedCNPJ(line)
+
+	switch {
+	case len(v) < 12:
+		r.println(msg("interactive.parcial", cnpj.FormatPartial(v), 14-len(v)))
+		return
+	case len(v) == 12:
+		dv, err := cnpj.CalculateDV(v)
+		if err != nil {
+			r.println(msg("interactive.invalido", line, i18n.Error(currentLang(), err)))
+			return
+		}
+		r.println(msg("interactive.dv", dv, cnpj.FormatCNPJ(v+dv)))
+		return
+	}
+
+	err := cnpj.Validate(v)
+	if err == nil {
+		r.println(msg("interactive.valido", cnpj.FormatCNPJ(v), r.establishment(v)))
+		return
+	}
+
+	r.println(msg("interactive.invalido", line, i18n.Error(currentLang(), err)))
+	if len(v) == 14 {
+		if dv, dvErr := cnpj.CalculateDV(v[:12]); dvErr == nil && dv != v[12:] {
+			r.println(msg("interactive.dvesperado", v[12:], dv))
+		}
+	}
+	if s := cnpj.Suggest(v); len(s) > 0 {
+		formatted := make([]string, len(s))
+		for i, c := range s {
+			formatted[i] = cnpj.FormatCNPJ(c)
+		}
+		r.println(msg("interactive.sugestoes", strings.Join(formatted, ", ")))
+	}
+}
+
+func (r *repl) establishment(v string) string {
+	if cnpj.IsMatriz(v) {
+		return msg("interactive.matriz")
+	}
+	return msg("interactive.filial", cnpj.Ordem(v))
+}
+
+// command executa um comando iniciado por ':' e indica se a sessão deve terminar
+func (r *repl) command(fields []string) bool {
+	if len(fields) == 0 {
+		r.println(msg("interactive.ajuda"))
+		return false
+	}
+
+	name, args := fields[0], fields[1:]
+	switch name {
+	case "q", "quit", "exit":
+		return true
+	case "help", "h", "?":
+		r.println(msg("interactive.ajuda"))
+	case "history":
+		for i, h := range r.history[:len(r.history)-1] {
+			r.println(fmt.Sprintf("%4d  %s", i+1, h))
+		}
+	case "gen":
+		r.generate(args)
+	case "explain":
+		if len(args) != 1 {
+			r.println(msg("interactive.uso", ":explain <cnpj>"))
+			return false
+		}
+		r.explain(args[0])
+	case "branch":
+		if len(args) < 1 || len(args) > 2 {
+			r.println(msg("interactive.uso", ":branch <cnpj> [ordem]"))
+			return false
+		}
+		r.branch(args)
+	default:
+		r.println(msg("interactive.comando", ":"+name))
+	}
+	return false
+}
+
+func (r *repl) generate(args []string) {
+	n, opts := 1, cnpj.GenerateOptions{}
+	for _, a := range args {
+		if a == "num" {
+			opts.Numeric = true
+			continue
+		}
+		v, err := strconv.Atoi(a)
+		if err != nil || v < 1 || v > 1000 {
+			r.println(msg("interactive.uso", ":gen [1-1000] [num]"))
+			return
+		}
+		n = v
+	}
+
+	for i := 0; i < n; i++ {
+		r.println(cnpj.FormatCNPJ(cnpj.GenerateWith(r.rng, opts)))
+	}
+}
+
+func (r *repl) explain(value string) {
+	calc, err := cnpj.ExplainDV(value)
+	if err != nil {
+		r.println(msg("interactive.invalido", value, i18n.Error(currentLang(), err)))
+		return
+	}
+
+	for d, c := range calc {
+		r.println(msg("interactive.explain.titulo", d+1))
+		for _, s := range c.Steps {
+			r.println(fmt.Sprintf("   %c  %2d × %d = %3d", s.Char, s.Value, s.Weight, s.Product))
+		}
+		r.println(msg("interactive.explain.resultado", c.Sum, c.Remainder, d+1, c.Digit))
+	}
+}
+
+func (r *repl) branch(args []string) {
+	v := cnpj.UnformattedCNPJ(args[0])
+	if len(args) == 1 {
+		if len(v) < 12 {
+			r.println(msg("interactive.invalido", args[0], i18n.Error(currentLang(), cnpj.ErroTamanho)))
+			return
+		}
+		r.println(msg("interactive.branch", cnpj.Raiz(v), cnpj.Ordem(v), r.establishment(v)))
+		if !cnpj.IsMatriz(v) {
+			if matriz, err := cnpj.Branch(v, "1"); err == nil {
+				r.println(msg("interactive.branch.novo", "0001", cnpj.FormatCNPJ(matriz)))
+			}
+		}
+		return
+	}
+
+	b, err := cnpj.Branch(v, args[1])
+	if err != nil {
+		r.println(msg("interactive.invalido", args[0], i18n.Error(currentLang(), err)))
+		return
+	}
+	r.println(msg("interactive.branch.novo", cnpj.Ordem(b), cnpj.FormatCNPJ(b)))
+}
+
+func init() {
+	rootCmd.AddCommand(interactiveCmd)
+}
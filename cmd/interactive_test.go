@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+	t.Setenv("LANG", "pt_BR.UTF-8")
+	noEmoji = true
+	t.Cleanup(func() { noEmoji = false })
+
+	var out bytes.Buffer
+	r := &repl{out: &out, rng: rand.New(rand.NewSource(1))}
+	input := "12ABC34501DE\n12ABC34501DE53\n:branch 11222333000181 2\n:gen 3\n:history\n:q\n12ABC34501DE35\n"
+	if err := r.run(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	for _, expected := range []string{
+		"DV esperado: 35 → 12.ABC.345/01DE-35",
+		"DV informado 53, esperado 35",
+		"Você quis dizer: 12.ABC.345/01DE-35",
+		"Estabelecimento 0002: 11.222.333/0002-62",
+		"   4  :gen 3",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("missing %q in output:\n%s", expected, got)
+		}
+	}
+	if strings.Contains(got, "12ABC34501DE35") {
+		t.Error("input after :q must not be processed")
+	}
+	if len(r.history) != 6 {
+		t.Errorf("expected 6 history entries, got %d", len(r.history))
+	}
+}
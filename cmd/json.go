@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/jsoncnpj"
+	"github.com/spf13/cobra"
+)
+
+var jsonOpts struct {
+	paths         []string
+	ndjson        bool
+	rewrite       string
+	out           string
+	inPlace       bool
+	all           bool
+	quiet         bool
+	failOnInvalid bool
+}
+
+// jsonRecord é o resultado de um valor encontrado em um documento JSON
+type jsonRecord struct {
+	Linha   int    `json:"linha,omitempty"`
+	Caminho string `json:"caminho"`
+	Valor   string `json:"valor"`
+	Valido  bool   `json:"valido"`
+	Codigo  string `json:"codigo,omitempty"`
+	Erro    string `json:"erro,omitempty"`
+}
+
+// jsonCmd representa o comando 'json'
+var jsonCmd = &cobra.Command{
+	Use:   "json [arquivo]",
+	Short: "Valida CNPJs dentro de documentos JSON/NDJSON",
+	Long: `Localiza CNPJs em documentos JSON ou NDJSON por seletores no estilo JSONPath
+($.nota.emitente.cnpj, $.fornecedores[*].documento, $..cnpj) e informa o caminho e o
+motivo de cada valor inválido.
+
+Com --rewrite normalize|format o documento é reescrito com os CNPJs válidos normalizados
+ou formatados, preservando a ordem das chaves e a indentação; o relatório vai para a saída
+de erro. Arquivos .ndjson/.jsonl (ou --ndjson) são processados linha a linha.
+
+Exemplos de uso:
+  ./app json --path '$.nota.emitente.cnpj' nota.json
+  ./app json -p '$.fornecedores[*].documento' -p '$..cnpj' --all payload.json
+  ./app json --ndjson -p '$.cnpj' --rewrite normalize eventos.ndjson > normalizados.ndjson
+  ./app json -p '$..cnpj' --rewrite format --in-place nota.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(jsonOpts.paths) == 0 {
+			return errors.New(msg("json.path"))
+		}
+		opts := jsoncnpj.Options{NDJSON: jsonOpts.ndjson}
+		for _, p := range jsonOpts.paths {
+			sel, err := jsoncnpj.Compile(p)
+			if err != nil {
+				return err
+			}
+			opts.Selectors = append(opts.Selectors, sel)
+		}
+
+		mode, ok := jsoncnpj.ParseMode(jsonOpts.rewrite)
+		if !ok {
+			return errors.New(msg("json.rewrite", jsonOpts.rewrite))
+		}
+		opts.Mode = mode
+		if mode == jsoncnpj.Keep && (jsonOpts.inPlace || jsonOpts.out != "") {
+			return errors.New(msg("json.rewrite_out"))
+		}
+
+		file := "-"
+		if len(args) == 1 {
+			file = args[0]
+			if ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(file, ".gz"))); ext == ".ndjson" || ext == ".jsonl" {
+				opts.NDJSON = true
+			}
+		}
+		if jsonOpts.inPlace && file == "-" {
+			return errors.New(msg("flag.in_place"))
+		}
+
+		in, closers, err := openReader(cmd, file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			for _, c := range closers {
+				_ = c.Close()
+			}
+		}()
+
+		// O relatório vai para a saída padrão, a menos que ela receba o documento reescrito
+		reportTo := cmd.OutOrStdout()
+		if mode != jsoncnpj.Keep {
+			reportTo = cmd.ErrOrStderr()
+		}
+		out, err := newRecordWriterTo(reportTo)
+		if err != nil {
+			return err
+		}
+
+		invalidos := 0
+		report := func(r jsoncnpj.Result) error {
+			if !r.Valid {
+				invalidos++
+			}
+			if jsonOpts.quiet || (r.Valid && !jsonOpts.all) {
+				return nil
+			}
+			return out.Write(newJSONRecord(r), jsonResultText(r))
+		}
+
+		switch {
+		case jsonOpts.inPlace:
+			err = replaceInput(file, in, func(w io.Writer) error { return jsoncnpj.Process(in, w, opts, report) })
+		case jsonOpts.out != "":
+			err = replaceFile(jsonOpts.out, func(w io.Writer) error { return jsoncnpj.Process(in, w, opts, report) })
+		case mode != jsoncnpj.Keep:
+			err = jsoncnpj.Process(in, cmd.OutOrStdout(), opts, report)
+		default:
+			err = jsoncnpj.Process(in, nil, opts, report)
+		}
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		if invalidos > 0 && (jsonOpts.quiet || jsonOpts.failOnInvalid) {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+func newJSONRecord(r jsoncnpj.Result) jsonRecord {
+	rec := jsonRecord{Linha: r.Line, Caminho: r.Path, Valor: r.Value, Valido: r.Valid}
+	if r.Err != nil {
+		rec.Codigo = i18n.Code(r.Err)
+		rec.Erro = i18n.Error(currentLang(), r.Err)
+	}
+	return rec
+}
+
+func jsonResultText(r jsoncnpj.Result) string {
+	path := r.Path
+	if r.Line > 0 {
+		path = msg("json.linha", r.Line, r.Path)
+	}
+	if r.Valid {
+		return msg("json.valido", path, r.Value)
+	}
+	return msg("json.invalido", path, r.Value, i18n.Error(currentLang(), r.Err))
+}
+
+func init() {
+	rootCmd.AddCommand(jsonCmd)
+
+	jsonCmd.Flags().StringArrayVarP(&jsonOpts.paths, "path", "p", nil, "seletor dos valores a validar, ex.: $.fornecedores[*].documento (repetível)")
+	jsonCmd.Flags().BoolVar(&jsonOpts.ndjson, "ndjson", false, "trata cada linha como um documento (automático para .ndjson e .jsonl)")
+	jsonCmd.Flags().StringVar(&jsonOpts.rewrite, "rewrite", "", "reescreve o documento com os CNPJs válidos: normalize ou format")
+	jsonCmd.Flags().StringVar(&jsonOpts.out, "out", "", "grava o documento reescrito neste arquivo em vez da saída padrão")
+	jsonCmd.Flags().BoolVar(&jsonOpts.inPlace, "in-place", false, "reescreve o próprio arquivo de entrada")
+	jsonCmd.Flags().BoolVar(&jsonOpts.all, "all", false, "inclui os valores válidos no relatório")
+	jsonCmd.Flags().BoolVarP(&jsonOpts.quiet, "quiet", "q", false, "não escreve o relatório; apenas o código de saída indica se há inválidos")
+	jsonCmd.Flags().BoolVar(&jsonOpts.failOnInvalid, "fail-on-invalid", false, "termina com código 2 se algum valor for inválido")
+}
@@ -0,0 +1,268 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/lists"
+	"github.com/spf13/cobra"
+)
+
+// listsCmd representa o comando 'lists', que agrupa as operações sobre listas
+var listsCmd = &cobra.Command{
+	Use:   "lists",
+	Short: "Deduplica, agrupa e compara listas de CNPJs",
+	Long: `Operações sobre listas de CNPJs com um valor por linha. Os valores são normalizados
+(sem máscara, em maiúsculas) antes de qualquer comparação, então 12.abc.345/01de-35 e
+12ABC34501DE35 são o mesmo CNPJ. Sem arquivos, a lista é lida de stdin.
+
+Exemplos de uso:
+  ./app lists dedupe fornecedores.txt
+  ./app lists group-by-raiz fornecedores.txt
+  ./app lists union erp.txt crm.txt --output csv
+  ./app lists intersect erp.txt crm.txt
+  ./app lists diff erp.txt crm.txt
+  ./app lists near-dupes fornecedores.txt --output json`,
+}
+
+// listRecord é um valor de uma lista resultante
+type listRecord struct {
+	CNPJ   string `json:"cnpj"`
+	Valido bool   `json:"valido"`
+}
+
+// dedupeRecord é um valor distinto e as grafias em que apareceu
+type dedupeRecord struct {
+	CNPJ        string   `json:"cnpj"`
+	Valido      bool     `json:"valido"`
+	Ocorrencias int      `json:"ocorrencias"`
+	Originais   []string `json:"originais"`
+}
+
+// groupRecord é uma empresa e seus estabelecimentos
+type groupRecord struct {
+	Raiz    string   `json:"raiz"`
+	Matriz  string   `json:"matriz"`
+	Filiais []string `json:"filiais"`
+}
+
+// nearDupeRecord é um par de valores provavelmente digitados errado um a partir do outro
+type nearDupeRecord struct {
+	A       string `json:"a"`
+	B       string `json:"b"`
+	AValido bool   `json:"a_valido"`
+	BValido bool   `json:"b_valido"`
+}
+
+// readList lê um valor por linha de um arquivo (ou de stdin, com "-"), sem linhas vazias
+func readList(cmd *cobra.Command, file string) ([]string, error) {
+	r, closers, err := openReader(cmd, file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}()
+	if r == nil {
+		return nil, nil
+	}
+
+	var values []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		if v := strings.TrimSpace(sc.Text()); v != "" {
+			values = append(values, v)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return values, nil
+}
+
+// readLists lê cada arquivo informado como uma lista; sem arquivos, lê stdin
+func readLists(cmd *cobra.Command, files []string) ([][]string, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	out := make([][]string, len(files))
+	for i, f := range files {
+		values, err := readList(cmd, f)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = values
+	}
+	return out, nil
+}
+
+// writeValues escreve uma lista de valores normalizados, um por linha no modo texto
+func writeValues(cmd *cobra.Command, values []string) error {
+	out, err := newRecordWriter(cmd)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if err := out.Write(listRecord{CNPJ: v, Valido: cnpj.IsValid(v)}, v); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// setOperation cria um subcomando que combina duas ou mais listas
+func setOperation(use, short string, op func(first []string, others ...[]string) []string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <arquivo> <arquivo>...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			all, err := readLists(cmd, args)
+			if err != nil {
+				return err
+			}
+			return writeValues(cmd, op(all[0], all[1:]...))
+		},
+	}
+}
+
+var listsDedupeCmd = &cobra.Command{
+	Use:   "dedupe [arquivo...]",
+	Short: "Remove valores repetidos, mesmo com grafias diferentes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := readLists(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		var values []string
+		for _, l := range all {
+			values = append(values, l...)
+		}
+		for _, e := range lists.Dedupe(values) {
+			rec := dedupeRecord{CNPJ: e.Value, Valido: e.Valid, Ocorrencias: e.Occurrences, Originais: e.Originals}
+			if err := out.Write(rec, e.Value); err != nil {
+				return err
+			}
+		}
+		return out.Close()
+	},
+}
+
+var listsGroupCmd = &cobra.Command{
+	Use:   "group-by-raiz [arquivo...]",
+	Short: "Agrupa os CNPJs por empresa, com matriz e filiais",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := readLists(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		for _, g := range lists.GroupByRaiz(lists.Union(all...)) {
+			rec := groupRecord{Raiz: g.Raiz, Matriz: g.Matriz, Filiais: g.Filiais}
+			if err := out.Write(rec, groupTree(g)); err != nil {
+				return err
+			}
+		}
+		return out.Close()
+	},
+}
+
+// groupTree desenha a empresa como uma árvore, com a matriz primeiro
+func groupTree(g lists.Group) string {
+	lines := []string{g.Raiz}
+	items := g.Filiais
+	if g.Matriz != "" {
+		items = append([]string{g.Matriz}, items...)
+	}
+	for i, v := range items {
+		branch := "├── "
+		if i == len(items)-1 {
+			branch = "└── "
+		}
+		label := cnpj.FormatCNPJ(v)
+		if v == g.Matriz {
+			label += " " + msg("lists.matriz")
+		}
+		lines = append(lines, branch+label)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var listsNearDupesCmd = &cobra.Command{
+	Use:   "near-dupes [arquivo...]",
+	Short: "Aponta valores a uma edição de distância, provavelmente erros de digitação",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := readLists(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		for _, d := range lists.NearDupes(lists.Union(all...)) {
+			rec := nearDupeRecord{A: d.A, B: d.B, AValido: d.AValid, BValido: d.BValid}
+			if err := out.Write(rec, msg("lists.quase", d.A, validMark(d.AValid), d.B, validMark(d.BValid))); err != nil {
+				return err
+			}
+		}
+		return out.Close()
+	},
+}
+
+func validMark(valid bool) string {
+	if valid {
+		return "✅"
+	}
+	return "❌"
+}
+
+func init() {
+	rootCmd.AddCommand(listsCmd)
+
+	listsCmd.AddCommand(listsDedupeCmd)
+	listsCmd.AddCommand(listsGroupCmd)
+	listsCmd.AddCommand(setOperation("union", "Valores presentes em qualquer uma das listas", func(first []string, others ...[]string) []string {
+		return lists.Union(append([][]string{first}, others...)...)
+	}))
+	listsCmd.AddCommand(setOperation("intersect", "Valores da primeira lista presentes em todas as outras", lists.Intersect))
+	listsCmd.AddCommand(setOperation("diff", "Valores da primeira lista ausentes das outras", lists.Diff))
+	listsCmd.AddCommand(listsNearDupesCmd)
+}
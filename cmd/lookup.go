@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/receita"
+	"github.com/spf13/cobra"
+)
+
+var lookupOpts struct {
+	db dbOptions
+}
+
+// lookupCmd representa o comando 'lookup'
+var lookupCmd = &cobra.Command{
+	Use:   "lookup <cnpj>...",
+	Short: "Consulta empresas nos Dados Abertos importados por import-rf",
+	Long: `Consulta o estabelecimento de cada CNPJ nos dados da Receita Federal importados pelo
+comando import-rf, sem acessar APIs externas: razão social, situação cadastral, natureza
+jurídica, CNAEs, endereço, Simples Nacional e sócios.
+
+O comando termina com código 2 se algum CNPJ for inválido ou não estiver na base.
+
+Exemplos de uso:
+  ./app lookup --sqlite rf.db 12.ABC.345/01DE-35
+  ./app lookup --sqlite rf.db 12ABC34501DE35 11222333000181 --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, dialect, err := lookupOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		missing := false
+		for _, arg := range args {
+			e, err := receita.Find(cmd.Context(), db, dialect, arg)
+			switch {
+			case errors.Is(err, receita.ErrNaoEncontrado):
+				missing = true
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), plain(msg("lookup.nao_encontrado", arg)))
+			case i18n.Code(err) != "":
+				missing = true
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), plain(msg("lookup.invalido", arg, i18n.Error(currentLang(), err))))
+			case err != nil:
+				_ = out.Close()
+				return err
+			default:
+				if err := out.Write(e, lookupText(e)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+
+		if missing {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+// lookupText é a representação de uma empresa consultada no formato text
+func lookupText(e receita.Empresa) string {
+	tipo := msg("lookup.filial")
+	if e.Matriz {
+		tipo = msg("lookup.matriz")
+	}
+	lines := []string{msg("lookup.empresa", e.RazaoSocial, cnpj.FormatCNPJ(e.CNPJ), tipo)}
+	if e.NomeFantasia != "" {
+		lines = append(lines, msg("lookup.fantasia", e.NomeFantasia))
+	}
+	lines = append(lines,
+		msg("lookup.situacao", e.Situacao, e.DataSituacao, e.DataInicioAtividade),
+		msg("lookup.natureza", e.NaturezaJuridica, e.Porte, e.CapitalSocial),
+		msg("lookup.cnae", e.CNAEPrincipal),
+	)
+	if len(e.CNAESecundarios) > 0 {
+		cnaes := make([]string, len(e.CNAESecundarios))
+		for i, c := range e.CNAESecundarios {
+			cnaes[i] = c.String()
+		}
+		lines = append(lines, msg("lookup.cnaes", strings.Join(cnaes, "; ")))
+	}
+
+	a := e.Endereco
+	municipio := a.Municipio.Descricao
+	if municipio == "" {
+		municipio = a.Municipio.Codigo
+	}
+	endereco := strings.Join(strings.Fields(strings.Join([]string{a.TipoLogradouro, a.Logradouro}, " ")), " ")
+	for _, part := range []string{a.Numero, a.Complemento} {
+		if part != "" {
+			endereco += ", " + part
+		}
+	}
+	lines = append(lines, msg("lookup.endereco", fmt.Sprintf("%s - %s, %s/%s, CEP %s", endereco, a.Bairro, municipio, a.UF, a.CEP)))
+
+	if e.Simples != nil {
+		yesNo := map[bool]string{true: msg("lookup.sim"), false: msg("lookup.nao")}
+		lines = append(lines, msg("lookup.simples", yesNo[e.Simples.Optante], yesNo[e.Simples.MEI]))
+	}
+	if len(e.Socios) > 0 {
+		socios := make([]string, len(e.Socios))
+		for i, s := range e.Socios {
+			socios[i] = fmt.Sprintf("%s (%s)", s.Nome, s.Qualificacao)
+		}
+		lines = append(lines, msg("lookup.socios", strings.Join(socios, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	rootCmd.AddCommand(lookupCmd)
+
+	addDBFlags(lookupCmd, &lookupOpts.db)
+}
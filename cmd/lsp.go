@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/lsp"
+	"github.com/spf13/cobra"
+)
+
+// lspCmd representa o comando 'lsp'
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Servidor de linguagem (LSP) que valida CNPJs nos documentos abertos no editor",
+	Long: `Inicia um servidor do Language Server Protocol sobre stdio. Qualquer editor com suporte a
+LSP passa a apontar os CNPJs inválidos de YAML, CSV, JSON ou código enquanto o arquivo é editado.
+
+  • Diagnósticos: CNPJs com máscara sempre; sem máscara, os válidos e os inválidos em linhas
+    (ou arquivos com cabeçalho) que citam "cnpj"
+  • Hover: valor formatado, validade, raiz, ordem e DV correto
+  • Code actions: corrigir o DV, aplicar ou remover a máscara, trocar por um CNPJ fictício
+
+Exemplos de configuração:
+  Neovim:  vim.lsp.start({ name = "cnpj", cmd = { "app", "lsp" } })
+  Helix:   [language-server.cnpj]
+           command = "app"
+           args = ["lsp"]`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return lsp.NewServer(currentLang()).Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+
+	// clientes como o do VS Code passam --stdio; a comunicação é sempre por stdio
+	lspCmd.Flags().Bool("stdio", true, "comunica-se pela entrada e saída padrão (único modo suportado)")
+}
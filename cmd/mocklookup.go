@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/mocklookup"
+	"github.com/spf13/cobra"
+)
+
+var mockLookupOpts struct {
+	addr string
+	mocklookup.Options
+}
+
+// mockLookupCmd representa o comando 'mock-lookup'
+var mockLookupCmd = &cobra.Command{
+	Use:   "mock-lookup",
+	Short: "Servidor local que imita as APIs públicas de consulta de CNPJ para testes",
+	Long: `Inicia um servidor HTTP que imita as rotas e o JSON da BrasilAPI e da ReceitaWS, para
+testes de integração sem acesso à rede:
+
+  GET /api/cnpj/v1/{cnpj}   → formato BrasilAPI
+  GET /v1/cnpj/{cnpj}       → formato ReceitaWS
+
+Todo CNPJ válido tem uma empresa fictícia, sempre a mesma para a mesma --seed; CNPJs
+inválidos respondem 400 e os listados em --not-found, 404. Latência, falhas injetadas e
+limite de requisições (429 com Retry-After) permitem testar a lógica de novas tentativas.
+
+Exemplos de uso:
+  ./app mock-lookup
+  ./app mock-lookup --addr :8080 --latency 200ms --jitter 300ms
+  ./app mock-lookup --fail-first 2 --error-status 503
+  ./app mock-lookup --rate-limit 3 --error-rate 0.1 --not-found 11222333000181
+
+curl http://localhost:4401/api/cnpj/v1/12ABC34501DE35`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o := mockLookupOpts.Options
+		if o.ErrorRate < 0 || o.ErrorRate > 1 {
+			return errors.New(msg("mocklookup.error_rate"))
+		}
+		if o.ErrorStatus != 0 && (o.ErrorStatus < 400 || o.ErrorStatus > 599) {
+			return errors.New(msg("mocklookup.error_status"))
+		}
+
+		log.Println(msg("mocklookup.iniciado", mockLookupOpts.addr))
+		return http.ListenAndServe(mockLookupOpts.addr, mocklookup.New(o))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockLookupCmd)
+
+	o := &mockLookupOpts
+	mockLookupCmd.Flags().StringVar(&o.addr, "addr", ":4401", "endereço em que o servidor escuta")
+	mockLookupCmd.Flags().Int64Var(&o.Seed, "seed", 0, "semente das empresas fictícias")
+	mockLookupCmd.Flags().DurationVar(&o.Latency, "latency", 0, "atraso de todas as respostas")
+	mockLookupCmd.Flags().DurationVar(&o.Jitter, "jitter", 0, "atraso adicional sorteado entre 0 e este valor")
+	mockLookupCmd.Flags().Float64Var(&o.ErrorRate, "error-rate", 0, "fração das requisições, entre 0 e 1, que falham")
+	mockLookupCmd.Flags().IntVar(&o.ErrorStatus, "error-status", http.StatusInternalServerError, "status HTTP das falhas injetadas")
+	mockLookupCmd.Flags().IntVar(&o.FailFirst, "fail-first", 0, "faz falhar as primeiras N consultas de cada CNPJ")
+	mockLookupCmd.Flags().IntVar(&o.RateLimit, "rate-limit", 0, "máximo de requisições por janela; acima dele responde 429 (0 desliga)")
+	mockLookupCmd.Flags().DurationVar(&o.Window, "rate-window", mocklookup.DefaultWindow, "janela do --rate-limit")
+	mockLookupCmd.Flags().StringSliceVar(&o.NotFound, "not-found", nil, "CNPJs válidos que respondem 404 (pode ser repetida)")
+}
@@ -0,0 +1,289 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFormat   string
+	outputTemplate string
+	noEmoji        bool
+	noColor        bool
+)
+
+// outputFormats lista os valores aceitos por --output em todos os comandos; o
+// formato sarif é exclusivo de scan, que o trata antes de criar o recordWriter
+var outputFormats = []string{"text", "json", "ndjson", "csv", "tsv"}
+
+// regexEmoji casa os emojis usados nas mensagens e os espaços que os seguem
+var regexEmoji = regexp.MustCompile(`[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}]\x{FE0F}?[ \t]*`)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "formato de saída: "+strings.Join(outputFormats, ", ")+"; scan também aceita sarif")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "template Go (text/template) aplicado a cada resultado")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "saída em texto simples, sem emojis")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "saída em texto simples, sem cores nem emojis (também via NO_COLOR)")
+}
+
+// plainOutput indica se a saída de texto deve ser escrita sem emojis nem cores
+func plainOutput() bool {
+	_, envNoColor := os.LookupEnv("NO_COLOR")
+	return noEmoji || noColor || envNoColor
+}
+
+// plain remove emojis do texto quando o modo simples está ativo
+func plain(text string) string {
+	if !plainOutput() {
+		return text
+	}
+
+	lines := strings.Split(regexEmoji.ReplaceAllString(text, ""), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordWriter escreve os resultados de um comando no formato escolhido em --output.
+// Cada registro é uma struct cujas tags json definem os nomes das colunas.
+type recordWriter struct {
+	out    *bufio.Writer
+	format string
+	tmpl   *template.Template
+	csv    *csv.Writer
+	count  int
+}
+
+// newRecordWriter cria o writer sobre cmd.OutOrStdout() conforme --output e --template
+func newRecordWriter(cmd *cobra.Command) (*recordWriter, error) {
+	return newRecordWriterTo(cmd.OutOrStdout())
+}
+
+func newRecordWriterTo(w io.Writer) (*recordWriter, error) {
+	rw := &recordWriter{out: bufio.NewWriter(w), format: outputFormat}
+
+	if outputTemplate != "" {
+		tmpl, err := template.New("output").Parse(outputTemplate)
+		if err != nil {
+			return nil, errors.New(msg("output.template", err))
+		}
+		rw.tmpl = tmpl
+		return rw, nil
+	}
+
+	switch rw.format {
+	case "text", "json", "ndjson":
+	case "csv":
+		rw.csv = csv.NewWriter(rw.out)
+	case "tsv":
+		rw.csv = csv.NewWriter(rw.out)
+		rw.csv.Comma = '\t'
+	default:
+		return nil, errors.New(msg("output.formato", rw.format, strings.Join(outputFormats, ", ")))
+	}
+	return rw, nil
+}
+
+// Text indica se o writer está no formato text, em que cada comando escreve suas próprias mensagens
+func (rw *recordWriter) Text() bool {
+	return rw.tmpl == nil && rw.format == "text"
+}
+
+// Write escreve um registro; text é a representação usada no formato text
+func (rw *recordWriter) Write(rec any, text string) error {
+	defer func() { rw.count++ }()
+
+	if rw.tmpl != nil {
+		if err := rw.tmpl.Execute(rw.out, rec); err != nil {
+			return err
+		}
+		return rw.out.WriteByte('\n')
+	}
+
+	switch rw.format {
+	case "text":
+		_, err := fmt.Fprintln(rw.out, plain(text))
+		return err
+	case "json":
+		prefix := ",\n  "
+		if rw.count == 0 {
+			prefix = "[\n  "
+		}
+		b, err := json.MarshalIndent(rec, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(rw.out, "%s%s", prefix, b)
+		return err
+	case "ndjson":
+		b, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(rw.out, "%s\n", b)
+		return err
+	default:
+		names, values := recordColumns(rec)
+		if rw.count == 0 {
+			if err := rw.csv.Write(names); err != nil {
+				return err
+			}
+		}
+		return rw.csv.Write(values)
+	}
+}
+
+// Flush descarrega o que já foi escrito, para comandos longos que mostram o progresso
+func (rw *recordWriter) Flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+		if err := rw.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return rw.out.Flush()
+}
+
+// Close finaliza o documento (o array JSON, por exemplo) e descarrega o buffer
+func (rw *recordWriter) Close() error {
+	switch {
+	case rw.tmpl != nil:
+	case rw.format == "json":
+		closing := "\n]\n"
+		if rw.count == 0 {
+			closing = "[]\n"
+		}
+		if _, err := rw.out.WriteString(closing); err != nil {
+			return err
+		}
+	case rw.csv != nil:
+		rw.csv.Flush()
+		if err := rw.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return rw.out.Flush()
+}
+
+// recordColumns extrai nomes (das tags json) e valores dos campos exportados de uma struct
+func recordColumns(rec any) ([]string, []string) {
+	v := reflect.Indirect(reflect.ValueOf(rec))
+	t := v.Type()
+
+	var names, values []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		names = append(names, name)
+		values = append(values, columnValue(v.Field(i)))
+	}
+	return names, values
+}
+
+func columnValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = v.Index(i).String()
+		}
+		return strings.Join(parts, "|")
+	}
+	if v.Kind() == reflect.Map {
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, fmt.Sprintf("%v=%v", iter.Key().Interface(), iter.Value().Interface()))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "|")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// replaceFile grava em um arquivo temporário no mesmo diretório do destino e o
+// renomeia ao final, para que um erro não deixe o arquivo pela metade
+func replaceFile(dest string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	err = write(tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if info, statErr := os.Stat(dest); statErr == nil {
+		_ = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// replaceInput é o replaceFile do --in-place: se a entrada aberta por openReader
+// estava comprimida, a saída é comprimida de novo com gzip
+func replaceInput(dest string, in io.Reader, write func(io.Writer) error) error {
+	if _, ok := in.(*gzip.Reader); !ok {
+		return replaceFile(dest, write)
+	}
+	return replaceFile(dest, func(w io.Writer) error {
+		gz := gzip.NewWriter(w)
+		if err := write(gz); err != nil {
+			return err
+		}
+		return gz.Close()
+	})
+}
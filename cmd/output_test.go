@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// executeCLI executa a CLI com os argumentos e captura stdout
+func executeCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		outputFormat, outputTemplate, noEmoji, noColor, langFlag = "text", "", false, false, ""
+		validateOpts, formatOpts = batchOptions{workers: 1}, batchOptions{workers: 1}
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetIn(strings.NewReader(""))
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestOutputFormats(t *testing.T) {
+	out, err := executeCLI(t, "validate", "--lang", "pt-BR", "12ABC34501DE35", "12ABC34501DE36")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[1] ✅  CNPJ válido:   12.ABC.345/01DE-35\n[2] ❌  CNPJ inválido: 12.ABC.345/01DE-36\n"
+	if out != expected {
+		t.Errorf("text output = %q, expected %q", out, expected)
+	}
+
+	out, err = executeCLI(t, "validate", "--output", "json", "12ABC34501DE35", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []cnpjRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(records) != 2 || !records[0].Valido || records[1].Valido || records[1].Indice != 2 {
+		t.Errorf("unexpected records: %+v", records)
+	}
+
+	out, _ = executeCLI(t, "format", "--output", "csv", "12ABC34501DE35")
+	if out != "indice,original,formatado,valido\n1,12ABC34501DE35,12.ABC.345/01DE-35,true\n" {
+		t.Errorf("csv output = %q", out)
+	}
+
+	out, _ = executeCLI(t, "validate", "--output", "ndjson", "12ABC34501DE35", "x")
+	if strings.Count(out, "\n") != 2 || !strings.HasPrefix(out, `{"indice":1,`) {
+		t.Errorf("ndjson output = %q", out)
+	}
+
+	out, _ = executeCLI(t, "validate", "--template", "{{.Original}}={{.Valido}}", "12ABC34501DE35")
+	if out != "12ABC34501DE35=true\n" {
+		t.Errorf("template output = %q", out)
+	}
+}
+
+func TestPlainOutput(t *testing.T) {
+	out, _ := executeCLI(t, "validate", "--lang", "pt-BR", "--no-emoji", "12ABC34501DE35")
+	if out != "[1] CNPJ válido:   12.ABC.345/01DE-35\n" {
+		t.Errorf("plain output = %q", out)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	_, err := executeCLI(t, "validate", "--quiet", "12ABC34501DE36")
+	if !errors.Is(err, errInvalidFound) {
+		t.Errorf("expected errInvalidFound, got %v", err)
+	}
+
+	out, err := executeCLI(t, "validate", "--quiet", "12ABC34501DE35")
+	if err != nil || out != "" {
+		t.Errorf("expected silent success, got %q, %v", out, err)
+	}
+
+	if _, err := executeCLI(t, "validate", "--output", "xml", "1"); err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
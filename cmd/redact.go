@@ -0,0 +1,163 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+// redactKeyEnv é a variável de ambiente lida quando --key não é informada
+const redactKeyEnv = "CNPJ_REDACT_KEY"
+
+var redactOpts struct {
+	policy  string
+	key     string
+	inPlace bool
+	count   bool
+}
+
+// redactRecord é a contagem de substituições de um arquivo
+type redactRecord struct {
+	Arquivo       string `json:"arquivo"`
+	Substituicoes int    `json:"substituicoes"`
+}
+
+// redactCmd representa o comando 'redact'
+var redactCmd = &cobra.Command{
+	Use:   "redact [arquivo...]",
+	Short: "Mascara os CNPJs de logs e dumps",
+	Long: `Substitui todos os CNPJs (com ou sem máscara) de arquivos ou de stdin, funcionando como um
+filtro Unix. A entrada é lida em blocos, com memória constante, qualquer que seja o tamanho.
+Tudo que tem a forma de um CNPJ é substituído, mesmo com DV incorreto ou em minúsculas, e
+arquivos .gz reescritos com --in-place continuam comprimidos.
+
+Políticas (--policy):
+  full     **.***.***/****-**          (padrão)
+  partial  12.ABC.345/****-**          mantém a raiz
+  hmac     [CNPJ:3f9a0c...]            token derivado de --key ou $CNPJ_REDACT_KEY
+  fake     CNPJ fictício válido, sempre o mesmo para a mesma entrada e chave
+
+Exemplos de uso:
+  cat app.log | ./app redact > app-mascarado.log
+  ./app redact --policy partial --count app.log.gz > app-mascarado.log
+  CNPJ_REDACT_KEY=segredo ./app redact --policy hmac dump.sql > dump-mascarado.sql
+  ./app redact --policy fake --in-place --count logs/*.log`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, err := redact.ParsePolicy(redactOpts.policy)
+		if err != nil {
+			return err
+		}
+		key := redactOpts.key
+		if key == "" {
+			key = os.Getenv(redactKeyEnv)
+		}
+		r, err := redact.New(policy, []byte(key))
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+		if redactOpts.inPlace {
+			for _, file := range args {
+				if file == "-" {
+					return errors.New(msg("flag.in_place"))
+				}
+			}
+		}
+
+		var counts []redactRecord
+		out := bufio.NewWriterSize(cmd.OutOrStdout(), 64*1024)
+		for _, file := range args {
+			n, err := redactFile(cmd, r, file, out)
+			if err != nil {
+				return err
+			}
+			counts = append(counts, redactRecord{Arquivo: file, Substituicoes: n})
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+
+		if !redactOpts.count {
+			return nil
+		}
+		report, err := newRecordWriterTo(cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		for _, c := range counts {
+			if err := report.Write(c, msg("redact.contagem", c.Arquivo, c.Substituicoes)); err != nil {
+				return err
+			}
+		}
+		return report.Close()
+	},
+}
+
+// redactFile mascara um arquivo (ou stdin, com "-") para out ou, com --in-place, para ele mesmo
+func redactFile(cmd *cobra.Command, r *redact.Redactor, file string, out io.Writer) (int, error) {
+	in, closers, err := openReader(cmd, file)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}()
+	if in == nil {
+		return 0, nil
+	}
+
+	if !redactOpts.inPlace {
+		return r.Copy(out, in)
+	}
+
+	var n int
+	err = replaceInput(file, in, func(w io.Writer) error {
+		bw := bufio.NewWriterSize(w, 64*1024)
+		var err error
+		if n, err = r.Copy(bw, in); err != nil {
+			return err
+		}
+		return bw.Flush()
+	})
+	return n, err
+}
+
+func init() {
+	rootCmd.AddCommand(redactCmd)
+
+	redactCmd.Flags().StringVar(&redactOpts.policy, "policy", "full", "política de substituição: full, partial, hmac ou fake")
+	redactCmd.Flags().StringVar(&redactOpts.key, "key", "", "Chave das políticas hmac e fake (padrão: $"+redactKeyEnv+")")
+	redactCmd.Flags().BoolVar(&redactOpts.inPlace, "in-place", false, "reescreve os próprios arquivos em vez de escrever na saída padrão")
+	redactCmd.Flags().BoolVar(&redactOpts.count, "count", false, "imprime na saída de erro a quantidade de substituições por arquivo")
+}
@@ -23,11 +23,25 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
 	"github.com/spf13/cobra"
 )
 
+var langFlag string
+
+// exitCodeError encerra a CLI com o código informado, sem mensagem de erro adicional
+type exitCodeError int
+
+func (e exitCodeError) Error() string { return fmt.Sprintf("código de saída %d", int(e)) }
+
+// errInvalidFound indica que a entrada tinha valores inválidos: a CLI termina com
+// 0 quando tudo é válido, 1 em erros de execução e 2 quando há inválidos
+const errInvalidFound = exitCodeError(2)
+
 // rootCmd representa o comando base quando nenhum subcomando é fornecido
 var rootCmd = &cobra.Command{
 	Use:   "AlfanumericCNPJ",
@@ -38,16 +52,43 @@ Comandos disponíveis:
   • generate  → Gera um novo CNPJ válido
   • validate  → Valida um ou mais CNPJs fornecidos
   • format    → Aplica a máscara padrão em CNPJs alfanuméricos
+  • conformance → Verifica o cálculo de DV contra vetores de referência
+  • csv       → Valida e anota a coluna de CNPJ de arquivos CSV/TSV
+  • json      → Valida CNPJs em documentos JSON/NDJSON por seletores
+  • scan      → Procura CNPJs reais em arquivos e commits
+  • redact    → Mascara os CNPJs de logs e dumps
+  • interactive → Valida CNPJs à medida que são digitados
+  • lists     → Deduplica, agrupa e compara listas de CNPJs
+  • stats     → Relatório de qualidade de uma lista ou coluna de CNPJs
+  • sql       → DDL de funções, domínio e migrações de CNPJ para PostgreSQL e SQLite
+  • db        → Auditoria e normalização de colunas de CNPJ em PostgreSQL e SQLite
+  • lsp       → Servidor de linguagem que valida CNPJs nos documentos abertos no editor
+  • fixtures  → Gera empresas fictícias com filiais, sócios, endereço e CNAE
+  • import-rf → Importa os Dados Abertos do CNPJ da Receita Federal para um banco local
+  • lookup    → Consulta empresas nos Dados Abertos importados, sem APIs externas
+  • mock-lookup → Imita as APIs públicas de consulta de CNPJ para testes de integração
+  • chave     → Valida, interpreta e gera chaves de acesso de NF-e, CT-e e MDF-e
 
 Exemplo de uso:
   ./AlfanumericCNPJ generate
   ./AlfanumericCNPJ validate GI.FZX.OWD/NZYM-40
-  ./AlfanumericCNPJ format ABCDEFGHIJKL80`,
+  ./AlfanumericCNPJ format ABCDEFGHIJKL80
+
+Códigos de saída:
+  0  sucesso, todos os valores válidos
+  1  erro de execução
+  2  há valores inválidos (validate/format com --quiet ou --fail-on-invalid)`,
 }
 
 // Execute executa o comando root e todos os subcomandos registrados
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var code exitCodeError
+		if errors.As(err, &code) {
+			os.Exit(int(code))
+		}
+
+		rootCmd.PrintErrln(msg("erro", i18n.Error(currentLang(), err)))
 		os.Exit(1)
 	}
 }
@@ -55,4 +96,18 @@ func Execute() {
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "idioma das mensagens: pt-BR, en ou es (padrão: $LANG)")
+}
+
+// currentLang resolve o idioma da CLI pela flag --lang e pelas variáveis de ambiente de locale
+func currentLang() i18n.Lang {
+	return i18n.Detect(langFlag, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG"))
+}
+
+// msg traduz a chave do catálogo para o idioma atual da CLI
+func msg(key string, args ...any) string {
+	return i18n.T(currentLang(), key, args...)
 }
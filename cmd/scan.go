@@ -0,0 +1,294 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/scanner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultAllowlist = ".cnpj-allowlist"
+	defaultBaseline  = ".cnpj-baseline.json"
+	// hookMarker identifica o hook de pre-commit instalado por este comando
+	hookMarker = "# Instalado por AlfanumericCNPJ scan --install-hook"
+)
+
+var scanOpts struct {
+	allowlist      string
+	baseline       string
+	updateBaseline bool
+	staged         bool
+	installHook    bool
+	strict         bool
+	maxSize        int64
+}
+
+// scanRecord é um CNPJ encontrado pelo comando 'scan'
+type scanRecord struct {
+	Arquivo     string `json:"arquivo"`
+	Linha       int    `json:"linha"`
+	Coluna      int    `json:"coluna"`
+	Valor       string `json:"valor"`
+	Mascarado   bool   `json:"mascarado"`
+	Valido      bool   `json:"valido"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// scanCmd representa o comando 'scan'
+var scanCmd = &cobra.Command{
+	Use:   "scan [caminho...]",
+	Short: "Procura CNPJs em arquivos para evitar o vazamento de dados reais",
+	Long: `Percorre diretórios (respeitando .gitignore) e aponta os CNPJs encontrados. Valores com
+DV válido são tratados como possíveis dados reais e fazem o comando terminar com código 2;
+valores com máscara e DV incorreto são apenas informados (ou também falham com --strict).
+
+Valores sabidamente fictícios podem ser listados em .cnpj-allowlist (um por linha) e
+achados já conhecidos podem ser congelados em .cnpj-baseline.json com --update-baseline.
+O baseline guarda apenas fingerprints, nunca os valores.
+
+Com --output sarif o relatório sai no formato SARIF 2.1.0, para serviços de code scanning.
+
+Exemplos de uso:
+  ./app scan
+  ./app scan testdata/ logs/ --output json
+  ./app scan --output sarif > cnpj.sarif
+  ./app scan --update-baseline
+  ./app scan --install-hook     # instala o hook de pre-commit (usa --staged)`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if scanOpts.installHook {
+			return installPreCommitHook(cmd)
+		}
+
+		opts := scanner.Options{MaxFileSize: scanOpts.maxSize}
+		_, err := loadScanFile(scanOpts.allowlist, defaultAllowlist, func(r io.Reader) error {
+			var err error
+			opts.Allowlist, err = scanner.LoadAllowlist(r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		baselinePath, err := loadScanFile(scanOpts.baseline, defaultBaseline, func(r io.Reader) error {
+			if scanOpts.updateBaseline {
+				return nil
+			}
+			var err error
+			opts.Baseline, err = scanner.LoadBaseline(r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		var findings []scanner.Finding
+		if scanOpts.staged {
+			findings, err = scanStaged(opts)
+		} else {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+			for _, root := range args {
+				found, scanErr := scanner.ScanTree(root, opts)
+				if scanErr != nil {
+					return scanErr
+				}
+				findings = append(findings, found...)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if scanOpts.updateBaseline {
+			if baselinePath == "" {
+				baselinePath = defaultBaseline
+			}
+			err := replaceFile(baselinePath, func(w io.Writer) error {
+				_, err := scanner.NewBaseline(findings).WriteTo(w)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			cmd.PrintErrln(plain(msg("scan.baseline", len(findings), baselinePath)))
+			return nil
+		}
+
+		if err := writeScanReport(cmd, findings); err != nil {
+			return err
+		}
+		for _, f := range findings {
+			if f.Valid || scanOpts.strict {
+				return errInvalidFound
+			}
+		}
+		return nil
+	},
+}
+
+// loadScanFile abre o arquivo informado na flag ou, se a flag estiver vazia, o
+// arquivo padrão quando ele existir. Retorna o caminho efetivamente usado.
+func loadScanFile(flag, fallback string, load func(io.Reader) error) (string, error) {
+	path := flag
+	if path == "" {
+		if _, err := os.Stat(fallback); err != nil {
+			return "", nil
+		}
+		path = fallback
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if flag != "" && errors.Is(err, os.ErrNotExist) && scanOpts.updateBaseline {
+			return path, nil
+		}
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := load(f); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return path, nil
+}
+
+// scanStaged examina o conteúdo dos arquivos no índice do git, que é o que será
+// de fato commitado, e não a cópia de trabalho
+func scanStaged(opts scanner.Options) ([]scanner.Finding, error) {
+	out, err := exec.Command("git", "diff", "--cached", "--name-only", "-z", "--diff-filter=ACMR").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --cached: %w", err)
+	}
+
+	var findings []scanner.Finding
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		content, err := exec.Command("git", "show", ":"+name).Output()
+		if err != nil {
+			return nil, fmt.Errorf("git show :%s: %w", name, err)
+		}
+		if opts.MaxFileSize > 0 && int64(len(content)) > opts.MaxFileSize {
+			continue
+		}
+		found, err := scanner.ScanReader(name, bytes.NewReader(content), opts)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+// installPreCommitHook grava um hook de pre-commit que executa 'scan --staged'.
+// Um hook existente que não foi instalado por este comando não é sobrescrito.
+func installPreCommitHook(cmd *cobra.Command) error {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return errors.New(msg("scan.sem_git", err))
+	}
+	hooks := strings.TrimSpace(string(out))
+	hook := filepath.Join(hooks, "pre-commit")
+
+	if existing, err := os.ReadFile(hook); err == nil && !strings.Contains(string(existing), hookMarker) {
+		return errors.New(msg("scan.hook_existente", hook, filepath.Base(os.Args[0])))
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf("#!/bin/sh\n%s\nexec %q scan --staged\n", hookMarker, exe)
+
+	if err := os.MkdirAll(hooks, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(hook, []byte(script), 0o755); err != nil {
+		return err
+	}
+	cmd.PrintErrln(plain(msg("scan.hook", hook)))
+	return nil
+}
+
+func writeScanReport(cmd *cobra.Command, findings []scanner.Finding) error {
+	if outputFormat == "sarif" {
+		return scanner.WriteSARIF(cmd.OutOrStdout(), findings, "")
+	}
+
+	out, err := newRecordWriter(cmd)
+	if err != nil {
+		return err
+	}
+	for _, f := range findings {
+		key := "scan.invalido"
+		if f.Valid {
+			key = "scan.valido"
+		}
+		rec := scanRecord{
+			Arquivo:     f.Path,
+			Linha:       f.Line,
+			Coluna:      f.Column,
+			Valor:       f.Value,
+			Mascarado:   f.Masked,
+			Valido:      f.Valid,
+			Fingerprint: f.Fingerprint,
+		}
+		if err := out.Write(rec, msg(key, f.Path, f.Line, f.Column, f.Value)); err != nil {
+			return err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if len(findings) == 0 && out.Text() {
+		cmd.PrintErrln(plain(msg("scan.nenhum")))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().StringVar(&scanOpts.allowlist, "allowlist", "", "Arquivo com CNPJs fictícios permitidos (padrão: "+defaultAllowlist+", se existir)")
+	scanCmd.Flags().StringVar(&scanOpts.baseline, "baseline", "", "Arquivo de baseline com achados conhecidos (padrão: "+defaultBaseline+", se existir)")
+	scanCmd.Flags().BoolVar(&scanOpts.updateBaseline, "update-baseline", false, "grava todos os achados atuais no baseline")
+	scanCmd.Flags().BoolVar(&scanOpts.staged, "staged", false, "examina apenas os arquivos no índice do git (modo pre-commit)")
+	scanCmd.Flags().BoolVar(&scanOpts.installHook, "install-hook", false, "instala um hook de pre-commit que executa 'scan --staged'")
+	scanCmd.Flags().BoolVar(&scanOpts.strict, "strict", false, "termina com código 2 também para valores com máscara e DV incorreto")
+	scanCmd.Flags().Int64Var(&scanOpts.maxSize, "max-size", scanner.DefaultMaxFileSize, "ignora arquivos maiores que este tamanho, em bytes")
+}
@@ -0,0 +1,341 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	"github.com/spf13/cobra"
+)
+
+// cada subcomando tem as próprias opções: flags com o mesmo nome e padrões
+// diferentes não podem compartilhar a variável
+var (
+	sqlDDLOpts struct {
+		dialect string
+		plpgsql bool
+		column  string
+	}
+	sqlMigrateOpts struct {
+		dialect  string
+		table    string
+		column   string
+		dryRun   bool
+		examples int
+		db       dbOptions
+	}
+	sqlQueryOpts struct {
+		db dbOptions
+	}
+)
+
+// sqlCmd representa o comando 'sql', que agrupa os geradores de DDL
+var sqlCmd = &cobra.Command{
+	Use:   "sql",
+	Short: "Gera DDL para que o banco rejeite CNPJs inválidos",
+	Long: `Gera SQL específico de cada banco para validar CNPJs alfanuméricos no próprio banco,
+com o mesmo cálculo de DV da biblioteca Go.
+
+  • ddl     → funções cnpj_is_valid, cnpj_dv e cnpj_format e o domínio cnpj (PostgreSQL)
+              ou as expressões equivalentes para CHECK (SQLite)
+  • migrate → restrição sobre uma coluna existente; com --dry-run, informa quantas
+              linhas seriam rejeitadas, sem alterar o banco
+  • query   → executa uma consulta em um arquivo SQLite com as funções cnpj_valid,
+              cnpj_dv, cnpj_format, cnpj_normalize, cnpj_raiz e cnpj_generate_series
+
+Exemplos de uso:
+  ./app sql ddl --dialect postgres > cnpj.sql
+  ./app sql ddl --dialect postgres --plpgsql
+  ./app sql ddl --dialect sqlite --column documento
+  ./app sql migrate --dialect postgres --table fornecedores --column documento
+  ./app sql migrate --sqlite dados.db --table fornecedores --column documento --dry-run
+  ./app sql query --sqlite dados.db "SELECT * FROM fornecedores WHERE NOT cnpj_valid(doc)"
+  ./app sql query --sqlite :memory: "SELECT cnpj FROM cnpj_generate_series(10, 42)" --output csv`,
+}
+
+var sqlDDLCmd = &cobra.Command{
+	Use:   "ddl",
+	Short: "Gera as funções de validação, DV e formatação e o domínio cnpj",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dialect, err := sqlddl.ParseDialect(sqlDDLOpts.dialect)
+		if err != nil {
+			return err
+		}
+		ddl, err := sqlddl.Functions(dialect, sqlDDLOpts.plpgsql, sqlDDLOpts.column)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), ddl)
+		return err
+	},
+}
+
+var sqlMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Gera a migração que restringe uma coluna existente a CNPJs válidos",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sqlMigrateOpts.table == "" || sqlMigrateOpts.column == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("sql.tabela"))
+		}
+
+		if !sqlMigrateOpts.dryRun {
+			dialect, err := sqlDialect()
+			if err != nil {
+				return err
+			}
+			ddl, err := sqlddl.Migration(dialect, sqlMigrateOpts.table, sqlMigrateOpts.column)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), ddl)
+			return err
+		}
+
+		db, _, err := sqlMigrateOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		report, err := sqlddl.DryRun(cmd.Context(), db, sqlMigrateOpts.table, sqlMigrateOpts.column, sqlMigrateOpts.examples)
+		if err != nil {
+			return err
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if err := out.Write(report, dryRunText(report)); err != nil {
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+		if report.Invalidos > 0 {
+			return errInvalidFound
+		}
+		return nil
+	},
+}
+
+var sqlQueryCmd = &cobra.Command{
+	Use:   "query <consulta>",
+	Short: "Executa uma consulta SQLite com as funções de CNPJ registradas",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sqlQueryOpts.db.sqlite == "" {
+			_ = cmd.Usage()
+			return errors.New(msg("sql.sqlite"))
+		}
+
+		db, _, err := sqlQueryOpts.db.open(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
+		rows, err := db.QueryContext(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		return writeRows(cmd, rows)
+	},
+}
+
+// writeRows escreve o resultado de uma consulta conforme --output; no formato
+// text as colunas são separadas por tabulação, com o cabeçalho na primeira linha
+func writeRows(cmd *cobra.Command, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(cmd.OutOrStdout())
+	var table *csv.Writer
+	switch outputFormat {
+	case "json", "ndjson":
+	case "csv":
+		table = csv.NewWriter(out)
+	case "text", "tsv":
+		table = csv.NewWriter(out)
+		table.Comma = '\t'
+	default:
+		return errors.New(msg("output.formato", outputFormat, strings.Join(outputFormats, ", ")))
+	}
+	if table != nil {
+		if err := table.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		if table != nil {
+			record := make([]string, len(values))
+			for i, v := range values {
+				record[i] = sqlText(v)
+			}
+			if err := table.Write(record); err != nil {
+				return err
+			}
+			continue
+		}
+
+		obj := make(map[string]any, len(columns))
+		for i, c := range columns {
+			obj[c] = values[i]
+			if b, ok := values[i].([]byte); ok {
+				obj[c] = string(b)
+			}
+		}
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		switch {
+		case outputFormat == "ndjson":
+			_, err = fmt.Fprintf(out, "%s\n", b)
+		case count == 0:
+			_, err = fmt.Fprintf(out, "[\n  %s", b)
+		default:
+			_, err = fmt.Fprintf(out, ",\n  %s", b)
+		}
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	switch {
+	case table != nil:
+		table.Flush()
+		if err := table.Error(); err != nil {
+			return err
+		}
+	case outputFormat == "json" && count == 0:
+		_, _ = out.WriteString("[]\n")
+	case outputFormat == "json":
+		_, _ = out.WriteString("\n]\n")
+	}
+	return out.Flush()
+}
+
+// sqlText converte um valor lido do banco para texto; NULL vira vazio
+func sqlText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(t)
+	}
+	return fmt.Sprint(v)
+}
+
+// sqlDialect usa --dialect ou, se ausente, o dialeto da conexão informada
+func sqlDialect() (sqlddl.Dialect, error) {
+	switch {
+	case sqlMigrateOpts.dialect != "":
+		return sqlddl.ParseDialect(sqlMigrateOpts.dialect)
+	case sqlMigrateOpts.db.sqlite != "":
+		return sqlddl.SQLite, nil
+	case sqlMigrateOpts.db.pgHost != "":
+		return sqlddl.Postgres, nil
+	}
+	return sqlddl.Postgres, nil
+}
+
+// dryRunText monta o relatório exibido no terminal
+func dryRunText(r sqlddl.Report) string {
+	var b strings.Builder
+	b.WriteString(msg("sql.dryrun", r.Tabela, r.Coluna, r.Total, r.Validos, r.Nulos, r.Invalidos))
+
+	codes := make([]string, 0, len(r.Motivos))
+	for code := range r.Motivos {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		b.WriteString(fmt.Sprintf("\n   %7d  %s", r.Motivos[code], i18n.T(currentLang(), code)))
+	}
+
+	if len(r.Exemplos) > 0 {
+		b.WriteString("\n" + msg("sql.exemplos"))
+		for _, e := range r.Exemplos {
+			b.WriteString("\n   " + e)
+		}
+	}
+	if r.Invalidos == 0 {
+		b.WriteString("\n" + msg("sql.pronto"))
+	}
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(sqlCmd)
+	sqlCmd.AddCommand(sqlDDLCmd, sqlMigrateCmd, sqlQueryCmd)
+
+	sqlDDLCmd.Flags().StringVar(&sqlDDLOpts.dialect, "dialect", "postgres", "banco de destino: postgres ou sqlite")
+	sqlDDLCmd.Flags().BoolVar(&sqlDDLOpts.plpgsql, "plpgsql", false, "gera as funções em PL/pgSQL em vez de SQL puro (PostgreSQL)")
+	sqlDDLCmd.Flags().StringVar(&sqlDDLOpts.column, "column", "cnpj", "coluna usada nas expressões do SQLite")
+
+	sqlMigrateCmd.Flags().StringVar(&sqlMigrateOpts.dialect, "dialect", "", "banco de destino: postgres ou sqlite (padrão: o da conexão, ou postgres)")
+	sqlMigrateCmd.Flags().StringVar(&sqlMigrateOpts.table, "table", "", "tabela que contém a coluna")
+	sqlMigrateCmd.Flags().StringVar(&sqlMigrateOpts.column, "column", "", "coluna de CNPJ")
+	sqlMigrateCmd.Flags().BoolVar(&sqlMigrateOpts.dryRun, "dry-run", false, "apenas conta as linhas que a restrição rejeitaria, sem alterar o banco")
+	sqlMigrateCmd.Flags().IntVar(&sqlMigrateOpts.examples, "examples", 10, "quantidade de valores inválidos exibidos no --dry-run")
+	addDBFlags(sqlMigrateCmd, &sqlMigrateOpts.db)
+
+	sqlQueryCmd.Flags().StringVar(&sqlQueryOpts.db.sqlite, "sqlite", "", "arquivo do banco SQLite (ou :memory:)")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+)
+
+// TestSQLDDLDefaults tests that sql ddl works with the default flags, which migrate
+// must not overwrite
+func TestSQLDDLDefaults(t *testing.T) {
+	t.Cleanup(func() {
+		sqlDDLOpts.dialect, sqlDDLOpts.column = "postgres", "cnpj"
+	})
+
+	out, err := executeCLI(t, "sql", "ddl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "CREATE OR REPLACE FUNCTION cnpj_dv") {
+		t.Errorf("postgres DDL missing cnpj_dv:\n%s", out)
+	}
+
+	out, err = executeCLI(t, "sql", "ddl", "--dialect", "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "sobre a coluna cnpj") {
+		t.Errorf("sqlite DDL should use the cnpj column:\n%s", out)
+	}
+}
+
+// TestSQLErrorsTranslated tests that sqlddl errors carry a code from the catalog
+func TestSQLErrorsTranslated(t *testing.T) {
+	t.Cleanup(func() {
+		sqlDDLOpts.dialect = "postgres"
+	})
+
+	_, err := executeCLI(t, "sql", "ddl", "--dialect", "oracle")
+	if got := i18n.Error(i18n.En, err); got != "unknown SQL dialect (use postgres or sqlite)" {
+		t.Errorf("translated error = %q", got)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 MadHouse madhouse@admin.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/csvcnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsOpts struct {
+	csv       bool
+	column    string
+	delimiter string
+	html      string
+	top       int
+}
+
+// statsCmd representa o comando 'stats'
+var statsCmd = &cobra.Command{
+	Use:   "stats [arquivo]",
+	Short: "Gera um relatório de qualidade de uma lista ou coluna de CNPJs",
+	Long: `Traça o perfil de uma lista de CNPJs (um por linha) ou da coluna de CNPJ de um CSV:
+totais, válidos e inválidos por motivo, participação de numéricos (legado) e alfanuméricos,
+com e sem máscara, duplicados, empresas com mais estabelecimentos e a frequência de
+caracteres em cada posição.
+
+O relatório sai como tabela no terminal, em JSON (--output json) ou em um arquivo HTML
+autocontido (--html).
+
+Exemplos de uso:
+  ./app stats cnpjs.txt
+  ./app stats --column documento clientes.csv
+  ./app stats clientes.csv --output json > perfil.json
+  ./app stats clientes.csv --html perfil.html`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := "-"
+		if len(args) == 1 {
+			file = args[0]
+		}
+		ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(file, ".gz")))
+		asCSV := statsOpts.csv || statsOpts.column != "" || ext == ".csv" || ext == ".tsv"
+
+		c := stats.New()
+		if asCSV {
+			if err := collectCSV(cmd, file, c); err != nil {
+				return err
+			}
+		} else {
+			src, err := openValues(cmd, nil, file)
+			if err != nil {
+				return err
+			}
+			for v, ok := src.Next(); ok; v, ok = src.Next() {
+				c.Add(v)
+			}
+			err = src.Err()
+			if closeErr := src.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		report := c.Report(statsOpts.top)
+		if statsOpts.html != "" {
+			f, err := os.Create(statsOpts.html)
+			if err != nil {
+				return err
+			}
+			err = stats.WriteHTML(f, report, statsLabels())
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
+		}
+		if err := out.Write(report, statsText(report)); err != nil {
+			return err
+		}
+		return out.Close()
+	},
+}
+
+// collectCSV acumula os valores da coluna de CNPJ de um CSV
+func collectCSV(cmd *cobra.Command, file string, c *stats.Collector) error {
+	delim, err := parseDelimiter(statsOpts.delimiter)
+	if err != nil {
+		return err
+	}
+	opts := csvcnpj.Options{Column: statsOpts.column, Delimiter: delim}
+
+	r, closers, err := openReader(cmd, file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, cl := range closers {
+			_ = cl.Close()
+		}
+	}()
+	if r == nil {
+		return nil
+	}
+
+	_, err = csvcnpj.Values(r, opts, func(v string) error {
+		c.Add(v)
+		return nil
+	})
+	return err
+}
+
+// statsText monta a tabela exibida no terminal
+func statsText(r stats.Report) string {
+	var b strings.Builder
+	line := func(key string, args ...any) {
+		b.WriteString(msg(key, args...))
+		b.WriteByte('\n')
+	}
+	valued := r.Total - r.Vazios
+
+	line("stats.total", r.Total, r.Distintos, r.Duplicados, stats.Percent(r.Duplicados, valued))
+	line("stats.validade", r.Validos, stats.Percent(r.Validos, r.Total), r.Invalidos, stats.Percent(r.Invalidos, r.Total))
+
+	codes := make([]string, 0, len(r.Motivos))
+	for code := range r.Motivos {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return r.Motivos[codes[i]] > r.Motivos[codes[j]] || r.Motivos[codes[i]] == r.Motivos[codes[j]] && codes[i] < codes[j]
+	})
+	for _, code := range codes {
+		b.WriteString(fmt.Sprintf("   %7d  %s\n", r.Motivos[code], i18n.T(currentLang(), code)))
+	}
+
+	tipos := r.Numericos + r.Alfanumericos
+	line("stats.tipo", r.Numericos, stats.Percent(r.Numericos, tipos), r.Alfanumericos, stats.Percent(r.Alfanumericos, tipos))
+	line("stats.mascara", r.ComMascara, stats.Percent(r.ComMascara, valued), r.SemMascara, stats.Percent(r.SemMascara, valued))
+
+	if len(r.MaisRepetidos) > 0 {
+		line("stats.repetidos")
+		for _, c := range r.MaisRepetidos {
+			b.WriteString(fmt.Sprintf("   %7d  %s\n", c.Quantidade, c.Valor))
+		}
+	}
+	if len(r.Empresas) > 0 {
+		line("stats.empresas")
+		for _, c := range r.Empresas {
+			b.WriteString(fmt.Sprintf("   %7d  %s\n", c.Quantidade, c.Valor))
+		}
+	}
+
+	line("stats.posicoes")
+	for _, p := range r.Posicoes {
+		parts := make([]string, len(p.Frequencias))
+		for i, f := range p.Frequencias {
+			parts[i] = fmt.Sprintf("%s %5.1f%%", f.Valor, stats.Percent(f.Quantidade, tipos))
+		}
+		b.WriteString(fmt.Sprintf("   %2d  %s\n", p.Posicao, strings.Join(parts, "   ")))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// statsLabels traduz os textos do relatório HTML para o idioma da CLI
+func statsLabels() stats.Labels {
+	return stats.Labels{
+		Titulo: msg("stats.html.titulo"), Total: msg("stats.html.total"), Validos: msg("stats.html.validos"),
+		Invalidos: msg("stats.html.invalidos"), Vazios: msg("stats.html.vazios"), Motivos: msg("stats.html.motivos"),
+		Numericos: msg("stats.html.numericos"), Alfanumericos: msg("stats.html.alfanumericos"),
+		ComMascara: msg("stats.html.com_mascara"), SemMascara: msg("stats.html.sem_mascara"),
+		Distintos: msg("stats.html.distintos"), Duplicados: msg("stats.html.duplicados"),
+		MaisRepetidos: msg("stats.html.mais_repetidos"), Empresas: msg("stats.html.empresas"),
+		Posicoes: msg("stats.html.posicoes"), Posicao: msg("stats.html.posicao"), Quantidade: msg("stats.html.quantidade"),
+		Estabelecimentos: msg("stats.html.estabelecimentos"), Valor: msg("stats.html.valor"),
+		Caractere: msg("stats.html.caractere"), Motivo: msg("stats.html.motivo"),
+		MotivoTexto: func(code string) string { return i18n.T(currentLang(), code) },
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsOpts.csv, "csv", false, "lê a entrada como CSV (automático para .csv, .tsv e com --column)")
+	statsCmd.Flags().StringVarP(&statsOpts.column, "column", "c", "", "nome ou índice (a partir de 1) da coluna de CNPJ; detectada automaticamente se omitido")
+	statsCmd.Flags().StringVar(&statsOpts.delimiter, "delimiter", "auto", "delimitador do CSV (auto, ',', ';', tab, '|')")
+	statsCmd.Flags().StringVar(&statsOpts.html, "html", "", "grava também um relatório HTML autocontido neste arquivo")
+	statsCmd.Flags().IntVar(&statsOpts.top, "top", 10, "quantidade de itens nas listas de repetidos, empresas e caracteres por posição")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStatsHTMLLang tests that the HTML report follows --lang
+func TestStatsHTMLLang(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "cnpjs.txt")
+	if err := os.WriteFile(in, []byte("12ABC34501DE35\n11222333000181\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "perfil.html")
+	t.Cleanup(func() {
+		statsOpts.html = ""
+	})
+
+	if _, err := executeCLI(t, "stats", "--lang", "en", "--html", out, in); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"CNPJ column profile", "Unmasked"} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("HTML report missing %q", s)
+		}
+	}
+	if strings.Contains(string(b), "Perfil da coluna") {
+		t.Error("HTML report has Portuguese headings with --lang en")
+	}
+}
@@ -27,6 +27,32 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var validateOpts batchOptions
+
+// cnpjRecord é o resultado de validate e format nos formatos estruturados
+type cnpjRecord struct {
+	Indice    int    `json:"indice"`
+	Original  string `json:"original"`
+	Formatado string `json:"formatado"`
+	Valido    bool   `json:"valido"`
+}
+
+func newCNPJRecord(valor string) cnpjRecord {
+	rec := cnpjRecord{Original: valor, Valido: cnpj.IsValid(valor)}
+	if formatado := cnpj.FormatCNPJ(valor); formatado != cnpj.MensagemFormatoInvalido {
+		rec.Formatado = formatado
+	}
+	return rec
+}
+
+// displayFormatted é o valor formatado usado nas mensagens de texto
+func (r cnpjRecord) displayFormatted() string {
+	if r.Formatado == "" {
+		return msg("cnpj.invalido")
+	}
+	return r.Formatado
+}
+
 // validateCmd represents the validate command
 var validateCmd = &cobra.Command{
 	Use:   "validate [CNPJ...]",
@@ -35,23 +61,62 @@ var validateCmd = &cobra.Command{
 
 Exemplos de uso:
   ./app validate OT.WXQ.ENJ/DKC6-20
-  ./app validate RZ.YYO.MTN/OLSV-26 VX7VLX1I5M4X05 RZYYOMTNOLSV26 JJQFNXSNR8FD58 VX.7VL.X1I/5M4X-05`,
+  ./app validate RZ.YYO.MTN/OLSV-26 VX7VLX1I5M4X05 RZYYOMTNOLSV26 JJQFNXSNR8FD58 VX.7VL.X1I/5M4X-05
+  ./app validate --file cnpjs.txt.gz --workers 8 --fail-on-invalid
+  cat cnpjs.txt | ./app validate --quiet && echo "todos válidos"
+  ./app validate --output ndjson --file cnpjs.txt
+  ./app validate --template '{{.Formatado}} {{.Valido}}' OTWXQENJDKC620`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := openValues(cmd, args, validateOpts.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = src.Close()
+		}()
 
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 0 {
-			cmd.Println("⚠️  Nenhum CNPJ foi informado. Por favor, passe pelo menos um argumento para validação.")
-			return
+		out, err := newRecordWriter(cmd)
+		if err != nil {
+			return err
 		}
-		for i, valor := range args {
-			if cnpj.IsValid(valor) {
-				cmd.Printf("[%d] ✅  CNPJ válido:   %s\n", i+1, cnpj.FormatCNPJ(valor))
-			} else {
-				cmd.Printf("[%d] ❌  CNPJ inválido: %s\n", i+1, cnpj.FormatCNPJ(valor))
+		total, invalidos := 0, 0
+
+		err = processOrdered(src, validateOpts.workers, newCNPJRecord, func(i int, _ string, rec cnpjRecord) error {
+			total++
+			if !rec.Valido {
+				invalidos++
+			}
+			if validateOpts.quiet {
+				return nil
 			}
+
+			rec.Indice = i + 1
+			key := "validate.valido"
+			if !rec.Valido {
+				key = "validate.invalido"
+			}
+			return out.Write(rec, msg(key, rec.Indice, rec.displayFormatted()))
+		})
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
 		}
+
+		if total == 0 && !validateOpts.quiet {
+			cmd.PrintErrln(plain(msg("validate.nenhum")))
+		}
+		if invalidos > 0 && (validateOpts.quiet || validateOpts.failOnInvalid) {
+			return errInvalidFound
+		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+
+	addBatchFlags(validateCmd, &validateOpts)
 }
@@ -0,0 +1,294 @@
+// Package chave interpreta, valida, formata e gera a chave de acesso de 44
+// posições dos documentos fiscais eletrônicos (NF-e, NFC-e, CT-e, MDF-e e
+// outros do mesmo leiaute):
+//
+//	cUF(2) AAMM(4) CNPJ(14) modelo(2) série(3) número(9) tpEmis(1) cNF(8) DV(1)
+//
+// Com o CNPJ alfanumérico, as 12 primeiras posições do CNPJ do emitente passam a
+// aceitar letras. O DV da chave é o módulo 11 com pesos de 2 a 9 da direita para
+// a esquerda, e cada caractere vale o seu código ASCII menos 48, como no CNPJ.
+package chave
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Tamanho é o número de caracteres da chave, sem espaços
+const Tamanho = 44
+
+var (
+	ErroTamanho           = &cnpj.Error{Code: "CHAVE_TAMANHO_INVALIDO", Message: "a chave de acesso deve ter 44 caracteres, sem contar os espaços"}
+	ErroCaractereInvalido = &cnpj.Error{Code: "CHAVE_CARACTERE_INVALIDO", Message: "a chave de acesso só aceita letras nas 12 primeiras posições do CNPJ"}
+	ErroDVIncorreto       = &cnpj.Error{Code: "CHAVE_DV_INCORRETO", Message: "o dígito verificador da chave de acesso não confere"}
+	ErroUF                = &cnpj.Error{Code: "CHAVE_UF_INVALIDA", Message: "o código de UF da chave de acesso não existe"}
+	ErroMes               = &cnpj.Error{Code: "CHAVE_MES_INVALIDO", Message: "o mês de emissão da chave de acesso é inválido"}
+	ErroCNPJ              = &cnpj.Error{Code: "CHAVE_CNPJ_INVALIDO", Message: "o CNPJ do emitente é inválido"}
+	ErroModelo            = &cnpj.Error{Code: "CHAVE_MODELO_INVALIDO", Message: "o modelo de documento da chave de acesso é desconhecido"}
+	ErroTipoEmissao       = &cnpj.Error{Code: "CHAVE_TIPO_EMISSAO_INVALIDO", Message: "o tipo de emissão da chave de acesso é desconhecido"}
+	ErroAno               = &cnpj.Error{Code: "CHAVE_ANO_INVALIDO", Message: "o ano de emissão da chave de acesso deve estar entre 2000 e 2099"}
+	ErroSerie             = &cnpj.Error{Code: "CHAVE_SERIE_INVALIDA", Message: "a série da chave de acesso deve estar entre 0 e 999"}
+	ErroNumero            = &cnpj.Error{Code: "CHAVE_NUMERO_INVALIDO", Message: "o número do documento deve estar entre 1 e 999.999.999"}
+)
+
+// regexChave aceita letras apenas na raiz e na ordem do CNPJ (posições 7 a 18)
+var regexChave = regexp.MustCompile(`^\d{6}[0-9A-Z]{12}\d{26}$`)
+
+// prefixos usados no atributo Id dos XMLs, como NFe3526...
+var prefixos = []string{"NFCom", "MDFe", "NF3e", "GTVe", "NFe", "CTe", "BPe"}
+
+// UFs mapeia o código IBGE da UF, usado na chave, para a sigla
+var UFs = map[string]string{
+	"11": "RO", "12": "AC", "13": "AM", "14": "RR", "15": "PA", "16": "AP", "17": "TO",
+	"21": "MA", "22": "PI", "23": "CE", "24": "RN", "25": "PB", "26": "PE", "27": "AL", "28": "SE", "29": "BA",
+	"31": "MG", "32": "ES", "33": "RJ", "35": "SP",
+	"41": "PR", "42": "SC", "43": "RS",
+	"50": "MS", "51": "MT", "52": "GO", "53": "DF",
+}
+
+// Modelos são os documentos que usam o leiaute de 44 posições
+var Modelos = map[string]string{
+	"55": "NF-e", "57": "CT-e", "58": "MDF-e", "62": "NFCom", "63": "BP-e",
+	"64": "GTV-e", "65": "NFC-e", "66": "NF3e", "67": "CT-e OS",
+}
+
+// TiposEmissao descreve o tpEmis da chave
+var TiposEmissao = map[string]string{
+	"1": "Normal",
+	"2": "Contingência FS-IA",
+	"3": "Contingência SCAN",
+	"4": "Contingência EPEC",
+	"5": "Contingência FS-DA",
+	"6": "Contingência SVC-AN",
+	"7": "Contingência SVC-RS",
+	"9": "Contingência off-line da NFC-e",
+}
+
+// Chave são os campos de uma chave de acesso
+type Chave struct {
+	Chave          string `json:"chave"`
+	CodigoUF       string `json:"codigo_uf"`
+	UF             string `json:"uf"`
+	Ano            int    `json:"ano"`
+	Mes            int    `json:"mes"`
+	CNPJ           string `json:"cnpj"`
+	Modelo         string `json:"modelo"`
+	Serie          string `json:"serie"`
+	Numero         string `json:"numero"`
+	TipoEmissao    string `json:"tipo_emissao"`
+	CodigoNumerico string `json:"codigo_numerico"`
+	DV             string `json:"dv"`
+}
+
+// Documento retorna o nome do documento do modelo, como NF-e
+func (c Chave) Documento() string {
+	return Modelos[c.Modelo]
+}
+
+// Emissao retorna a descrição do tipo de emissão
+func (c Chave) Emissao() string {
+	return TiposEmissao[c.TipoEmissao]
+}
+
+// Normalize remove os espaços e o prefixo do atributo Id (NFe, CTe...) e passa as
+// letras para maiúsculas, como cnpj.UnformattedCNPJ
+func Normalize(value string) string {
+	v := strings.Join(strings.Fields(value), "")
+	for _, p := range prefixos {
+		if len(v) >= len(p) && strings.EqualFold(v[:len(p)], p) {
+			v = v[len(p):]
+			break
+		}
+	}
+	return strings.ToUpper(v)
+}
+
+// Parse separa os campos da chave, com ou sem espaços, e a valida. Se o tamanho
+// e os caracteres estiverem corretos, os campos são preenchidos mesmo quando a
+// chave é inválida, junto com o primeiro erro encontrado: DV, UF, mês, CNPJ,
+// modelo e tipo de emissão, nessa ordem.
+func Parse(value string) (Chave, error) {
+	v := Normalize(value)
+	if len(v) != Tamanho {
+		return Chave{}, ErroTamanho
+	}
+	if !regexChave.MatchString(v) {
+		return Chave{}, ErroCaractereInvalido
+	}
+
+	ano, _ := strconv.Atoi(v[2:4])
+	mes, _ := strconv.Atoi(v[4:6])
+	c := Chave{
+		Chave:          v,
+		CodigoUF:       v[:2],
+		UF:             UFs[v[:2]],
+		Ano:            2000 + ano,
+		Mes:            mes,
+		CNPJ:           v[6:20],
+		Modelo:         v[20:22],
+		Serie:          v[22:25],
+		Numero:         v[25:34],
+		TipoEmissao:    v[34:35],
+		CodigoNumerico: v[35:43],
+		DV:             v[43:],
+	}
+
+	switch {
+	case CalculateDV(v[:43]) != c.DV:
+		return c, ErroDVIncorreto
+	case c.UF == "":
+		return c, ErroUF
+	case mes < 1 || mes > 12:
+		return c, ErroMes
+	}
+	if err := cnpj.Validate(c.CNPJ); err != nil {
+		return c, fmt.Errorf("%w: %w", ErroCNPJ, err)
+	}
+	switch {
+	case c.Documento() == "":
+		return c, ErroModelo
+	case c.Emissao() == "":
+		return c, ErroTipoEmissao
+	}
+	return c, nil
+}
+
+// Validate é como IsValid, mas retorna o motivo da rejeição
+func Validate(value string) error {
+	_, err := Parse(value)
+	return err
+}
+
+// IsValid indica se a chave, com ou sem espaços, é válida
+func IsValid(value string) bool {
+	return Validate(value) == nil
+}
+
+// CalculateDV calcula o DV das 43 primeiras posições da chave. O resto 0 ou 1
+// da divisão por 11 resulta em DV 0.
+func CalculateDV(base string) string {
+	sum, weight := 0, 2
+	for i := len(base) - 1; i >= 0; i-- {
+		sum += int(base[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+	if r := sum % 11; r >= 2 {
+		return strconv.Itoa(11 - r)
+	}
+	return "0"
+}
+
+// Format separa a chave em 11 grupos de 4 caracteres, como no DANFE. Valores que
+// não têm 44 caracteres são retornados sem alteração.
+func Format(value string) string {
+	v := Normalize(value)
+	if len(v) != Tamanho {
+		return value
+	}
+	groups := make([]string, 0, Tamanho/4)
+	for i := 0; i < Tamanho; i += 4 {
+		groups = append(groups, v[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
+
+// GenerateOptions controla a geração de chaves para testes
+type GenerateOptions struct {
+	// UF é a sigla do emitente; vazia sorteia uma
+	UF string
+	// Emissao define o ano e o mês; zero usa o mês atual
+	Emissao time.Time
+	// CNPJ é o emitente; vazio gera um CNPJ de matriz
+	CNPJ string
+	// Numeric gera um emitente com CNPJ numérico quando CNPJ está vazio
+	Numeric bool
+	// Modelo é o código do documento; vazio usa 55 (NF-e)
+	Modelo string
+	// Serie vai de 0 a 999; negativa sorteia uma
+	Serie int
+	// Numero vai de 1 a 999.999.999; zero sorteia um
+	Numero int
+	// TipoEmissao é o tpEmis; vazio usa 1 (normal)
+	TipoEmissao string
+}
+
+// Generate gera uma chave válida a partir de r. Os campos não informados em
+// opts são sorteados ou recebem os valores padrão.
+func Generate(r *rand.Rand, opts GenerateOptions) (string, error) {
+	codigoUF := ""
+	if opts.UF == "" {
+		codigos := make([]string, 0, len(UFs))
+		for codigo := range UFs {
+			codigos = append(codigos, codigo)
+		}
+		// o mapa não tem ordem: ordenar mantém a geração determinística
+		slices.Sort(codigos)
+		codigoUF = codigos[r.Intn(len(codigos))]
+	}
+	for codigo, sigla := range UFs {
+		if sigla == strings.ToUpper(opts.UF) {
+			codigoUF = codigo
+		}
+	}
+	if codigoUF == "" {
+		return "", ErroUF
+	}
+
+	if opts.Emissao.IsZero() {
+		opts.Emissao = time.Now()
+	}
+	if y := opts.Emissao.Year(); y < 2000 || y > 2099 {
+		return "", ErroAno
+	}
+
+	emitente := cnpj.UnformattedCNPJ(opts.CNPJ)
+	if opts.CNPJ == "" {
+		emitente = cnpj.GenerateWith(r, cnpj.GenerateOptions{Numeric: opts.Numeric, Matriz: true})
+	} else if err := cnpj.Validate(opts.CNPJ); err != nil {
+		return "", fmt.Errorf("%w: %w", ErroCNPJ, err)
+	}
+
+	if opts.Modelo == "" {
+		opts.Modelo = "55"
+	}
+	if Modelos[opts.Modelo] == "" {
+		return "", ErroModelo
+	}
+	if opts.TipoEmissao == "" {
+		opts.TipoEmissao = "1"
+	}
+	if TiposEmissao[opts.TipoEmissao] == "" {
+		return "", ErroTipoEmissao
+	}
+	if opts.Serie < 0 {
+		opts.Serie = r.Intn(1000)
+	}
+	if opts.Numero == 0 {
+		opts.Numero = 1 + r.Intn(999_999_999)
+	}
+	if opts.Serie > 999 {
+		return "", ErroSerie
+	}
+	if opts.Numero < 0 || opts.Numero > 999_999_999 {
+		return "", ErroNumero
+	}
+
+	// o código numérico não pode repetir o número do documento
+	codigo := r.Intn(100_000_000)
+	for codigo == opts.Numero {
+		codigo = r.Intn(100_000_000)
+	}
+
+	base := fmt.Sprintf("%s%02d%02d%s%s%03d%09d%s%08d", codigoUF, opts.Emissao.Year()%100, int(opts.Emissao.Month()),
+		emitente, opts.Modelo, opts.Serie, opts.Numero, opts.TipoEmissao, codigo)
+	return base + CalculateDV(base), nil
+}
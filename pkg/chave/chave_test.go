@@ -0,0 +1,155 @@
+package chave
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// base é uma NF-e de SP, 2026-07, emitida pelo CNPJ alfanumérico 12ABC345000188
+const base = "352607" + "12ABC345000188" + "55" + "001" + "000012345" + "1" + "87654321"
+
+// TestParse tests the fields of a valid key, with and without spaces and prefix
+func TestParse(t *testing.T) {
+	key := base + CalculateDV(base)
+	for _, in := range []string{key, Format(key), "NFe" + key, " " + Format(key) + "\n", "nfe" + strings.ToLower(key)} {
+		c, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if c.Chave != key || c.UF != "SP" || c.Ano != 2026 || c.Mes != 7 || c.CNPJ != "12ABC345000188" ||
+			c.Modelo != "55" || c.Documento() != "NF-e" || c.Serie != "001" || c.Numero != "000012345" ||
+			c.TipoEmissao != "1" || c.Emissao() != "Normal" || c.CodigoNumerico != "87654321" {
+			t.Errorf("Parse(%q) = %+v", in, c)
+		}
+	}
+}
+
+// TestCalculateDV tests the DV against sums computed by hand: with zeros before
+// the last position, the sum is that character's value times 2
+func TestCalculateDV(t *testing.T) {
+	zeros := strings.Repeat("0", 42)
+	tests := []struct{ last, want string }{
+		{"0", "0"}, // soma 0, resto 0
+		{"1", "9"}, // soma 2, 11 - 2
+		{"5", "1"}, // soma 10, 11 - 10
+		{"6", "0"}, // soma 12, resto 1
+		{"B", "8"}, // 'B' vale 18: soma 36, resto 3
+	}
+	for _, tt := range tests {
+		if dv := CalculateDV(zeros + tt.last); dv != tt.want {
+			t.Errorf("CalculateDV(...%s) = %s, want %s", tt.last, dv, tt.want)
+		}
+	}
+}
+
+// TestParse_Errors tests the error for each invalid field
+func TestParse_Errors(t *testing.T) {
+	// troca o trecho [i:j] da base e recalcula o DV
+	with := func(i int, part string) string {
+		b := base[:i] + part + base[i+len(part):]
+		return b + CalculateDV(b)
+	}
+	key := base + CalculateDV(base)
+	wrongDV := key[:43] + string('0'+(key[43]-'0'+1)%10)
+
+	tests := []struct {
+		name  string
+		value string
+		want  error
+	}{
+		{"short", key[:43], ErroTamanho},
+		{"letter outside the CNPJ", "A" + key[1:], ErroCaractereInvalido},
+		{"symbol in the CNPJ", key[:10] + "-" + key[11:], ErroCaractereInvalido},
+		{"DV", wrongDV, ErroDVIncorreto},
+		{"UF", with(0, "99"), ErroUF},
+		{"month", with(4, "13"), ErroMes},
+		{"CNPJ", with(6, "12ABC345000189"), ErroCNPJ},
+		{"model", with(20, "01"), ErroModelo},
+		{"emission type", with(34, "8"), ErroTipoEmissao},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.value)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.value, err, tt.want)
+			}
+			if IsValid(tt.value) {
+				t.Errorf("IsValid(%q) = true", tt.value)
+			}
+		})
+	}
+
+	c, err := Parse(with(6, "12ABC345000189"))
+	if !errors.Is(err, cnpj.ErroDVIncorreto) || c.UF != "SP" {
+		t.Errorf("the CNPJ error should wrap the cnpj error and keep the fields: %v %+v", err, c)
+	}
+}
+
+// TestFormat tests the groups of 4 characters
+func TestFormat(t *testing.T) {
+	key := base + CalculateDV(base)
+	got := Format(key)
+	if len(strings.Fields(got)) != 11 || strings.ReplaceAll(got, " ", "") != key || !strings.HasPrefix(got, "3526 0712 ABC3") {
+		t.Errorf("Format = %q", got)
+	}
+	if got := Format("123"); got != "123" {
+		t.Errorf("Format(123) = %q", got)
+	}
+}
+
+// TestGenerate tests that the generated keys are valid and follow the options
+func TestGenerate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	emissao := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 500; i++ {
+		opts := GenerateOptions{Emissao: emissao, Serie: -1, Numeric: i%2 == 0}
+		if i%3 == 0 {
+			opts.UF, opts.Modelo, opts.CNPJ = "mg", "65", "11.222.333/0001-81"
+		}
+		key, err := Generate(r, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := Parse(key)
+		if err != nil {
+			t.Fatalf("Generate = %s: %v", key, err)
+		}
+		if c.Ano != 2025 || c.Mes != 3 || c.CodigoNumerico == c.Numero[1:] {
+			t.Errorf("Generate = %+v", c)
+		}
+		if i%3 == 0 && (c.UF != "MG" || c.Modelo != "65" || c.CNPJ != "11222333000181") {
+			t.Errorf("options ignored: %+v", c)
+		}
+		if i%3 != 0 && opts.Numeric && strings.ContainsAny(c.CNPJ, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			t.Errorf("numeric CNPJ expected: %s", c.CNPJ)
+		}
+	}
+
+	a, _ := Generate(rand.New(rand.NewSource(7)), GenerateOptions{Emissao: emissao})
+	b, _ := Generate(rand.New(rand.NewSource(7)), GenerateOptions{Emissao: emissao})
+	if a != b {
+		t.Error("the same seed should generate the same key")
+	}
+
+	for _, tt := range []struct {
+		opts GenerateOptions
+		want error
+	}{
+		{GenerateOptions{UF: "XX"}, ErroUF},
+		{GenerateOptions{Modelo: "01"}, ErroModelo},
+		{GenerateOptions{TipoEmissao: "8"}, ErroTipoEmissao},
+		{GenerateOptions{CNPJ: "12ABC345000189"}, ErroCNPJ},
+		{GenerateOptions{Emissao: time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)}, ErroAno},
+		{GenerateOptions{Serie: 1000}, ErroSerie},
+		{GenerateOptions{Numero: 1_000_000_000}, ErroNumero},
+	} {
+		if _, err := Generate(r, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("Generate(%+v) = %v, want %v", tt.opts, err, tt.want)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cnpj
 
 import (
-	"errors"
 	"fmt"
 	"math/rand"
 	"regexp"
@@ -9,14 +8,38 @@ import (
 	"time"
 )
 
+// Error é um erro da biblioteca com código estável, que não muda com o idioma da mensagem
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// ErrorCode retorna o código estável do erro
+func (e *Error) ErrorCode() string { return e.Code }
+
+// MensagemFormatoInvalido é o texto retornado por FormatCNPJ quando o valor não tem 14 caracteres
+const MensagemFormatoInvalido = "CNPJ inválido"
+
+// ErroDVInvalido continua declarado como error, como antes dos códigos estáveis;
+// o valor é um *Error com o código CNPJ_DV_INVALIDO, acessível com errors.As
+var ErroDVInvalido error = &Error{Code: "CNPJ_DV_INVALIDO", Message: "não é possível calcular o DV pois o CNPJ fornecido é inválido"}
+
 var (
-	ErroDVInvalido    = errors.New("não é possível calcular o DV pois o CNPJ fornecido é inválido")
-	regexCNPJSemDV    = regexp.MustCompile(`^[A-Z\d]{12}$`)
-	regexCNPJ         = regexp.MustCompile(`^[A-Z\d]{12}\d{2}$`)
-	regexMascara      = regexp.MustCompile(`[./-]`)
-	regexNaoPermitido = regexp.MustCompile(`[^A-Z\d./-]`)
-	pesosDV           = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
-	cnpjZerado        = "00000000000000"
+	ErroVazio             = &Error{Code: "CNPJ_VAZIO", Message: "o CNPJ não foi informado"}
+	ErroCaractereInvalido = &Error{Code: "CNPJ_CARACTERE_INVALIDO", Message: "o CNPJ contém caracteres não permitidos"}
+	ErroTamanho           = &Error{Code: "CNPJ_TAMANHO_INVALIDO", Message: "o CNPJ deve ter 14 caracteres, sem contar a máscara"}
+	ErroDVNaoNumerico     = &Error{Code: "CNPJ_DV_NAO_NUMERICO", Message: "os dígitos verificadores devem ser numéricos"}
+	ErroZerado            = &Error{Code: "CNPJ_ZERADO", Message: "o CNPJ não pode ser zerado"}
+	ErroDVIncorreto       = &Error{Code: "CNPJ_DV_INCORRETO", Message: "os dígitos verificadores não conferem"}
+	regexCNPJSemDV        = regexp.MustCompile(`^[A-Z\d]{12}$`)
+	regexCNPJ             = regexp.MustCompile(`^[A-Z\d]{12}\d{2}$`)
+	regexMascara          = regexp.MustCompile(`[./-]`)
+	regexNaoPermitido     = regexp.MustCompile(`[^A-Z\d./-]`)
+	regexForaDoAlfabeto   = regexp.MustCompile(`[^0-9A-Z]`)
+	pesosDV               = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjZerado            = "00000000000000"
 )
 
 func isValidCharSet(value string) bool {
@@ -84,33 +107,43 @@ func CalculateDV(value string) (string, error) {
 }
 
 func IsValid(value string) bool {
+	return Validate(value) == nil
+}
+
+// Validate é como IsValid, mas retorna o motivo da rejeição como um *Error com código estável
+func Validate(value string) error {
+	if value == "" {
+		return ErroVazio
+	}
 	if !isValidCharSet(value) {
-		return false
+		return ErroCaractereInvalido
 	}
 
-	var dv string
-
 	semMascara := removeMascaraCNPJ(value)
-	if regexCNPJ.MatchString(semMascara) {
-		dv = semMascara[12:]
-		semMascara = semMascara[:12]
+	if len(semMascara) != 14 {
+		return ErroTamanho
 	}
-
-	if !regexCNPJSemDV.MatchString(semMascara) {
-		return false
+	if !regexCNPJ.MatchString(semMascara) {
+		return ErroDVNaoNumerico
+	}
+	if semMascara[:12] == cnpjZerado[:12] {
+		return ErroZerado
 	}
 
-	dvCalculado, err := CalculateDV(semMascara)
+	dv, err := CalculateDV(semMascara[:12])
 	if err != nil {
-		return false
+		return err
+	}
+	if dv != semMascara[12:] {
+		return ErroDVIncorreto
 	}
-	return dv == dvCalculado
+	return nil
 }
 
 func FormatCNPJ(value string) string {
 	value = removeMascaraCNPJ(value)
 	if len(value) != 14 {
-		return "CNPJ inválido"
+		return MensagemFormatoInvalido
 	}
 
 	mask := "##.###.###/####-##"
@@ -127,21 +160,84 @@ func FormatCNPJ(value string) string {
 	return string(runMask)
 }
 
+// FormatPartial aplica a máscara de forma incremental, para uso enquanto o valor
+// é digitado: "12ABC3" vira "12.ABC.3". Caracteres fora do alfabeto são
+// descartados, letras são convertidas para maiúsculas, letras nas posições do
+// DV são ignoradas e o excedente após 14 caracteres é cortado.
+func FormatPartial(value string) string {
+	var sb strings.Builder
+	n := 0
+	for _, r := range strings.ToUpper(value) {
+		if n == 14 {
+			break
+		}
+
+		isDigit := r >= '0' && r <= '9'
+		if !isDigit && (r < 'A' || r > 'Z' || n >= 12) {
+			continue
+		}
+
+		switch n {
+		case 2, 5:
+			sb.WriteByte('.')
+		case 8:
+			sb.WriteByte('/')
+		case 12:
+			sb.WriteByte('-')
+		}
+		sb.WriteRune(r)
+		n++
+	}
+	return sb.String()
+}
+
+// UnformattedCNPJ remove a máscara e qualquer outro caractere fora do alfabeto
+// do CNPJ, convertendo letras minúsculas para maiúsculas antes. Até a chegada do
+// CNPJ alfanumérico as minúsculas eram descartadas junto com a máscara, e
+// "12.abc.345/01de-35" resultava em "123450135"; agora resulta em
+// "12ABC34501DE35". Para rejeitar minúsculas, valide o valor original com
+// Validate ou IsValid, que continuam a recusá-las.
 func UnformattedCNPJ(value string) string {
-	return strings.ToUpper(regexp.MustCompile(`[^0-9A-Z]`).ReplaceAllString(value, ""))
+	return regexForaDoAlfabeto.ReplaceAllString(strings.ToUpper(value), "")
 }
 
-func GenerateCNPJ() string {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	var sb strings.Builder
+// GenerateOptions controla a geração de CNPJs por GenerateWith
+type GenerateOptions struct {
+	// Numeric gera apenas dígitos, como os CNPJs anteriores ao formato alfanumérico
+	Numeric bool
+	// Matriz fixa a ordem em 0001, o número do estabelecimento matriz
+	Matriz bool
+}
+
+// GenerateWith gera um CNPJ válido, sem máscara, usando r como fonte de
+// aleatoriedade. A mesma semente produz sempre a mesma sequência.
+func GenerateWith(r *rand.Rand, opts GenerateOptions) string {
 	alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	for i := 0; i < 12; i++ {
-		sb.WriteByte(alphabet[r.Intn(len(alphabet))])
+	if opts.Numeric {
+		alphabet = alphabet[:10]
 	}
-	base := sb.String()
-	dv, err := CalculateDV(base)
-	if err != nil {
-		return ""
+
+	size := 12
+	if opts.Matriz {
+		size = 8
+	}
+
+	for {
+		var sb strings.Builder
+		for i := 0; i < size; i++ {
+			sb.WriteByte(alphabet[r.Intn(len(alphabet))])
+		}
+		if opts.Matriz {
+			sb.WriteString("0001")
+		}
+
+		base := sb.String()
+		if dv, err := CalculateDV(base); err == nil {
+			return base + dv
+		}
 	}
-	return base + dv
+}
+
+func GenerateCNPJ() string {
+	return GenerateWith(rand.New(rand.NewSource(time.Now().UnixNano())), GenerateOptions{})
 }
@@ -2,6 +2,9 @@ package cnpj
 
 import (
 	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
 	"testing"
 )
 
@@ -103,3 +106,195 @@ func TestValidateCNPJ_Invalid(t *testing.T) {
 		}
 	}
 }
+
+// TestFormatPartial tests the as-you-type formatter
+func TestFormatPartial(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"1", "1"},
+		{"12", "12"},
+		{"12a", "12.A"},
+		{"12ABC3", "12.ABC.3"},
+		{"12.ABC.345/", "12.ABC.345"},
+		{"12ABC34501DE", "12.ABC.345/01DE"},
+		{"12ABC34501DEX3", "12.ABC.345/01DE-3"},
+		{"12ABC34501DE3599", "12.ABC.345/01DE-35"},
+		{"12 abc 345 01de 35", "12.ABC.345/01DE-35"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPartial(tt.input); got != tt.expected {
+			t.Errorf("FormatPartial(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestGenerateWith tests seeded generation and its options
+func TestGenerateWith(t *testing.T) {
+	a := GenerateWith(rand.New(rand.NewSource(42)), GenerateOptions{})
+	b := GenerateWith(rand.New(rand.NewSource(42)), GenerateOptions{})
+	if a != b {
+		t.Errorf("same seed should generate the same CNPJ: %s != %s", a, b)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := GenerateWith(r, GenerateOptions{Numeric: i%2 == 0, Matriz: i%3 == 0})
+		if !IsValid(v) {
+			t.Fatalf("GenerateWith produced invalid CNPJ %s", v)
+		}
+		if i%2 == 0 && !regexDigitos.MatchString(v) {
+			t.Errorf("numeric CNPJ should only contain digits: %s", v)
+		}
+		if i%3 == 0 && v[8:12] != "0001" {
+			t.Errorf("matriz CNPJ should have ordem 0001: %s", v)
+		}
+	}
+}
+
+var regexDigitos = regexp.MustCompile(`^\d{14}$`) //cnpjvet:ignore verifica o formato numérico
+
+// TestValidate tests that Validate agrees with IsValid and reports the reason
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected error
+	}{
+		{"12.ABC.345/01DE-35", nil},
+		{"", ErroVazio},
+		{"12.ABc.345/01DE-35", ErroCaractereInvalido},
+		{"0123456?789ABC", ErroCaractereInvalido},
+		{"0000000000019", ErroTamanho},
+		{"12ABC34501DE", ErroTamanho},
+		{"0000000000019L", ErroDVNaoNumerico},
+		{"00.000.000/0000-00", ErroZerado},
+		{"00000000000192", ErroDVIncorreto},
+	}
+
+	for _, tt := range tests {
+		err := Validate(tt.input)
+		if err != tt.expected {
+			t.Errorf("Validate(%q) = %v, expected %v", tt.input, err, tt.expected)
+		}
+		if IsValid(tt.input) != (err == nil) {
+			t.Errorf("IsValid(%q) disagrees with Validate", tt.input)
+		}
+	}
+}
+
+// TestSuggest tests suggestions for common typos
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"12ABC345O1DE35", "12ABC34501DE35"},     // O no lugar de 0
+		{"12.abc.345/01de-36", "12ABC34501DE35"}, // DV incorreto
+		{"21ABC34501DE35", "12ABC34501DE35"},     // transposição
+		{"12ABC34501DE", "12ABC34501DE35"},       // sem DV
+	}
+
+	for _, tt := range tests {
+		got := Suggest(tt.input)
+		found := false
+		for _, s := range got {
+			if !IsValid(s) {
+				t.Errorf("Suggest(%q) returned invalid suggestion %s", tt.input, s)
+			}
+			found = found || s == tt.expected
+		}
+		if !found {
+			t.Errorf("Suggest(%q) = %v, expected to contain %s", tt.input, got, tt.expected)
+		}
+	}
+
+	if got := Suggest("12ABC34501DE35"); got != nil {
+		t.Errorf("Suggest for a valid CNPJ should be nil, got %v", got)
+	}
+	if got := Suggest("12ABC34501DE36"); got[len(got)-1] != "12ABC34501DE35" {
+		t.Errorf("DV correction should be the last suggestion, got %v", got)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	text := "emitente 12.ABC.345/01DE-35, fornecedor 11222333000181 e 12.ABC.345/01DE-36;\n" +
+		"ignorados: 12ABC34501DE36, X11222333000181, hash 0123456789ABCDEF0123\n" +
+		"CNPJ_EMPRESA=11222333000181,12ABC34501DE35"
+
+	got := Extract(text)
+	expected := []Match{
+		{Value: "12.ABC.345/01DE-35", Masked: true, Valid: true},
+		{Value: "11222333000181", Valid: true},
+		{Value: "12.ABC.345/01DE-36", Masked: true},
+		{Value: "11222333000181", Valid: true},
+		{Value: "12ABC34501DE35", Valid: true},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Extract returned %d matches, expected %d: %+v", len(got), len(expected), got)
+	}
+	for i, m := range got {
+		e := expected[i]
+		if m.Value != e.Value || m.Masked != e.Masked || m.Valid != e.Valid || text[m.Start:m.End] != m.Value {
+			t.Errorf("match %d = %+v, expected %+v", i, m, e)
+		}
+	}
+}
+
+func TestExplainDV(t *testing.T) {
+	calc, err := ExplainDV("12.ABC.345/01DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dv, _ := CalculateDV("12ABC34501DE")
+	if got := fmt.Sprintf("%d%d", calc[0].Digit, calc[1].Digit); got != dv {
+		t.Errorf("ExplainDV digits = %s, expected %s", got, dv)
+	}
+	if len(calc[0].Steps) != 12 || len(calc[1].Steps) != 13 {
+		t.Errorf("unexpected step count: %d and %d", len(calc[0].Steps), len(calc[1].Steps))
+	}
+	if s := calc[0].Steps[2]; s.Char != 'A' || s.Value != 17 || s.Weight != 3 || s.Product != 51 {
+		t.Errorf("unexpected step for 'A': %+v", s)
+	}
+
+	if _, err := ExplainDV("12ABC"); err == nil {
+		t.Error("expected error for short value")
+	}
+}
+
+func TestBranch(t *testing.T) {
+	if Raiz("12.ABC.345/01DE-35") != "12ABC345" || Ordem("12.ABC.345/01DE-35") != "01DE" {
+		t.Error("unexpected raiz/ordem")
+	}
+	if IsMatriz("12.ABC.345/01DE-35") || !IsMatriz("11.222.333/0001-81") {
+		t.Error("unexpected IsMatriz result")
+	}
+
+	filial, err := Branch("11.222.333/0001-81", "2")
+	if err != nil || filial[:12] != "112223330002" || !IsValid(filial) {
+		t.Errorf("Branch() = %q, %v", filial, err)
+	}
+	if _, err := Branch("11222333000181", "00001"); err == nil {
+		t.Error("expected error for long ordem")
+	}
+	if _, err := Branch("112", "1"); err == nil {
+		t.Error("expected error for short value")
+	}
+}
+
+func TestUnformattedCNPJ(t *testing.T) {
+	tests := map[string]string{
+		"12.ABC.345/01DE-35":   "12ABC34501DE35",
+		"12.abc.345/01de-35":   "12ABC34501DE35",
+		" 12abc345 01de35 \t":  "12ABC34501DE35",
+		"11.222.333/0001-81\n": "11222333000181",
+	}
+	for input, expected := range tests {
+		if got := UnformattedCNPJ(input); got != expected {
+			t.Errorf("UnformattedCNPJ(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
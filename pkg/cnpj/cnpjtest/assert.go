@@ -0,0 +1,44 @@
+package cnpjtest
+
+import (
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// AssertValid falha o teste se o valor não for um CNPJ válido
+func AssertValid(t testing.TB, value string) {
+	t.Helper()
+	if !cnpj.IsValid(value) {
+		t.Errorf("expected %q to be a valid CNPJ", value)
+	}
+}
+
+// AssertInvalid falha o teste se o valor for um CNPJ válido
+func AssertInvalid(t testing.TB, value string) {
+	t.Helper()
+	if cnpj.IsValid(value) {
+		t.Errorf("expected %q to be an invalid CNPJ", value)
+	}
+}
+
+// AssertDV falha o teste se o DV calculado para o valor for diferente do esperado
+func AssertDV(t testing.TB, value, expected string) {
+	t.Helper()
+	dv, err := cnpj.CalculateDV(value)
+	if err != nil {
+		t.Errorf("CalculateDV(%q) returned error: %v, expected %s", value, err, expected)
+		return
+	}
+	if dv != expected {
+		t.Errorf("CalculateDV(%q) = %s, expected %s", value, dv, expected)
+	}
+}
+
+// AssertFormatted falha o teste se a formatação do valor for diferente da esperada
+func AssertFormatted(t testing.TB, value, expected string) {
+	t.Helper()
+	if got := cnpj.FormatCNPJ(value); got != expected {
+		t.Errorf("FormatCNPJ(%q) = %s, expected %s", value, got, expected)
+	}
+}
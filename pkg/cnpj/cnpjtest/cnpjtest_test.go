@@ -0,0 +1,96 @@
+package cnpjtest
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+func TestFixtures(t *testing.T) {
+	for _, f := range Fixtures() {
+		if got := cnpj.IsValid(f.Value); got != f.Valid {
+			t.Errorf("fixture %s (%q): IsValid = %v, expected %v", f.Name, f.Value, got, f.Valid)
+		}
+	}
+
+	if len(Valid())+len(Invalid()) != len(Fixtures()) {
+		t.Error("Valid and Invalid should partition Fixtures")
+	}
+}
+
+func TestGet(t *testing.T) {
+	if Get("alnum-matriz").Value != "A1B2C3D4000193" {
+		t.Error("unexpected value for alnum-matriz")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unknown fixture")
+		}
+	}()
+	Get("does-not-exist")
+}
+
+func TestDerive(t *testing.T) {
+	a := Derive(t.Name())
+	AssertValid(t, a)
+
+	if b := Derive(t.Name()); a != b {
+		t.Errorf("Derive should be stable: %s != %s", a, b)
+	}
+	if c := Derive(t.Name() + "/other"); a == c {
+		t.Errorf("Derive should differ between names: %s", a)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	valid := func(v ValidCNPJ, m MaskedCNPJ) bool {
+		return cnpj.IsValid(string(v)) && len(v) == 14 && cnpj.IsValid(string(m)) && len(m) == 18
+	}
+	if err := quick.Check(valid, nil); err != nil {
+		t.Error(err)
+	}
+
+	invalid := func(v InvalidCNPJ) bool {
+		return !cnpj.IsValid(string(v))
+	}
+	if err := quick.Check(invalid, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	cases := Golden()
+	if len(cases) == 0 {
+		t.Fatal("golden dataset is empty")
+	}
+
+	for _, c := range cases {
+		if got := cnpj.IsValid(c.Input); got != c.Valid {
+			t.Errorf("IsValid(%q) = %v, expected %v", c.Input, got, c.Valid)
+		}
+		if c.DV != "" {
+			AssertDV(t, c.Input, c.DV)
+		} else if _, err := cnpj.CalculateDV(c.Input); err == nil {
+			t.Errorf("CalculateDV(%q) should return an error", c.Input)
+		}
+	}
+}
+
+func FuzzFromBytes(f *testing.F) {
+	f.Add([]byte("seed"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		AssertValid(t, ValidFromBytes(data))
+		AssertInvalid(t, InvalidFromBytes(data))
+	})
+}
+
+func FuzzIsValid(f *testing.F) {
+	AddSeeds(f)
+	f.Fuzz(func(t *testing.T, value string) {
+		if cnpj.IsValid(value) {
+			AssertFormatted(t, value, cnpj.FormatCNPJ(cnpj.UnformattedCNPJ(value)))
+		}
+	})
+}
@@ -0,0 +1,100 @@
+// Package cnpjtest reúne utilitários para testes que precisam de CNPJs:
+// fixtures nomeadas e estáveis, derivação determinística a partir do nome do
+// teste, geradores para testing/quick e fuzzing, asserções e um dataset de
+// referência com os DVs esperados.
+package cnpjtest
+
+import (
+	"crypto/sha256"
+	"sort"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Fixture descreve um CNPJ de teste com nome estável
+type Fixture struct {
+	Name         string
+	Value        string
+	Valid        bool
+	Alphanumeric bool
+	Matriz       bool
+}
+
+var fixtures = []Fixture{
+	{Name: "numeric-matriz", Value: "11222333000181", Valid: true, Matriz: true},
+	{Name: "numeric-filial", Value: "11222333000262", Valid: true},
+	{Name: "numeric-masked", Value: "11.222.333/0001-81", Valid: true, Matriz: true},
+	{Name: "alnum-matriz", Value: "A1B2C3D4000193", Valid: true, Alphanumeric: true, Matriz: true},
+	{Name: "alnum-filial", Value: "A1B2C3D4000274", Valid: true, Alphanumeric: true},
+	{Name: "alnum-masked", Value: "12.ABC.345/01DE-35", Valid: true, Alphanumeric: true},
+	{Name: "numeric-invalid-dv", Value: "11222333000182", Matriz: true},
+	{Name: "alnum-invalid-dv", Value: "A1B2C3D4000194", Alphanumeric: true, Matriz: true},
+	{Name: "alnum-letter-in-dv", Value: "A1B2C3D400019L", Alphanumeric: true, Matriz: true},
+	{Name: "invalid-char", Value: "A1B2C3D4#00193", Alphanumeric: true},
+	{Name: "lowercase", Value: "a1b2c3d4000193", Alphanumeric: true, Matriz: true},
+	{Name: "too-short", Value: "1122233300018"},
+	{Name: "too-long", Value: "112223330001811"},
+	{Name: "all-zeroes", Value: "00000000000000"},
+	{Name: "empty", Value: ""},
+}
+
+// Fixtures retorna todas as fixtures conhecidas, ordenadas por nome
+func Fixtures() []Fixture {
+	out := make([]Fixture, len(fixtures))
+	copy(out, fixtures)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Get retorna a fixture com o nome informado e entra em pânico se ela não existir,
+// já que um nome desconhecido é sempre um erro no próprio teste
+func Get(name string) Fixture {
+	for _, f := range fixtures {
+		if f.Name == name {
+			return f
+		}
+	}
+	panic("cnpjtest: fixture desconhecida: " + name)
+}
+
+// Valid retorna apenas as fixtures válidas
+func Valid() []Fixture {
+	return filter(true)
+}
+
+// Invalid retorna apenas as fixtures inválidas
+func Invalid() []Fixture {
+	return filter(false)
+}
+
+func filter(valid bool) []Fixture {
+	var out []Fixture
+	for _, f := range Fixtures() {
+		if f.Valid == valid {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Derive retorna sempre o mesmo CNPJ válido, sem máscara, para o mesmo nome.
+// O uso típico é Derive(t.Name()), o que dá a cada teste um valor próprio e estável.
+func Derive(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	base := make([]byte, 12)
+	for i := range base {
+		base[i] = alphabet[int(sum[i])%len(alphabet)]
+	}
+	if string(base) == "000000000000" {
+		base[11] = '1'
+	}
+
+	dv, err := cnpj.CalculateDV(string(base))
+	if err != nil {
+		panic("cnpjtest: base derivada inválida: " + string(base))
+	}
+	return string(base) + dv
+}
@@ -0,0 +1,101 @@
+package cnpjtest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// ValidCNPJ implementa quick.Generator e produz CNPJs válidos sem máscara
+type ValidCNPJ string
+
+// MaskedCNPJ implementa quick.Generator e produz CNPJs válidos com máscara
+type MaskedCNPJ string
+
+// InvalidCNPJ implementa quick.Generator e produz valores que IsValid rejeita
+type InvalidCNPJ string
+
+// Generate implementa quick.Generator
+func (ValidCNPJ) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(ValidCNPJ(randomValid(r)))
+}
+
+// Generate implementa quick.Generator
+func (MaskedCNPJ) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(MaskedCNPJ(cnpj.FormatCNPJ(randomValid(r))))
+}
+
+// Generate implementa quick.Generator
+func (InvalidCNPJ) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(InvalidCNPJ(randomInvalid(r)))
+}
+
+// ValidFromBytes mapeia bytes arbitrários de um alvo de fuzzing em um CNPJ
+// válido. A mesma entrada sempre produz o mesmo valor.
+func ValidFromBytes(data []byte) string {
+	return randomValid(randFromBytes(data))
+}
+
+// InvalidFromBytes mapeia bytes arbitrários de um alvo de fuzzing em um valor
+// inválido. A mesma entrada sempre produz o mesmo valor.
+func InvalidFromBytes(data []byte) string {
+	return randomInvalid(randFromBytes(data))
+}
+
+func randFromBytes(data []byte) *rand.Rand {
+	sum := sha256.Sum256(data)
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum[:8]))))
+}
+
+func randomBase(r *rand.Rand) string {
+	for {
+		base := make([]byte, 12)
+		for i := range base {
+			base[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		if string(base) != "000000000000" {
+			return string(base)
+		}
+	}
+}
+
+func randomValid(r *rand.Rand) string {
+	base := randomBase(r)
+	dv, _ := cnpj.CalculateDV(base)
+	return base + dv
+}
+
+// randomInvalid aplica uma mutação aleatória sobre um CNPJ válido e repete até
+// que o resultado seja de fato rejeitado por IsValid
+func randomInvalid(r *rand.Rand) string {
+	for {
+		valid := randomValid(r)
+		var v string
+
+		switch r.Intn(6) {
+		case 0: // DV errado
+			d := (int(valid[13]-'0') + 1 + r.Intn(9)) % 10
+			v = valid[:13] + string(rune('0'+d))
+		case 1: // caractere fora do alfabeto
+			pos := r.Intn(len(valid))
+			v = valid[:pos] + string("#?!@$ _"[r.Intn(7)]) + valid[pos+1:]
+		case 2: // tamanho curto
+			v = valid[:r.Intn(len(valid))]
+		case 3: // tamanho longo
+			v = valid + string(alphabet[r.Intn(len(alphabet))])
+		case 4: // letra minúscula
+			v = strings.ToLower(valid)
+		default: // letra no lugar do DV
+			v = valid[:12+r.Intn(2)] + string(alphabet[10+r.Intn(26)])
+			v += valid[len(v):]
+		}
+
+		if !cnpj.IsValid(v) {
+			return v
+		}
+	}
+}
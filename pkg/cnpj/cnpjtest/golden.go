@@ -0,0 +1,60 @@
+package cnpjtest
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//go:embed golden.csv
+var goldenCSV string
+
+// GoldenCase é uma linha do dataset de referência. DV fica vazio quando
+// CalculateDV deve retornar erro para a entrada.
+type GoldenCase struct {
+	Input string
+	DV    string
+	Valid bool
+}
+
+var (
+	goldenOnce  sync.Once
+	goldenCases []GoldenCase
+)
+
+// Golden retorna o dataset de referência embutido no pacote
+func Golden() []GoldenCase {
+	goldenOnce.Do(func() {
+		records, err := csv.NewReader(strings.NewReader(goldenCSV)).ReadAll()
+		if err != nil {
+			panic("cnpjtest: golden.csv inválido: " + err.Error())
+		}
+
+		for _, rec := range records[1:] {
+			valid, err := strconv.ParseBool(rec[2])
+			if err != nil {
+				panic("cnpjtest: golden.csv inválido: " + err.Error())
+			}
+			goldenCases = append(goldenCases, GoldenCase{Input: rec[0], DV: rec[1], Valid: valid})
+		}
+	})
+
+	out := make([]GoldenCase, len(goldenCases))
+	copy(out, goldenCases)
+	return out
+}
+
+// AddSeeds adiciona ao corpus de fuzzing todas as entradas do dataset de
+// referência e das fixtures. O alvo deve receber um único argumento string.
+func AddSeeds(f *testing.F) {
+	f.Helper()
+	for _, c := range Golden() {
+		f.Add(c.Input)
+	}
+	for _, fx := range fixtures {
+		f.Add(fx.Value)
+	}
+}
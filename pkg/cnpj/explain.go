@@ -0,0 +1,98 @@
+package cnpj
+
+import "strings"
+
+// DVStep é a contribuição de um caractere para a soma de um dígito verificador
+type DVStep struct {
+	Char    byte
+	Value   int
+	Weight  int
+	Product int
+}
+
+// DVCalculation detalha o cálculo de um dígito verificador: a soma dos produtos,
+// o resto da divisão por 11 e o dígito resultante (0 se o resto for menor que 2,
+// senão 11 menos o resto)
+type DVCalculation struct {
+	Steps     []DVStep
+	Sum       int
+	Remainder int
+	Digit     int
+}
+
+// ExplainDV refaz o cálculo de CalculateDV passo a passo, para fins didáticos.
+// Cada caractere vale o seu código ASCII menos 48, de modo que '0'..'9' valem
+// 0..9 e 'A'..'Z' valem 17..42. O segundo DV inclui o primeiro no cálculo.
+func ExplainDV(value string) ([2]DVCalculation, error) {
+	var out [2]DVCalculation
+	if _, err := CalculateDV(value); err != nil {
+		return out, err
+	}
+
+	base := removeMascaraCNPJ(value)[:12]
+	for d := 0; d < 2; d++ {
+		chars := base
+		if d == 1 {
+			chars += string(rune('0' + out[0].Digit))
+		}
+
+		calc := &out[d]
+		for i := 0; i < len(chars); i++ {
+			step := DVStep{Char: chars[i], Value: int(chars[i]) - 48, Weight: pesosDV[i+1-d]}
+			step.Product = step.Value * step.Weight
+			calc.Steps = append(calc.Steps, step)
+			calc.Sum += step.Product
+		}
+		calc.Remainder = calc.Sum % 11
+		if calc.Remainder >= 2 {
+			calc.Digit = 11 - calc.Remainder
+		}
+	}
+	return out, nil
+}
+
+// Raiz retorna os 8 primeiros caracteres, que identificam a empresa, ou vazio
+// se o valor for curto demais
+func Raiz(value string) string {
+	v := removeMascaraCNPJ(value)
+	if len(v) < 8 {
+		return ""
+	}
+	return v[:8]
+}
+
+// Ordem retorna os 4 caracteres que identificam o estabelecimento, ou vazio
+// se o valor for curto demais
+func Ordem(value string) string {
+	v := removeMascaraCNPJ(value)
+	if len(v) < 12 {
+		return ""
+	}
+	return v[8:12]
+}
+
+// IsMatriz indica se o CNPJ é do estabelecimento matriz, de ordem 0001
+func IsMatriz(value string) bool {
+	return Ordem(value) == "0001"
+}
+
+// Branch monta o CNPJ, sem máscara, de outro estabelecimento da mesma empresa.
+// A ordem tem até 4 caracteres e é completada com zeros à esquerda: "2" vira 0002.
+func Branch(value, ordem string) (string, error) {
+	raiz := Raiz(value)
+	if raiz == "" {
+		return "", ErroTamanho
+	}
+
+	ordem = strings.ToUpper(ordem)
+	if ordem == "" || len(ordem) > 4 || !regexCNPJSemDV.MatchString(raiz+strings.Repeat("0", 4-len(ordem))+ordem) {
+		return "", ErroCaractereInvalido
+	}
+
+	base := raiz + strings.Repeat("0", 4-len(ordem)) + ordem
+	dv, err := CalculateDV(base)
+	if err != nil {
+		return "", err
+	}
+	return base + dv, nil
+}
@@ -0,0 +1,70 @@
+package cnpj
+
+import (
+	"regexp"
+	"sort"
+)
+
+var (
+	regexTextoComMascara = NewTextPattern(`[A-Z\d]{2}\.[A-Z\d]{3}\.[A-Z\d]{3}/[A-Z\d]{4}-\d{2}`)
+	regexTextoSemMascara = NewTextPattern(`[A-Z\d]{12}\d{2}`)
+)
+
+// TextPattern procura CNPJs em um texto livre. O trecho precisa estar cercado
+// por algo que não seja letra nem dígito: diferente de \b, que trata _ como parte
+// da palavra, isso encontra valores como cnpj_11222333000181.
+type TextPattern struct {
+	re *regexp.Regexp
+}
+
+// NewTextPattern compila expr, o padrão do CNPJ sem limites, e entra em pânico
+// se ele for inválido, como regexp.MustCompile
+func NewTextPattern(expr string) *TextPattern {
+	return &TextPattern{re: regexp.MustCompile(`(?:^|[^0-9A-Za-z])(` + expr + `)(?:$|[^0-9A-Za-z])`)}
+}
+
+// FindAllIndex retorna as posições em bytes de cada CNPJ em text, sem os limites
+func (p *TextPattern) FindAllIndex(text string) [][]int {
+	var out [][]int
+	for start := 0; start < len(text); {
+		loc := p.re.FindStringSubmatchIndex(text[start:])
+		if loc == nil {
+			break
+		}
+		out = append(out, []int{start + loc[2], start + loc[3]})
+		// o limite final não é consumido: ele pode abrir o próximo CNPJ
+		start += loc[3]
+	}
+	return out
+}
+
+// Match é um CNPJ encontrado em um texto por Extract
+type Match struct {
+	// Value é o trecho exatamente como aparece no texto
+	Value string
+	// Start e End são as posições em bytes do trecho no texto
+	Start, End int
+	Masked     bool
+	Valid      bool
+}
+
+// Extract encontra CNPJs em um texto livre. Valores com máscara são sempre
+// retornados, mesmo com DV incorreto, pois o formato já indica um CNPJ; valores
+// sem máscara só são retornados quando o DV confere, já que qualquer sequência
+// de 14 caracteres alfanuméricos seria um candidato.
+func Extract(text string) []Match {
+	var out []Match
+	for _, loc := range regexTextoComMascara.FindAllIndex(text) {
+		v := text[loc[0]:loc[1]]
+		out = append(out, Match{Value: v, Start: loc[0], End: loc[1], Masked: true, Valid: IsValid(v)})
+	}
+	for _, loc := range regexTextoSemMascara.FindAllIndex(text) {
+		v := text[loc[0]:loc[1]]
+		if IsValid(v) {
+			out = append(out, Match{Value: v, Start: loc[0], End: loc[1], Valid: true})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+	return out
+}
@@ -0,0 +1,63 @@
+package cnpj
+
+// maxSugestoes limita a quantidade de sugestões retornadas por Suggest
+const maxSugestoes = 5
+
+// confusaveis mapeia caracteres frequentemente trocados na digitação ou em OCR
+var confusaveis = map[byte]string{
+	'0': "ODQ", 'O': "0Q", 'Q': "0O", 'D': "0",
+	'1': "IL", 'I': "1L", 'L': "1I",
+	'2': "Z", 'Z': "2",
+	'5': "S", 'S': "5",
+	'6': "G", 'G': "6",
+	'8': "B", 'B': "8",
+}
+
+// Suggest retorna CNPJs válidos, sem máscara, próximos de um valor inválido,
+// do mais ao menos provável: troca de um caractere parecido (0/O, 1/I, 5/S...),
+// inversão de dois caracteres vizinhos e, por fim, a correção do DV. Retorna
+// nil para valores válidos ou sem sugestão possível.
+func Suggest(value string) []string {
+	v := UnformattedCNPJ(value)
+	if IsValid(v) {
+		return nil
+	}
+
+	var out []string
+	seen := map[string]bool{}
+	add := func(candidate string) {
+		if len(out) < maxSugestoes && !seen[candidate] && IsValid(candidate) {
+			seen[candidate] = true
+			out = append(out, candidate)
+		}
+	}
+
+	if len(v) == 14 {
+		b := []byte(v)
+		for i := range b {
+			orig := b[i]
+			for _, c := range []byte(confusaveis[orig]) {
+				b[i] = c
+				add(string(b))
+			}
+			b[i] = orig
+		}
+
+		for i := 0; i < len(b)-1; i++ {
+			if b[i] == b[i+1] {
+				continue
+			}
+			b[i], b[i+1] = b[i+1], b[i]
+			add(string(b))
+			b[i], b[i+1] = b[i+1], b[i]
+		}
+	}
+
+	if len(v) == 12 || len(v) == 14 {
+		if dv, err := CalculateDV(v[:12]); err == nil {
+			add(v[:12] + dv)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+package cnpjvet
+
+import (
+	"errors"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"golang.org/x/tools/go/analysis"
+)
+
+// classeDigito casa \d e [0-9] dentro do texto de um padrão de regex
+const classeDigito = `(\\d|\[0-9\])`
+
+var (
+	// regexSoDigitos casa padrões como \d{14} e [0-9]{14}
+	regexSoDigitos = regexp.MustCompile(classeDigito + `\{14\}`)
+	// regexMascaraDigitos casa a raiz e a ordem com máscara escritas só com dígitos, como \d{2}\.\d{3}\.\d{3}/\d{4}
+	regexMascaraDigitos = regexp.MustCompile(classeDigito + `\{2\}[^{}]{0,8}?` + classeDigito + `\{3\}[^{}]{0,8}?` +
+		classeDigito + `\{3\}[^{}]{0,8}?` + classeDigito + `\{4\}`)
+	regexClasseDigito = regexp.MustCompile(classeDigito)
+
+	// regexForma e regexFormaMascara reconhecem literais com a forma de um CNPJ
+	regexForma        = regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+	regexFormaMascara = regexp.MustCompile(`^[0-9A-Z]{2}\.[0-9A-Z]{3}\.[0-9A-Z]{3}/[0-9A-Z]{4}-[0-9]{2}$`)
+)
+
+// regex aponta padrões que aceitam CNPJs só com dígitos e sugere a classe [0-9A-Z]
+// nas 12 primeiras posições. Arquivos de teste são ignorados, pois costumam
+// verificar de propósito o formato numérico.
+func (c *checker) regex(arg ast.Expr) {
+	if c.test(arg) {
+		return
+	}
+	tv, ok := c.pass.TypesInfo.Types[arg]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	pattern := constant.StringVal(tv.Value)
+
+	fixed := regexSoDigitos.ReplaceAllStringFunc(pattern, func(m string) string {
+		return "[0-9A-Z]{12}" + regexClasseDigito.FindString(m) + "{2}"
+	})
+	fixed = regexMascaraDigitos.ReplaceAllStringFunc(fixed, func(m string) string {
+		return regexClasseDigito.ReplaceAllLiteralString(m, "[0-9A-Z]")
+	})
+	if fixed == pattern {
+		return
+	}
+
+	d := analysis.Diagnostic{
+		Pos:     arg.Pos(),
+		End:     arg.End(),
+		Message: "a regex " + strconv.Quote(pattern) + " aceita só dígitos, mas o CNPJ alfanumérico tem letras nas 12 primeiras posições; use [0-9A-Z] ou cnpj.IsValid",
+	}
+	if lit, ok := arg.(*ast.BasicLit); ok {
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Aceitar letras na raiz e na ordem",
+			TextEdits: []analysis.TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: []byte(quoteLike(lit.Value, fixed))}},
+		}}
+	}
+	c.report(d)
+}
+
+// quoteLike escreve s como literal no mesmo estilo (raw ou interpretado) de orig
+func quoteLike(orig, s string) string {
+	if strings.HasPrefix(orig, "`") && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// format aponta verbos %014d e %14d aplicados a um CNPJ. O argumento precisa
+// envolver um CNPJ ou o próprio formato precisa citá-lo.
+func (c *checker) format(call *ast.CallExpr, pos int) {
+	tv, ok := c.pass.TypesInfo.Types[call.Args[pos]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	format := constant.StringVal(tv.Value)
+	byName := regexNome.MatchString(format)
+
+	for i, arg := range verbs(format) {
+		if !arg || pos+1+i >= len(call.Args) {
+			continue
+		}
+		value := call.Args[pos+1+i]
+		if byName || c.mentions(value, false) {
+			c.report(analysis.Diagnostic{
+				Pos:     value.Pos(),
+				End:     value.End(),
+				Message: "%014d formata o CNPJ como número e não comporta letras; guarde-o como string e use cnpj.FormatCNPJ ou cnpj.UnformattedCNPJ",
+			})
+		}
+	}
+}
+
+// verbs percorre a string de formato e indica, para cada argumento consumido, se o
+// verbo é um %d de largura 14. Formatos com índices explícitos ou * não são analisados.
+func verbs(format string) []bool {
+	var out []bool
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		start := i
+		for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+			i++
+		}
+		width := format[start:i]
+		if i < len(format) && format[i] == '.' {
+			i++
+			for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+				i++
+			}
+		}
+		if i >= len(format) {
+			break
+		}
+		switch format[i] {
+		case '%':
+			continue
+		case '[', '*':
+			return nil
+		}
+		out = append(out, width == "14" && format[i] == 'd')
+	}
+	return out
+}
+
+// literal aponta strings com a forma de um CNPJ que não passam em cnpj.IsValid e,
+// quando só o DV está errado, sugere o valor corrigido. Sem máscara, a string só é
+// considerada quando o contexto cita um CNPJ, pois 14 dígitos também podem ser
+// datas ou códigos; arquivos de teste são ignorados, pois costumam ter casos inválidos.
+func (c *checker) literal(lit *ast.BasicLit, stack []ast.Node) {
+	if lit.Kind != token.STRING || c.test(lit) {
+		return
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return
+	}
+	masked := regexFormaMascara.MatchString(value)
+	if !masked && !(regexForma.MatchString(value) && c.context(stack)) {
+		return
+	}
+
+	// o CNPJ zerado é usado como valor vazio, não como um CNPJ de verdade
+	err = cnpj.Validate(value)
+	if err == nil || errors.Is(err, cnpj.ErroZerado) {
+		return
+	}
+
+	d := analysis.Diagnostic{
+		Pos:     lit.Pos(),
+		End:     lit.End(),
+		Message: strconv.Quote(value) + " parece um CNPJ, mas é inválido: " + err.Error(),
+	}
+	if errors.Is(err, cnpj.ErroDVIncorreto) {
+		base := cnpj.UnformattedCNPJ(value)[:12]
+		dv, _ := cnpj.CalculateDV(base)
+		fixed := base + dv
+		if masked {
+			fixed = cnpj.FormatCNPJ(fixed)
+		}
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Corrigir o DV para " + fixed,
+			TextEdits: []analysis.TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: []byte(quoteLike(lit.Value, fixed))}},
+		}}
+	}
+	c.report(d)
+}
+
+// test indica se n está em um arquivo de teste
+func (c *checker) test(n ast.Node) bool {
+	return strings.HasSuffix(c.pass.Fset.File(n.Pos()).Name(), "_test.go")
+}
+
+// context procura um CNPJ ao redor de um literal, subindo pela pilha até o
+// comando, declaração ou par chave-valor que o contém
+func (c *checker) context(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.KeyValueExpr:
+			return c.mentions(n.Key, true)
+		case ast.Stmt, ast.Spec, *ast.Field:
+			return c.mentions(n, true)
+		}
+	}
+	return false
+}
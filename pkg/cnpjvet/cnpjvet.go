@@ -0,0 +1,285 @@
+// Package cnpjvet é um analisador go/analysis que aponta código que ainda trata
+// o CNPJ como número: conversões com strconv, regexes só de dígitos, campos e
+// variáveis inteiros, formatação com %014d e literais que parecem CNPJs mas são
+// inválidos. O Analyzer pode ser usado sozinho (cmd/cnpjvet) ou em um multichecker.
+package cnpjvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `aponta suposições de que o CNPJ é numérico
+
+O CNPJ alfanumérico aceita letras nas 12 primeiras posições. O cnpjvet encontra,
+pelo nome dos identificadores e pelo fluxo de atribuições, valores de CNPJ
+convertidos com strconv, guardados em inteiros ou formatados com %014d, regexes
+que aceitam só dígitos e literais com forma de CNPJ e DV incorreto (fora de
+arquivos de teste), sugerindo as funções de pkg/cnpj.
+
+Um comentário //cnpjvet:ignore na mesma linha ou na linha anterior silencia o
+aviso, para os casos em que o formato numérico é intencional.`
+
+// Analyzer é o passe do cnpjvet, compatível com singlechecker, multichecker e go vet -vettool
+var Analyzer = &analysis.Analyzer{
+	Name:     "cnpjvet",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpjvet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// diretivaIgnorar é o comentário que silencia os avisos de uma linha
+const diretivaIgnorar = "cnpjvet:ignore"
+
+var (
+	// regexNome casa os identificadores que, pelo nome, guardam um CNPJ
+	regexNome = regexp.MustCompile(`(?i)cnpj|cgc`)
+	// regexContagem casa nomes de contadores e coleções, como TotalCNPJ e cnpjs
+	regexContagem = regexp.MustCompile(`(?i)qtd|quant|total|count|contagem|cnpjs`)
+	// regexChave casa strings usadas como nome de campo, como "cnpj_empresa"
+	regexChave = regexp.MustCompile(`^[A-Za-z_]+$`)
+)
+
+// conversoes são as funções de strconv que só aceitam dígitos
+var conversoes = map[string]bool{"Atoi": true, "ParseInt": true, "ParseUint": true, "ParseFloat": true}
+
+// formatadores mapeia as funções com string de formato à posição do formato
+var formatadores = map[string]int{
+	"fmt.Sprintf": 0, "fmt.Printf": 0, "fmt.Errorf": 0, "fmt.Fprintf": 1, "fmt.Appendf": 1,
+	"log.Printf": 0, "log.Fatalf": 0, "log.Panicf": 0,
+}
+
+// compiladores são as funções de regexp cujo primeiro argumento é o padrão
+var compiladores = map[string]bool{
+	"Compile": true, "MustCompile": true, "CompilePOSIX": true, "MustCompilePOSIX": true,
+	"MatchString": true, "Match": true, "MatchReader": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := &checker{pass: pass, marcados: map[types.Object]bool{}, ignorados: map[string]bool{}}
+	for _, f := range pass.Files {
+		for _, group := range f.Comments {
+			for _, comment := range group.List {
+				if strings.Contains(comment.Text, diretivaIgnorar) {
+					c.ignorados[c.linha(comment.Pos(), 0)] = true
+				}
+			}
+		}
+	}
+
+	filter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.Field)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.BasicLit)(nil),
+	}
+	insp.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			c.assign(n)
+		case *ast.ValueSpec:
+			c.valueSpec(n)
+		case *ast.Field:
+			c.field(n)
+		case *ast.CallExpr:
+			c.call(n)
+		case *ast.BasicLit:
+			c.literal(n, stack)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checker guarda o estado de um passe: os objetos que carregam um CNPJ e as
+// linhas marcadas com //cnpjvet:ignore
+type checker struct {
+	pass      *analysis.Pass
+	marcados  map[types.Object]bool
+	ignorados map[string]bool
+}
+
+// linha identifica, por arquivo e número, a linha de pos deslocada de delta
+func (c *checker) linha(pos token.Pos, delta int) string {
+	p := c.pass.Fset.Position(pos)
+	return p.Filename + ":" + strconv.Itoa(p.Line+delta)
+}
+
+// report emite o diagnóstico, exceto se a linha ou a anterior tiverem //cnpjvet:ignore
+func (c *checker) report(d analysis.Diagnostic) {
+	if c.ignorados[c.linha(d.Pos, 0)] || c.ignorados[c.linha(d.Pos, -1)] {
+		return
+	}
+	c.pass.Report(d)
+}
+
+// mentions indica se a expressão envolve um CNPJ: um identificador com cnpj no
+// nome, uma chave de mapa ou formulário com esse nome ou uma variável que
+// recebeu um valor de CNPJ. Nomes de pacote, como em
+// cnpj.IsValid(x), só contam com pkgs, pois o resultado nem sempre é um CNPJ.
+func (c *checker) mentions(e ast.Node, pkgs bool) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			// chaves como req["cnpj"] e r.FormValue("cnpj")
+			value, _ := strconv.Unquote(lit.Value)
+			found = regexChave.MatchString(value) && regexNome.MatchString(value)
+			return false
+		}
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := c.pass.TypesInfo.ObjectOf(id)
+		if _, pkg := obj.(*types.PkgName); pkg && !pkgs {
+			return true
+		}
+		found = regexNome.MatchString(id.Name) || (obj != nil && c.marcados[obj])
+		return !found
+	})
+	return found
+}
+
+// propagate marca as variáveis definidas a partir de expressões com CNPJ, para
+// que doc := req.CNPJ; strconv.Atoi(doc) também seja encontrado
+func (c *checker) propagate(lhs []ast.Expr, rhs []ast.Expr) {
+	for i, l := range lhs {
+		id, ok := l.(*ast.Ident)
+		if !ok || id.Name == "_" {
+			continue
+		}
+		var src ast.Expr
+		switch {
+		case len(lhs) == len(rhs):
+			src = rhs[i]
+		case len(rhs) == 1:
+			src = rhs[0]
+		default:
+			continue
+		}
+		if obj := c.pass.TypesInfo.ObjectOf(id); obj != nil && c.mentions(src, false) {
+			c.marcados[obj] = true
+		}
+	}
+}
+
+func (c *checker) assign(n *ast.AssignStmt) {
+	c.propagate(n.Lhs, n.Rhs)
+	if n.Tok != token.DEFINE {
+		return
+	}
+	for _, l := range n.Lhs {
+		if id, ok := l.(*ast.Ident); ok {
+			c.integer(id, nil)
+		}
+	}
+}
+
+func (c *checker) valueSpec(n *ast.ValueSpec) {
+	lhs := make([]ast.Expr, len(n.Names))
+	for i, id := range n.Names {
+		lhs[i] = id
+		c.integer(id, n.Type)
+	}
+	c.propagate(lhs, n.Values)
+}
+
+func (c *checker) field(n *ast.Field) {
+	for _, id := range n.Names {
+		c.integer(id, n.Type)
+	}
+}
+
+// integer aponta um identificador de CNPJ com tipo inteiro. Trocar o tipo por
+// string só é sugerido quando ele está escrito na declaração e ninguém usa o
+// identificador: com usos, a troca quebraria contas, atribuições e formatos.
+func (c *checker) integer(id *ast.Ident, typ ast.Expr) {
+	if !regexNome.MatchString(id.Name) || regexContagem.MatchString(id.Name) {
+		return
+	}
+	obj := c.pass.TypesInfo.Defs[id]
+	if obj == nil {
+		return
+	}
+	if _, ok := obj.(*types.Var); !ok {
+		return
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsFloat) == 0 {
+		return
+	}
+
+	d := analysis.Diagnostic{
+		Pos:     id.Pos(),
+		End:     id.End(),
+		Message: id.Name + " guarda o CNPJ como " + obj.Type().String() + ": o CNPJ alfanumérico tem letras, use string (normalizada com cnpj.UnformattedCNPJ)",
+	}
+	if typ != nil && !c.used(obj) {
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Trocar o tipo por string",
+			TextEdits: []analysis.TextEdit{{Pos: typ.Pos(), End: typ.End(), NewText: []byte("string")}},
+		}}
+	}
+	c.report(d)
+}
+
+// used indica se obj pode ter usos: os do pacote analisado e, para nomes
+// exportados, os de outros pacotes, que o analisador não enxerga
+func (c *checker) used(obj types.Object) bool {
+	if obj.Exported() {
+		return true
+	}
+	for _, u := range c.pass.TypesInfo.Uses {
+		if u == obj {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *checker) call(n *ast.CallExpr) {
+	fn, ok := typeutil.Callee(c.pass.TypesInfo, n).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+	if sig, _ := fn.Type().(*types.Signature); sig == nil || sig.Recv() != nil {
+		return
+	}
+
+	switch path := fn.Pkg().Path(); {
+	case path == "strconv" && conversoes[fn.Name()]:
+		if len(n.Args) > 0 && c.mentions(n.Args[0], false) {
+			c.report(analysis.Diagnostic{
+				Pos:     n.Pos(),
+				End:     n.End(),
+				Message: "strconv." + fn.Name() + " em um CNPJ: o CNPJ alfanumérico tem letras e não é um número; mantenha-o como string e valide com cnpj.Validate",
+			})
+		}
+	case path == "regexp" && compiladores[fn.Name()]:
+		if len(n.Args) > 0 {
+			c.regex(n.Args[0])
+		}
+	default:
+		if pos, ok := formatadores[path+"."+fn.Name()]; ok && len(n.Args) > pos {
+			c.format(n, pos)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cnpjvet
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer checks the diagnostics and suggested fixes against testdata/src/a,
+// whose a.go.golden holds the source after every fix is applied
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a")
+}
+
+// TestVerbs tests the mapping of format verbs to arguments
+func TestVerbs(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []bool
+	}{
+		{"%014d", []bool{true}},
+		{"%s %14d %d", []bool{false, true, false}},
+		{"100%% %014d", []bool{true}},
+		{"%-14d|%.2f", []bool{true, false}},
+		{"%015d %14s", []bool{false, false}},
+		{"%[1]014d", nil},
+		{"%*d", nil},
+	}
+	for _, tt := range tests {
+		got := verbs(tt.format)
+		if len(got) != len(tt.want) {
+			t.Errorf("verbs(%q) = %v, want %v", tt.format, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("verbs(%q) = %v, want %v", tt.format, got, tt.want)
+				break
+			}
+		}
+	}
+}
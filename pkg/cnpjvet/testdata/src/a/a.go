@@ -0,0 +1,63 @@
+package a
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+type Empresa struct {
+	Nome string
+	CNPJ int64 // want `CNPJ guarda o CNPJ como int64`
+	Raiz string
+}
+
+type Resumo struct {
+	TotalCNPJ int
+}
+
+var (
+	soDigitos    = regexp.MustCompile(`^\d{14}$`)                                           // want `aceita só dígitos`
+	comMascara   = regexp.MustCompile("^[0-9]{2}\\.[0-9]{3}\\.[0-9]{3}/[0-9]{4}-[0-9]{2}$") // want `aceita só dígitos`
+	alfanumerico = regexp.MustCompile(`^[0-9A-Z]{12}\d{2}$`)
+	intencional  = regexp.MustCompile(`^\d{14}$`) //cnpjvet:ignore legado
+)
+
+func converte(cnpj string) (int, error) {
+	return strconv.Atoi(cnpj) // want `strconv.Atoi em um CNPJ`
+}
+
+func fluxo(e Empresa, req map[string]string) {
+	doc := req["cnpj"]
+	n, _ := strconv.ParseInt(doc, 10, 64) // want `strconv.ParseInt em um CNPJ`
+	idade, _ := strconv.Atoi(req["idade"])
+	fmt.Println(n, idade)
+
+	fmt.Printf("%014d\n", e.CNPJ)  // want `%014d formata o CNPJ`
+	fmt.Printf("CNPJ: %014d\n", n) // want `%014d formata o CNPJ`
+	fmt.Printf("%s %014d\n", e.Nome, 42)
+}
+
+func literais() []string {
+	cnpj := "11222333000180" // want `"11222333000180" parece um CNPJ, mas é inválido`
+	codigo := "20240101120000"
+	vazio := "00000000000000"
+	return []string{
+		cnpj, codigo, vazio,
+		"12.ABC.345/01DE-00", // want `"12.ABC.345/01DE-00" parece um CNPJ, mas é inválido`
+		"12.ABC.345/01DE-35",
+		"11.222.333/0001-81",
+	}
+}
+
+func contagem(cnpjs []string) {
+	var qtdCNPJ int
+	for range cnpjs {
+		qtdCNPJ++
+	}
+	var cgc uint64 // want `cgc guarda o CNPJ como uint64`
+	fmt.Println(qtdCNPJ, cgc)
+}
+
+// cgcLegado não tem usos, então o tipo pode ser trocado
+var cgcLegado int64 // want `cgcLegado guarda o CNPJ como int64`
@@ -0,0 +1,15 @@
+package a
+
+import (
+	"regexp"
+	"testing"
+)
+
+var digitos = regexp.MustCompile(`^\d{14}$`)
+
+func TestInvalido(t *testing.T) {
+	cnpj := "11.222.333/0001-80"
+	if cnpj == "" || !digitos.MatchString("11222333000181") {
+		t.Fatal("vazio")
+	}
+}
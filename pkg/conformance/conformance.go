@@ -0,0 +1,148 @@
+// Package conformance verifica implementações do cálculo de DV do CNPJ
+// alfanumérico contra vetores de referência versionados.
+//
+// Um arquivo de vetores é um documento JSON no formato:
+//
+//	{
+//	  "version": 1,
+//	  "name": "alfanumeric-cnpj",
+//	  "source": "origem dos vetores",
+//	  "vectors": [
+//	    {"input": "12.ABC.345/01DE-35", "dv": "35", "valid": true, "notes": "exemplo oficial"}
+//	  ]
+//	}
+//
+// O campo dv fica vazio quando o cálculo deve falhar para a entrada.
+package conformance
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// FormatVersion é a versão do formato de arquivo de vetores suportada
+const FormatVersion = 1
+
+//go:embed vectors.json
+var defaultVectors []byte
+
+var ErrVersaoNaoSuportada = errors.New("versão do arquivo de vetores não suportada")
+
+// Vector é um caso de referência
+type Vector struct {
+	Input string `json:"input"`
+	DV    string `json:"dv"`
+	Valid bool   `json:"valid"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// Suite é um conjunto versionado de vetores
+type Suite struct {
+	Version int      `json:"version"`
+	Name    string   `json:"name"`
+	Source  string   `json:"source,omitempty"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// Implementation é qualquer implementação capaz de calcular o DV e validar CNPJs
+type Implementation interface {
+	CalculateDV(value string) (string, error)
+	IsValid(value string) bool
+}
+
+// Result é o resultado da execução de um vetor
+type Result struct {
+	Vector   Vector
+	GotDV    string
+	GotValid bool
+	Err      error
+}
+
+// Pass indica se a implementação reproduziu o vetor
+func (r Result) Pass() bool {
+	return r.Err == nil && r.GotDV == r.Vector.DV && r.GotValid == r.Vector.Valid
+}
+
+// Report resume a execução de uma suíte
+type Report struct {
+	Suite   string
+	Results []Result
+	Passed  int
+	Failed  int
+}
+
+// Library é a implementação de referência deste módulo
+var Library Implementation = library{}
+
+type library struct{}
+
+func (library) CalculateDV(value string) (string, error) { return cnpj.CalculateDV(value) }
+func (library) IsValid(value string) bool                { return cnpj.IsValid(value) }
+
+// Load lê uma suíte de vetores e rejeita versões desconhecidas do formato
+func Load(r io.Reader) (*Suite, error) {
+	var s Suite
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("arquivo de vetores inválido: %w", err)
+	}
+
+	if s.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrVersaoNaoSuportada, s.Version)
+	}
+	return &s, nil
+}
+
+// Default retorna a suíte de vetores embutida no pacote
+func Default() *Suite {
+	s, err := Load(bytes.NewReader(defaultVectors))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Encode escreve a suíte no formato de arquivo de vetores
+func (s *Suite) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(s)
+}
+
+// Run executa todos os vetores da suíte contra a implementação.
+// Um erro no cálculo do DV é esperado quando o vetor tem dv vazio.
+func Run(s *Suite, impl Implementation) Report {
+	report := Report{Suite: s.Name}
+
+	for _, v := range s.Vectors {
+		res := Result{Vector: v}
+
+		dv, err := impl.CalculateDV(v.Input)
+		if err != nil {
+			var pe *ProtocolError
+			if errors.As(err, &pe) {
+				res.Err = err
+			}
+			dv = ""
+		}
+		res.GotDV = dv
+		res.GotValid = impl.IsValid(v.Input)
+
+		if res.Pass() {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, res)
+	}
+
+	return report
+}
@@ -0,0 +1,88 @@
+package conformance
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDefaultSuite(t *testing.T) {
+	report := Run(Default(), Library)
+	if report.Failed != 0 {
+		for _, r := range report.Results {
+			if !r.Pass() {
+				t.Errorf("vector %q: dv=%q valid=%v, expected dv=%q valid=%v",
+					r.Vector.Input, r.GotDV, r.GotValid, r.Vector.DV, r.Vector.Valid)
+			}
+		}
+	}
+	if report.Passed == 0 {
+		t.Error("expected at least one vector")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Default().Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(&buf); err != nil {
+		t.Errorf("round trip failed: %v", err)
+	}
+
+	_, err := Load(strings.NewReader(`{"version": 99, "vectors": []}`))
+	if !errors.Is(err, ErrVersaoNaoSuportada) {
+		t.Errorf("expected ErrVersaoNaoSuportada, got %v", err)
+	}
+
+	if _, err := Load(strings.NewReader(`{"version": 1, "vetores": []}`)); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+// brokenDV simula uma implementação que não trata letras corretamente
+type brokenDV struct{ Implementation }
+
+func (b brokenDV) CalculateDV(value string) (string, error) {
+	if strings.ContainsAny(value, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return "00", nil
+	}
+	return b.Implementation.CalculateDV(value)
+}
+
+func TestRunDetectsFailures(t *testing.T) {
+	report := Run(Default(), brokenDV{Library})
+	if report.Failed == 0 {
+		t.Error("expected failures for broken implementation")
+	}
+}
+
+func TestClientServe(t *testing.T) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+
+	go func() {
+		_ = Serve(reqR, respW, Library)
+		_ = respW.Close()
+	}()
+
+	client := NewClient(respR, reqW)
+	report := Run(Default(), client)
+	_ = reqW.Close()
+
+	if report.Failed != 0 || client.Err() != nil {
+		t.Errorf("expected external implementation to pass: %d failures, err=%v", report.Failed, client.Err())
+	}
+}
+
+func TestClientProtocolError(t *testing.T) {
+	client := NewClient(strings.NewReader("not json\n"), io.Discard)
+	report := Run(&Suite{Vectors: []Vector{{Input: "12ABC34501DE", DV: "35"}}}, client)
+
+	var pe *ProtocolError
+	if report.Failed != 1 || !errors.As(report.Results[0].Err, &pe) {
+		t.Errorf("expected protocol error, got %+v", report.Results)
+	}
+}
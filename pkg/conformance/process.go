@@ -0,0 +1,173 @@
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// O protocolo entre o verificador e uma implementação externa usa uma mensagem
+// JSON por linha. Para cada requisição em stdin a implementação escreve
+// exatamente uma resposta em stdout:
+//
+//	{"op":"dv","input":"12ABC34501DE"}     -> {"dv":"35"} ou {"error":"..."}
+//	{"op":"valid","input":"12ABC34501DE35"} -> {"valid":true}
+
+type request struct {
+	Op    string `json:"op"`
+	Input string `json:"input"`
+}
+
+type response struct {
+	DV    string `json:"dv,omitempty"`
+	Valid bool   `json:"valid,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ProtocolError indica falha de comunicação com a implementação externa,
+// e não uma resposta de erro esperada
+type ProtocolError struct {
+	Err error
+}
+
+func (e *ProtocolError) Error() string { return "erro de protocolo: " + e.Err.Error() }
+func (e *ProtocolError) Unwrap() error { return e.Err }
+
+// implError é a resposta de erro devolvida pela implementação externa
+type implError string
+
+func (e implError) Error() string { return string(e) }
+
+// Client fala o protocolo de linhas JSON com uma implementação externa
+type Client struct {
+	enc *json.Encoder
+	in  *bufio.Scanner
+	err error
+}
+
+// NewClient cria um cliente que escreve requisições em w e lê respostas de r
+func NewClient(r io.Reader, w io.Writer) *Client {
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &Client{enc: json.NewEncoder(w), in: in}
+}
+
+func (c *Client) call(op, input string) (response, error) {
+	var resp response
+	if c.err != nil {
+		return resp, c.err
+	}
+
+	if err := c.enc.Encode(request{Op: op, Input: input}); err != nil {
+		c.err = &ProtocolError{Err: err}
+		return resp, c.err
+	}
+
+	if !c.in.Scan() {
+		err := c.in.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		c.err = &ProtocolError{Err: err}
+		return resp, c.err
+	}
+
+	if err := json.Unmarshal(c.in.Bytes(), &resp); err != nil {
+		c.err = &ProtocolError{Err: fmt.Errorf("resposta inválida %q: %w", c.in.Text(), err)}
+		return resp, c.err
+	}
+	return resp, nil
+}
+
+// CalculateDV implementa Implementation
+func (c *Client) CalculateDV(value string) (string, error) {
+	resp, err := c.call("dv", value)
+	if err != nil {
+		return "", err
+	}
+	if resp.Error != "" {
+		return "", implError(resp.Error)
+	}
+	return resp.DV, nil
+}
+
+// IsValid implementa Implementation
+func (c *Client) IsValid(value string) bool {
+	resp, err := c.call("valid", value)
+	return err == nil && resp.Valid
+}
+
+// Err retorna o primeiro erro de protocolo encontrado, se houver
+func (c *Client) Err() error {
+	return c.err
+}
+
+// Process é uma implementação externa executada como processo filho
+type Process struct {
+	*Client
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+// StartProcess inicia o comando e conecta stdin/stdout ao protocolo
+func StartProcess(name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &Process{Client: NewClient(stdout, stdin), cmd: cmd, stdin: stdin}, nil
+}
+
+// Close encerra o stdin do processo e aguarda sua finalização
+func (p *Process) Close() error {
+	_ = p.stdin.Close()
+	return p.cmd.Wait()
+}
+
+// Serve atende o protocolo em r/w usando impl, até o fim da entrada.
+// Permite que esta biblioteca seja usada como implementação de referência
+// por verificadores escritos em outras linguagens.
+func Serve(r io.Reader, w io.Writer, impl Implementation) error {
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64*1024), 1024*1024)
+	enc := json.NewEncoder(w)
+
+	for in.Scan() {
+		var req request
+		var resp response
+
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			resp.Error = "requisição inválida: " + err.Error()
+		} else {
+			switch req.Op {
+			case "dv":
+				dv, err := impl.CalculateDV(req.Input)
+				if err != nil {
+					resp.Error = err.Error()
+				}
+				resp.DV = dv
+			case "valid":
+				resp.Valid = impl.IsValid(req.Input)
+			default:
+				resp.Error = "operação desconhecida: " + req.Op
+			}
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return in.Err()
+}
@@ -0,0 +1,390 @@
+// Package csvcnpj valida e anota colunas de CNPJ em arquivos CSV/TSV, com
+// detecção de delimitador, de coluna e de codificação (UTF-8 ou Latin-1).
+package csvcnpj
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// sampleSize é o tamanho da amostra usada para detectar codificação e delimitador
+const sampleSize = 64 * 1024
+
+// sampleRows é a quantidade de linhas usada para detectar a coluna pelo conteúdo
+const sampleRows = 100
+
+// delimiters são os delimitadores considerados na detecção automática
+var delimiters = []rune{',', ';', '\t', '|'}
+
+// AnnotationColumns são as colunas acrescentadas a cada linha pelo modo de anotação
+var AnnotationColumns = []string{"cnpj_normalizado", "cnpj_formatado", "cnpj_valido", "cnpj_erro", "cnpj_sugestao"}
+
+var ErrColunaNaoEncontrada = errors.New("coluna de CNPJ não encontrada")
+
+// Options controla o processamento de um arquivo
+type Options struct {
+	// Column é o nome ou o índice (a partir de 1) da coluna; vazio detecta automaticamente
+	Column string
+	// Delimiter é o separador de campos; zero detecta automaticamente
+	Delimiter rune
+	// Encoding é a codificação da entrada e da saída; vazio detecta automaticamente
+	Encoding Encoding
+	// NoHeader indica que a primeira linha já é de dados
+	NoHeader bool
+	// Normalize substitui o valor da coluna pela forma canônica em vez de acrescentar colunas
+	Normalize bool
+	// ErrorText converte o motivo da rejeição em texto; o padrão é err.Error()
+	ErrorText func(error) string
+}
+
+// Annotation é o resultado da análise de um valor
+type Annotation struct {
+	Normalizado string
+	Formatado   string
+	Valido      bool
+	Erro        error
+	Sugestao    string
+}
+
+// Annotate valida o valor como está, como cnpj.Validate, e o normaliza e formata
+// para as colunas de saída, sugerindo uma correção quando possível
+func Annotate(value string) Annotation {
+	a := Annotation{Normalizado: cnpj.UnformattedCNPJ(value)}
+
+	a.Erro = cnpj.Validate(value)
+	a.Valido = a.Erro == nil
+	if len(a.Normalizado) == 14 {
+		a.Formatado = cnpj.FormatCNPJ(a.Normalizado)
+	}
+	if !a.Valido {
+		// minúsculas ou separadores fora da máscara: a sugestão é o próprio valor normalizado
+		if cnpj.IsValid(a.Normalizado) {
+			a.Sugestao = a.Normalizado
+		} else if s := cnpj.Suggest(a.Normalizado); len(s) > 0 {
+			a.Sugestao = s[0]
+		}
+	}
+	return a
+}
+
+// Summary resume a análise de um arquivo
+type Summary struct {
+	Linhas      int            `json:"linhas"`
+	Validos     int            `json:"validos"`
+	Invalidos   int            `json:"invalidos"`
+	Corrigiveis int            `json:"corrigiveis"`
+	Motivos     map[string]int `json:"motivos"`
+	Coluna      string         `json:"coluna"`
+	Delimitador string         `json:"delimitador"`
+	Codificacao string         `json:"codificacao"`
+}
+
+func (s *Summary) add(a Annotation) {
+	s.Linhas++
+	if a.Valido {
+		s.Validos++
+		return
+	}
+
+	s.Invalidos++
+	if a.Sugestao != "" {
+		s.Corrigiveis++
+	}
+	if s.Motivos == nil {
+		s.Motivos = map[string]int{}
+	}
+	code := "CNPJ_INVALIDO"
+	var e *cnpj.Error
+	if errors.As(a.Erro, &e) {
+		code = e.Code
+	}
+	s.Motivos[code]++
+}
+
+// table é um CSV aberto, com codificação, delimitador, cabeçalho e coluna de
+// CNPJ já detectados. As primeiras linhas ficam em buffered, pois foram lidas
+// para a detecção da coluna.
+type table struct {
+	in       *csv.Reader
+	header   []string
+	buffered [][]string
+	col      int
+	enc      Encoding
+	delim    rune
+	crlf     bool
+	column   string
+	// empty indica que a entrada não tinha nem o cabeçalho
+	empty bool
+}
+
+func openTable(r io.Reader, opts Options) (*table, error) {
+	br := bufio.NewReaderSize(r, sampleSize)
+	sample, _ := br.Peek(sampleSize)
+
+	t := &table{enc: opts.Encoding, delim: opts.Delimiter, crlf: bytes.Contains(sample, []byte("\r\n"))}
+	if t.enc == "" {
+		t.enc = DetectEncoding(sample)
+	}
+	if t.delim == 0 {
+		t.delim = SniffDelimiter(sample)
+	}
+
+	t.in = csv.NewReader(NewDecoder(br, t.enc))
+	t.in.Comma = t.delim
+	t.in.FieldsPerRecord = -1
+	t.in.LazyQuotes = true
+
+	if !opts.NoHeader {
+		rec, err := t.in.Read()
+		if err == io.EOF {
+			t.empty = true
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.header = rec
+	}
+
+	// Amostra de linhas para a detecção da coluna pelo conteúdo
+	for len(t.buffered) < sampleRows {
+		rec, err := t.in.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.buffered = append(t.buffered, rec)
+	}
+
+	t.col = -1
+	if opts.Column != "" {
+		c, err := ResolveColumn(opts.Column, t.header)
+		if err != nil {
+			return nil, err
+		}
+		t.col = c
+	} else {
+		t.col = DetectColumn(t.header, t.buffered)
+	}
+	if t.col < 0 {
+		return nil, ErrColunaNaoEncontrada
+	}
+	t.column = strconv.Itoa(t.col + 1)
+	if t.col < len(t.header) {
+		t.column = t.header[t.col]
+	}
+	return t, nil
+}
+
+// each chama fn para cada linha de dados, começando pelas linhas da amostra
+func (t *table) each(fn func(rec []string) error) error {
+	for _, rec := range t.buffered {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	for {
+		rec, err := t.in.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
+
+// value retorna o valor da coluna de CNPJ, vazio se a linha for curta
+func (t *table) value(rec []string) string {
+	if t.col < len(rec) {
+		return rec[t.col]
+	}
+	return ""
+}
+
+// Values chama fn com o valor da coluna de CNPJ de cada linha, sem alterá-lo,
+// e retorna o nome (ou o índice) da coluna usada
+func Values(r io.Reader, opts Options, fn func(value string) error) (string, error) {
+	t, err := openTable(r, opts)
+	if err != nil {
+		return "", err
+	}
+	if t.empty {
+		return "", nil
+	}
+	return t.column, t.each(func(rec []string) error {
+		return fn(t.value(rec))
+	})
+}
+
+// Process lê um CSV de r, analisa a coluna de CNPJ e escreve o resultado em w
+// com o mesmo delimitador e a mesma codificação da entrada
+func Process(r io.Reader, w io.Writer, opts Options) (Summary, error) {
+	var summary Summary
+	if opts.ErrorText == nil {
+		opts.ErrorText = func(err error) string { return err.Error() }
+	}
+
+	t, err := openTable(r, opts)
+	if err != nil {
+		return summary, err
+	}
+	summary.Codificacao, summary.Delimitador = string(t.enc), string(t.delim)
+	if t.empty {
+		return summary, nil
+	}
+	summary.Coluna = t.column
+
+	out := csv.NewWriter(NewEncoder(w, t.enc))
+	out.Comma = t.delim
+	out.UseCRLF = t.crlf
+
+	if t.header != nil {
+		header := t.header
+		if !opts.Normalize {
+			header = append(header, AnnotationColumns...)
+		}
+		if err := out.Write(header); err != nil {
+			return summary, err
+		}
+	}
+
+	err = t.each(func(rec []string) error {
+		a := Annotate(t.value(rec))
+		summary.add(a)
+
+		if opts.Normalize {
+			if t.col < len(rec) {
+				rec[t.col] = a.Normalizado
+			}
+			return out.Write(rec)
+		}
+
+		erro := ""
+		if a.Erro != nil {
+			erro = opts.ErrorText(a.Erro)
+		}
+		return out.Write(append(rec, a.Normalizado, a.Formatado, strconv.FormatBool(a.Valido), erro, a.Sugestao))
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	out.Flush()
+	return summary, out.Error()
+}
+
+// SniffDelimiter escolhe o delimitador que divide as linhas da amostra no
+// maior número de colunas de forma consistente; na dúvida retorna vírgula
+func SniffDelimiter(sample []byte) rune {
+	lines := strings.Split(strings.ReplaceAll(string(sample), "\r\n", "\n"), "\n")
+	if len(lines) > 1 {
+		// A última linha pode estar cortada pelo tamanho da amostra
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 20 {
+		lines = lines[:20]
+	}
+
+	best, bestScore := ',', 0
+	for _, d := range delimiters {
+		counts := map[int]int{}
+		for _, l := range lines {
+			if l == "" {
+				continue
+			}
+			r := csv.NewReader(strings.NewReader(l))
+			r.Comma = d
+			r.LazyQuotes = true
+			if rec, err := r.Read(); err == nil && len(rec) > 1 {
+				counts[len(rec)]++
+			}
+		}
+
+		// Pontua pela quantidade de linhas com o número de colunas mais frequente
+		for n, c := range counts {
+			if score := c*100 + n; c > 0 && score > bestScore {
+				best, bestScore = d, score
+			}
+		}
+	}
+	return best
+}
+
+// ResolveColumn localiza a coluna pelo nome (sem diferenciar maiúsculas) ou pelo índice a partir de 1
+func ResolveColumn(spec string, header []string) (int, error) {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), spec) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(spec); err == nil && n >= 1 {
+		return n - 1, nil
+	}
+	return -1, fmt.Errorf("%w: %q", ErrColunaNaoEncontrada, spec)
+}
+
+// DetectColumn procura a coluna de CNPJ pelo nome no cabeçalho e, na falta
+// dele, pela proporção de valores com cara de CNPJ na amostra. Retorna -1 se
+// nenhuma coluna for convincente.
+func DetectColumn(header []string, rows [][]string) int {
+	for i, h := range header {
+		name := strings.ToLower(h)
+		if strings.Contains(name, "cnpj") || strings.Contains(name, "cgc") {
+			return i
+		}
+	}
+
+	type score struct {
+		col   int
+		ratio float64
+	}
+	var scores []score
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r))
+	}
+	for col := 0; col < width; col++ {
+		filled, looks := 0, 0
+		for _, r := range rows {
+			if col >= len(r) || strings.TrimSpace(r[col]) == "" {
+				continue
+			}
+			filled++
+			v := cnpj.UnformattedCNPJ(r[col])
+			if len(v) == 14 && (cnpj.IsValid(v) || looksLikeCNPJ(r[col])) {
+				looks++
+			}
+		}
+		if filled > 0 {
+			scores = append(scores, score{col, float64(looks) / float64(filled)})
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].ratio > scores[j].ratio })
+	if len(scores) > 0 && scores[0].ratio >= 0.5 {
+		return scores[0].col
+	}
+	return -1
+}
+
+// looksLikeCNPJ reconhece valores com a máscara ##.###.###/####-##, mesmo com DV errado
+func looksLikeCNPJ(value string) bool {
+	v := strings.TrimSpace(value)
+	return len(v) == 18 && v[2] == '.' && v[6] == '.' && v[10] == '/' && v[15] == '-'
+}
@@ -0,0 +1,166 @@
+package csvcnpj
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	tests := map[string]rune{
+		"a,b,c\n1,2,3\n":                  ',',
+		"nome;cnpj;cidade\nX;1;São Paulo": ';',
+		"a\tb\n1\t2\n":                    '\t',
+		"a|b|c\n1|2|3\n":                  '|',
+		"\"a;b\",c\n\"1;2\",3\n":          ',',
+		"single\n":                        ',',
+	}
+
+	for sample, expected := range tests {
+		if got := SniffDelimiter([]byte(sample)); got != expected {
+			t.Errorf("SniffDelimiter(%q) = %q, expected %q", sample, got, expected)
+		}
+	}
+}
+
+func TestDetectColumn(t *testing.T) {
+	if got := DetectColumn([]string{"nome", "CNPJ do Fornecedor"}, nil); got != 1 {
+		t.Errorf("header detection = %d, expected 1", got)
+	}
+
+	rows := [][]string{
+		{"Empresa A", "12.ABC.345/01DE-35", "SP"},
+		{"Empresa B", "11222333000181", "RJ"},
+		{"Empresa C", "11.222.333/0001-82", "MG"},
+	}
+	if got := DetectColumn([]string{"nome", "documento", "uf"}, rows); got != 1 {
+		t.Errorf("content detection = %d, expected 1", got)
+	}
+
+	if got := DetectColumn(nil, [][]string{{"a", "b"}}); got != -1 {
+		t.Errorf("expected no column, got %d", got)
+	}
+}
+
+func TestResolveColumn(t *testing.T) {
+	header := []string{"nome", "Documento"}
+	if c, _ := ResolveColumn("documento", header); c != 1 {
+		t.Errorf("by name = %d", c)
+	}
+	if c, _ := ResolveColumn("1", header); c != 0 {
+		t.Errorf("by index = %d", c)
+	}
+	if _, err := ResolveColumn("cnpj", header); !errors.Is(err, ErrColunaNaoEncontrada) {
+		t.Errorf("expected ErrColunaNaoEncontrada, got %v", err)
+	}
+}
+
+func TestProcessAnnotate(t *testing.T) {
+	input := "nome;documento\r\n\"Empresa; A\";12.abc.345/01de-35\r\nEmpresa B;12ABC34501DE36\r\nEmpresa C;\r\n"
+
+	var out bytes.Buffer
+	summary, err := Process(strings.NewReader(input), &out, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "nome;documento;cnpj_normalizado;cnpj_formatado;cnpj_valido;cnpj_erro;cnpj_sugestao\r\n" +
+		"\"Empresa; A\";12.abc.345/01de-35;12ABC34501DE35;12.ABC.345/01DE-35;false;o CNPJ contém caracteres não permitidos;12ABC34501DE35\r\n" +
+		"Empresa B;12ABC34501DE36;12ABC34501DE36;12.ABC.345/01DE-36;false;os dígitos verificadores não conferem;12ABC34501DE35\r\n" +
+		"Empresa C;;;;false;o CNPJ não foi informado;\r\n"
+	if out.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+
+	if summary.Linhas != 3 || summary.Validos != 0 || summary.Invalidos != 3 || summary.Corrigiveis != 2 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Motivos["CNPJ_CARACTERE_INVALIDO"] != 1 || summary.Motivos["CNPJ_DV_INCORRETO"] != 1 || summary.Motivos["CNPJ_VAZIO"] != 1 {
+		t.Errorf("unexpected reasons: %v", summary.Motivos)
+	}
+	if summary.Coluna != "documento" || summary.Delimitador != ";" {
+		t.Errorf("unexpected detection: %+v", summary)
+	}
+}
+
+// TestAnnotate tests that Annotate agrees with cnpj.Validate on the raw value
+func TestAnnotate(t *testing.T) {
+	for _, value := range []string{"12abc34501de35", "11 222 333 0001 81"} {
+		a := Annotate(value)
+		if a.Valido || !errors.Is(a.Erro, cnpj.ErroCaractereInvalido) {
+			t.Errorf("Annotate(%q) = %+v, want CNPJ_CARACTERE_INVALIDO", value, a)
+		}
+		if a.Sugestao != a.Normalizado || !cnpj.IsValid(a.Sugestao) {
+			t.Errorf("Annotate(%q) should suggest the normalized value, got %q", value, a.Sugestao)
+		}
+	}
+	if a := Annotate("12.ABC.345/01DE-35"); !a.Valido || a.Normalizado != "12ABC34501DE35" {
+		t.Errorf("masked value: %+v", a)
+	}
+}
+
+func TestProcessNormalizeLatin1(t *testing.T) {
+	// "São" em Latin-1
+	input := []byte("raz\xe3o,cnpj\nS\xe3o Jo\xe3o,12.abc.345/01de-35\n")
+
+	var out bytes.Buffer
+	summary, err := Process(bytes.NewReader(input), &out, Options{Normalize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte("raz\xe3o,cnpj\nS\xe3o Jo\xe3o,12ABC34501DE35\n")
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Errorf("output = %q, expected %q", out.Bytes(), expected)
+	}
+	if summary.Codificacao != string(Latin1) {
+		t.Errorf("encoding = %s, expected latin1", summary.Codificacao)
+	}
+}
+
+func TestProcessNoHeaderByIndex(t *testing.T) {
+	var out bytes.Buffer
+	_, err := Process(strings.NewReader("x,11222333000181\n"), &out, Options{NoHeader: true, Column: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "x,11222333000181,11222333000181,11.222.333/0001-81,true,,\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestLatin1RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEncoder(&buf, Latin1)
+	text := "Ação — ok"
+	// Escreve byte a byte para exercitar runas divididas entre chamadas
+	for i := 0; i < len(text); i++ {
+		_, _ = w.Write([]byte{text[i]})
+	}
+	if buf.String() != "A\xe7\xe3o ? ok" {
+		t.Errorf("encoded = %q", buf.String())
+	}
+
+	decoded, _ := io.ReadAll(NewDecoder(&buf, Latin1))
+	if string(decoded) != "Ação ? ok" {
+		t.Errorf("decoded = %q", decoded)
+	}
+}
+
+func TestValues(t *testing.T) {
+	var got []string
+	column, err := Values(strings.NewReader("nome;cnpj\nA;11222333000181\nB\n"), Options{}, func(v string) error {
+		got = append(got, v)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if column != "cnpj" || len(got) != 2 || got[0] != "11222333000181" || got[1] != "" {
+		t.Errorf("Values() = %q, %v", column, got)
+	}
+}
@@ -0,0 +1,112 @@
+package csvcnpj
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Encoding é a codificação de caracteres de um arquivo CSV
+type Encoding string
+
+const (
+	UTF8   Encoding = "utf-8"
+	Latin1 Encoding = "latin1"
+)
+
+// ParseEncoding reconhece os nomes usuais das codificações suportadas;
+// "auto" e vazio retornam vazio, indicando detecção automática
+func ParseEncoding(name string) (Encoding, bool) {
+	switch name {
+	case "", "auto":
+		return "", true
+	case "utf-8", "utf8", "UTF-8", "UTF8":
+		return UTF8, true
+	case "latin1", "latin-1", "iso-8859-1", "ISO-8859-1", "LATIN1":
+		return Latin1, true
+	}
+	return "", false
+}
+
+// DetectEncoding assume UTF-8 quando a amostra é UTF-8 válido e Latin-1 caso contrário.
+// Uma sequência UTF-8 cortada no fim da amostra não conta como inválida.
+func DetectEncoding(sample []byte) Encoding {
+	for i := 0; i < utf8.UTFMax && len(sample) > 0; i++ {
+		if utf8.Valid(sample) {
+			return UTF8
+		}
+		sample = sample[:len(sample)-1]
+	}
+	if utf8.Valid(sample) {
+		return UTF8
+	}
+	return Latin1
+}
+
+// NewDecoder converte a entrada para UTF-8
+func NewDecoder(r io.Reader, enc Encoding) io.Reader {
+	if enc == Latin1 {
+		return &latin1Reader{r: r, buf: make([]byte, 32*1024)}
+	}
+	return r
+}
+
+// NewEncoder converte a saída UTF-8 para a codificação informada.
+// Caracteres sem representação em Latin-1 viram '?'.
+func NewEncoder(w io.Writer, enc Encoding) io.Writer {
+	if enc == Latin1 {
+		return &latin1Writer{w: w}
+	}
+	return w
+}
+
+type latin1Reader struct {
+	r       io.Reader
+	buf     []byte
+	pending []byte
+	err     error
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	for len(l.pending) == 0 {
+		if l.err != nil {
+			return 0, l.err
+		}
+		n, err := l.r.Read(l.buf)
+		for _, b := range l.buf[:n] {
+			l.pending = utf8.AppendRune(l.pending, rune(b))
+		}
+		l.err = err
+	}
+
+	n := copy(p, l.pending)
+	l.pending = l.pending[n:]
+	return n, nil
+}
+
+type latin1Writer struct {
+	w     io.Writer
+	carry []byte
+}
+
+func (l *latin1Writer) Write(p []byte) (int, error) {
+	data := append(append([]byte(nil), l.carry...), p...)
+	out := make([]byte, 0, len(data))
+
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 && !utf8.FullRune(data) {
+			break
+		}
+		if r > 0xFF {
+			r = '?'
+		}
+		out = append(out, byte(r))
+		data = data[size:]
+	}
+	l.carry = append(l.carry[:0], data...)
+
+	if _, err := l.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
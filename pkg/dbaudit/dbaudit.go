@@ -0,0 +1,218 @@
+// Package dbaudit audita e normaliza colunas de CNPJ em tabelas do PostgreSQL
+// e do SQLite. A tabela é percorrida em lotes ordenados por uma coluna chave,
+// o que permite retomar uma normalização interrompida a partir da última chave
+// confirmada e desfazê-la com o log de rollback.
+package dbaudit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+// DefaultBatch é o tamanho de lote usado quando nenhum é informado
+const DefaultBatch = 1000
+
+// Target identifica a coluna auditada
+type Target struct {
+	Dialect sqlddl.Dialect
+	Table   string
+	Column  string
+	// Key é a coluna que ordena os lotes; vazia usa rowid no SQLite e id no PostgreSQL
+	Key string
+}
+
+// key retorna a coluna chave, aplicando o padrão do dialeto
+func (t Target) key() string {
+	switch {
+	case t.Key != "":
+		return t.Key
+	case t.Dialect == sqlddl.SQLite:
+		return "rowid"
+	}
+	return "id"
+}
+
+func (t Target) validate() error {
+	if _, err := sqlddl.ParseDialect(string(t.Dialect)); err != nil {
+		return err
+	}
+	for _, name := range []string{t.Table, t.Column, t.key()} {
+		if !sqlddl.ValidIdentifier(name) {
+			return fmt.Errorf("%w: %q", sqlddl.ErrIdentificadorInvalido, name)
+		}
+	}
+	if strings.Contains(t.Column, ".") || strings.Contains(t.key(), ".") {
+		return fmt.Errorf("%w: %q", sqlddl.ErrIdentificadorInvalido, t.Column)
+	}
+	return nil
+}
+
+// placeholder retorna o marcador do n-ésimo parâmetro (a partir de 1)
+func (t Target) placeholder(n int) string {
+	if t.Dialect == sqlddl.Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// row é uma linha lida em um lote; Value é nil quando a coluna é NULL
+type row struct {
+	Key   string
+	Value *string
+}
+
+// queryer é satisfeita por *sql.DB e *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// batch lê até size linhas com chave maior que after (ou desde o início, se
+// after for vazio), em ordem de chave
+func (t Target) batch(ctx context.Context, q queryer, after string, size int) ([]row, error) {
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", t.key(), t.Column, t.Table)
+	var args []any
+	if after != "" {
+		query += fmt.Sprintf(" WHERE %s > %s", t.key(), t.placeholder(1))
+		args = append(args, after)
+	}
+	query += fmt.Sprintf(" ORDER BY %s LIMIT %d", t.key(), size)
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []row
+	for rows.Next() {
+		var (
+			key   any
+			value sql.NullString
+		)
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		r := row{Key: text(key)}
+		if value.Valid {
+			r.Value = &value.String
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// text converte o valor da chave lido do banco em texto, usado nos
+// parâmetros, no checkpoint e no log de rollback
+func text(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(t)
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// Finding é uma linha com CNPJ inválido
+type Finding struct {
+	Chave  string `json:"chave"`
+	Valor  string `json:"valor"`
+	Codigo string `json:"codigo"`
+	// Normalizavel indica que o valor fica válido ao ser normalizado
+	Normalizavel bool   `json:"normalizavel"`
+	Sugestao     string `json:"sugestao,omitempty"`
+	Erro         error  `json:"-"`
+}
+
+// Summary resume uma auditoria
+type Summary struct {
+	Total     int `json:"total"`
+	Nulos     int `json:"nulos"`
+	Validos   int `json:"validos"`
+	Invalidos int `json:"invalidos"`
+	// Normalizaveis conta os inválidos que a normalização corrigiria, ex.: minúsculas
+	Normalizaveis int            `json:"normalizaveis"`
+	Motivos       map[string]int `json:"motivos"`
+}
+
+// Audit percorre a coluna em lotes e chama report para cada valor inválido
+func Audit(ctx context.Context, db *sql.DB, t Target, size int, report func(Finding) error) (Summary, error) {
+	summary := Summary{Motivos: map[string]int{}}
+	if err := t.validate(); err != nil {
+		return summary, err
+	}
+	if size <= 0 {
+		size = DefaultBatch
+	}
+
+	after := ""
+	for {
+		rows, err := t.batch(ctx, db, after, size)
+		if err != nil {
+			return summary, err
+		}
+
+		for _, r := range rows {
+			summary.Total++
+			if r.Value == nil {
+				summary.Nulos++
+				continue
+			}
+			err := cnpj.Validate(*r.Value)
+			if err == nil {
+				summary.Validos++
+				continue
+			}
+
+			f := Finding{Chave: r.Key, Valor: *r.Value, Codigo: code(err), Erro: err}
+			f.Normalizavel = cnpj.IsValid(Canonical(*r.Value))
+			if !f.Normalizavel {
+				if s := cnpj.Suggest(Canonical(*r.Value)); len(s) > 0 {
+					f.Sugestao = s[0]
+				}
+			}
+
+			summary.Invalidos++
+			summary.Motivos[f.Codigo]++
+			if f.Normalizavel {
+				summary.Normalizaveis++
+			}
+			if report != nil {
+				if err := report(f); err != nil {
+					return summary, err
+				}
+			}
+		}
+
+		if len(rows) < size {
+			return summary, nil
+		}
+		after = rows[len(rows)-1].Key
+	}
+}
+
+// Canonical é a forma gravada pela normalização: sem máscara, espaços ou
+// outros separadores, com letras maiúsculas
+func Canonical(value string) string {
+	return cnpj.UnformattedCNPJ(value)
+}
+
+func code(err error) string {
+	var e *cnpj.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return "CNPJ_INVALIDO"
+}
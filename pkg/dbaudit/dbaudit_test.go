@@ -0,0 +1,178 @@
+package dbaudit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	_ "modernc.org/sqlite"
+)
+
+// fixture cria uma tabela com valores em formatos variados; as linhas se
+// repetem para ocupar vários lotes
+func fixture(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`CREATE TABLE fornecedores (id INTEGER PRIMARY KEY, doc TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	values := []any{"12ABC34501DE35", "12.abc.345/01de-35", " 12.ABC.345/01DE-35 ", "12ABC34501DE36", nil, "x", "11.222.333/0001-81"}
+	for i := 0; i < 10; i++ {
+		for _, v := range values {
+			if _, err := db.Exec(`INSERT INTO fornecedores (doc) VALUES (?)`, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return db
+}
+
+func target() Target {
+	return Target{Dialect: sqlddl.SQLite, Table: "fornecedores", Column: "doc"}
+}
+
+func TestAudit(t *testing.T) {
+	db := fixture(t)
+
+	var findings []Finding
+	summary, err := Audit(context.Background(), db, target(), 4, func(f Finding) error {
+		findings = append(findings, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Total != 70 || summary.Nulos != 10 || summary.Validos != 20 || summary.Invalidos != 40 || summary.Normalizaveis != 20 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Motivos["CNPJ_DV_INCORRETO"] != 10 || summary.Motivos["CNPJ_CARACTERE_INVALIDO"] != 30 {
+		t.Errorf("unexpected reasons: %v", summary.Motivos)
+	}
+	if len(findings) != 40 || findings[0].Chave != "2" || !findings[0].Normalizavel || findings[2].Sugestao == "" {
+		t.Errorf("unexpected findings: %+v", findings[:3])
+	}
+}
+
+func TestNormalizeAndRollback(t *testing.T) {
+	db := fixture(t)
+	ctx := context.Background()
+
+	before := dump(t, db)
+
+	dry, err := Normalize(ctx, db, target(), NormalizeOptions{Batch: 5, DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dry.Alterados != 30 || dry.Ignorados != 20 || dump(t, db) != before {
+		t.Errorf("dry-run changed data or miscounted: %+v", dry)
+	}
+
+	var (
+		log         bytes.Buffer
+		checkpoints []string
+	)
+	opts := NormalizeOptions{Batch: 5, Log: &log, Checkpoint: func(last string) error {
+		checkpoints = append(checkpoints, last)
+		return nil
+	}}
+	summary, err := Normalize(ctx, db, target(), opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Alterados != 30 || summary.Lidos != 70 || summary.UltimaChave != "70" || len(checkpoints) != 14 {
+		t.Errorf("unexpected summary %+v, checkpoints %v", summary, checkpoints)
+	}
+
+	var distinct int
+	if err := db.QueryRow(`SELECT count(DISTINCT doc) FROM fornecedores WHERE doc LIKE '12%'`).Scan(&distinct); err != nil {
+		t.Fatal(err)
+	}
+	if distinct != 2 {
+		t.Errorf("expected 2 distinct values after normalization, got %d", distinct)
+	}
+
+	again, err := Normalize(ctx, db, target(), NormalizeOptions{Batch: 5}, nil)
+	if err != nil || again.Alterados != 0 {
+		t.Errorf("normalization is not idempotent: %+v, %v", again, err)
+	}
+
+	restored, err := Rollback(ctx, db, &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 30 || dump(t, db) != before {
+		t.Errorf("rollback restored %d rows; data differs", restored)
+	}
+}
+
+func TestNormalizeResume(t *testing.T) {
+	db := fixture(t)
+	ctx := context.Background()
+
+	stop := errors.New("stop")
+	var last string
+	_, err := Normalize(ctx, db, target(), NormalizeOptions{Batch: 7, Checkpoint: func(l string) error {
+		last = l
+		if l == "21" {
+			return stop
+		}
+		return nil
+	}}, nil)
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected stop, got %v", err)
+	}
+
+	summary, err := Normalize(ctx, db, target(), NormalizeOptions{Batch: 7, After: last}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Lidos != 49 || summary.Alterados != 21 {
+		t.Errorf("unexpected resumed summary: %+v", summary)
+	}
+}
+
+func TestInvalidTarget(t *testing.T) {
+	db := fixture(t)
+
+	bad := Target{Dialect: sqlddl.SQLite, Table: "fornecedores; DROP TABLE x", Column: "doc"}
+	if _, err := Audit(context.Background(), db, bad, 0, nil); !errors.Is(err, sqlddl.ErrIdentificadorInvalido) {
+		t.Errorf("expected ErrIdentificadorInvalido, got %v", err)
+	}
+	if _, err := Rollback(context.Background(), db, strings.NewReader(`{"chave":"1"}`)); !errors.Is(err, ErrLogInvalido) {
+		t.Errorf("expected ErrLogInvalido, got %v", err)
+	}
+}
+
+func dump(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	rows, err := db.Query(`SELECT id, coalesce(doc, 'NULL') FROM fornecedores ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var b strings.Builder
+	for rows.Next() {
+		var (
+			id  int
+			doc string
+		)
+		if err := rows.Scan(&id, &doc); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&b, "%d=%s\n", id, doc)
+	}
+	return b.String()
+}
@@ -0,0 +1,232 @@
+package dbaudit
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+var ErrLogInvalido = errors.New("log de rollback inválido")
+
+// NormalizeOptions controla uma normalização
+type NormalizeOptions struct {
+	// Batch é a quantidade de linhas por transação
+	Batch int
+	// DryRun apenas informa as alterações, sem gravar nada
+	DryRun bool
+	// After retoma a normalização depois desta chave; vazio começa do início
+	After string
+	// Log recebe o log de rollback em NDJSON, antes de cada lote ser confirmado
+	Log io.Writer
+	// Checkpoint é chamado com a última chave de cada lote confirmado
+	Checkpoint func(last string) error
+}
+
+// Change é uma alteração de valor, como gravada no log de rollback
+type Change struct {
+	Chave  string `json:"chave"`
+	Antes  string `json:"antes"`
+	Depois string `json:"depois"`
+}
+
+// NormalizeSummary resume uma normalização
+type NormalizeSummary struct {
+	Lidos     int `json:"lidos"`
+	Alterados int `json:"alterados"`
+	// Ignorados são os valores que continuariam inválidos após a normalização
+	Ignorados   int    `json:"ignorados"`
+	Nulos       int    `json:"nulos"`
+	UltimaChave string `json:"ultima_chave"`
+}
+
+// logHeader é a primeira linha do log de rollback; identifica a coluna alterada
+type logHeader struct {
+	Dialeto sqlddl.Dialect `json:"dialeto"`
+	Tabela  string         `json:"tabela"`
+	Coluna  string         `json:"coluna"`
+	Chave   string         `json:"chave_coluna"`
+}
+
+// Normalize grava a forma canônica (Canonical) de cada valor que fica válido ao
+// ser normalizado, um lote por transação. Valores que continuariam inválidos não
+// são alterados. Cada UPDATE confere o valor lido, então alterações concorrentes
+// não são sobrescritas.
+func Normalize(ctx context.Context, db *sql.DB, t Target, opts NormalizeOptions, report func(Change) error) (NormalizeSummary, error) {
+	summary := NormalizeSummary{UltimaChave: opts.After}
+	if err := t.validate(); err != nil {
+		return summary, err
+	}
+	if opts.Batch <= 0 {
+		opts.Batch = DefaultBatch
+	}
+
+	var log *json.Encoder
+	if opts.Log != nil && !opts.DryRun {
+		log = json.NewEncoder(opts.Log)
+		if err := log.Encode(logHeader{Dialeto: t.Dialect, Tabela: t.Table, Coluna: t.Column, Chave: t.key()}); err != nil {
+			return summary, err
+		}
+	}
+
+	update := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s = %s",
+		t.Table, t.Column, t.placeholder(1), t.key(), t.placeholder(2), t.Column, t.placeholder(3))
+
+	for {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return summary, err
+		}
+
+		n, err := normalizeBatch(ctx, tx, t, update, opts, log, &summary, report)
+		if err == nil && opts.DryRun {
+			err = tx.Rollback()
+		} else if err == nil {
+			err = tx.Commit()
+		} else {
+			_ = tx.Rollback()
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		if opts.Checkpoint != nil && !opts.DryRun && n > 0 {
+			if err := opts.Checkpoint(summary.UltimaChave); err != nil {
+				return summary, err
+			}
+		}
+		if n < opts.Batch {
+			return summary, nil
+		}
+	}
+}
+
+// normalizeBatch processa um lote dentro de tx e retorna quantas linhas foram lidas
+func normalizeBatch(ctx context.Context, tx *sql.Tx, t Target, update string, opts NormalizeOptions,
+	log *json.Encoder, summary *NormalizeSummary, report func(Change) error) (int, error) {
+	rows, err := t.batch(ctx, tx, summary.UltimaChave, opts.Batch)
+	if err != nil {
+		return 0, err
+	}
+
+	var changes []Change
+	for _, r := range rows {
+		summary.Lidos++
+		if r.Value == nil {
+			summary.Nulos++
+			continue
+		}
+		canonical := Canonical(*r.Value)
+		if !cnpj.IsValid(canonical) {
+			summary.Ignorados++
+			continue
+		}
+		if canonical == *r.Value {
+			continue
+		}
+		changes = append(changes, Change{Chave: r.Key, Antes: *r.Value, Depois: canonical})
+	}
+
+	for _, c := range changes {
+		if !opts.DryRun {
+			if _, err := tx.ExecContext(ctx, update, c.Depois, c.Chave, c.Antes); err != nil {
+				return 0, err
+			}
+			if log != nil {
+				if err := log.Encode(c); err != nil {
+					return 0, err
+				}
+			}
+		}
+		summary.Alterados++
+		if report != nil {
+			if err := report(c); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// O log precisa estar gravado antes da confirmação do lote
+	if s, ok := opts.Log.(interface{ Sync() error }); ok && log != nil {
+		if err := s.Sync(); err != nil {
+			return 0, err
+		}
+	}
+	if len(rows) > 0 {
+		summary.UltimaChave = rows[len(rows)-1].Key
+	}
+	return len(rows), nil
+}
+
+// Rollback desfaz as alterações registradas em um log de Normalize, em uma
+// única transação. Cada valor só volta ao original se ainda estiver com o valor
+// gravado pela normalização. Retorna quantas linhas foram restauradas.
+func Rollback(ctx context.Context, db *sql.DB, log io.Reader) (int, error) {
+	sc := bufio.NewScanner(log)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		changes []Change
+		header  *logHeader
+	)
+	for line := 1; sc.Scan(); line++ {
+		raw := sc.Bytes()
+		if len(raw) == 0 {
+			continue
+		}
+
+		var h logHeader
+		if err := json.Unmarshal(raw, &h); err == nil && h.Tabela != "" {
+			// Uma normalização retomada acrescenta um novo cabeçalho ao mesmo log
+			if header != nil && *header != h {
+				return 0, fmt.Errorf("%w: linha %d: o log mistura colunas diferentes", ErrLogInvalido, line)
+			}
+			header = &h
+			continue
+		}
+
+		var c Change
+		if err := json.Unmarshal(raw, &c); err != nil || header == nil || c.Chave == "" {
+			return 0, fmt.Errorf("%w: linha %d", ErrLogInvalido, line)
+		}
+		changes = append(changes, c)
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, ErrLogInvalido
+	}
+
+	t := Target{Dialect: header.Dialeto, Table: header.Tabela, Column: header.Coluna, Key: header.Chave}
+	if err := t.validate(); err != nil {
+		return 0, err
+	}
+	restore := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s = %s",
+		t.Table, t.Column, t.placeholder(1), t.key(), t.placeholder(2), t.Column, t.placeholder(3))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	restored := 0
+	// Em ordem inversa, para o caso de a mesma linha aparecer mais de uma vez
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		res, err := tx.ExecContext(ctx, restore, c.Antes, c.Chave, c.Depois)
+		if err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			restored += int(n)
+		}
+	}
+	return restored, tx.Commit()
+}
@@ -0,0 +1,93 @@
+package fixtures
+
+// Tabelas usadas na geração. São pequenas de propósito: o objetivo é ter dados
+// verossímeis e consistentes entre si, não reproduzir cadastros reais.
+
+// uf associa a sigla à capital e à faixa de CEP dos Correios, de modo que o CEP
+// gerado seja coerente com o estado
+type uf struct {
+	Sigla   string
+	Capital string
+	// CEPMin e CEPMax são os cinco primeiros dígitos da faixa
+	CEPMin, CEPMax int
+}
+
+var ufs = []uf{
+	{"AC", "Rio Branco", 69900, 69999},
+	{"AL", "Maceió", 57000, 57999},
+	{"AM", "Manaus", 69000, 69299},
+	{"AP", "Macapá", 68900, 68999},
+	{"BA", "Salvador", 40000, 48999},
+	{"CE", "Fortaleza", 60000, 63999},
+	{"DF", "Brasília", 70000, 72799},
+	{"ES", "Vitória", 29000, 29999},
+	{"GO", "Goiânia", 72800, 72999},
+	{"MA", "São Luís", 65000, 65999},
+	{"MG", "Belo Horizonte", 30000, 39999},
+	{"MS", "Campo Grande", 79000, 79999},
+	{"MT", "Cuiabá", 78000, 78899},
+	{"PA", "Belém", 66000, 68899},
+	{"PB", "João Pessoa", 58000, 58999},
+	{"PE", "Recife", 50000, 56999},
+	{"PI", "Teresina", 64000, 64999},
+	{"PR", "Curitiba", 80000, 87999},
+	{"RJ", "Rio de Janeiro", 20000, 28999},
+	{"RN", "Natal", 59000, 59999},
+	{"RO", "Porto Velho", 76800, 76999},
+	{"RR", "Boa Vista", 69300, 69399},
+	{"RS", "Porto Alegre", 90000, 99999},
+	{"SC", "Florianópolis", 88000, 89999},
+	{"SE", "Aracaju", 49000, 49999},
+	{"SP", "São Paulo", 1000, 19999},
+	{"TO", "Palmas", 77000, 77999},
+}
+
+// atividade é uma subclasse CNAE com as palavras usadas nos nomes da empresa
+type atividade struct {
+	Codigo    string
+	Descricao string
+	// Fantasia prefixa o nome fantasia; Ramo completa a razão social
+	Fantasia, Ramo string
+}
+
+var atividades = []atividade{
+	{"4711-3/02", "Comércio varejista de mercadorias em geral, com predominância de produtos alimentícios - supermercados", "Supermercado", "Comércio de Alimentos"},
+	{"4721-1/02", "Padaria e confeitaria com predominância de revenda", "Padaria", "Panificação"},
+	{"5611-2/01", "Restaurantes e similares", "Restaurante", "Alimentação"},
+	{"4771-7/01", "Comércio varejista de produtos farmacêuticos, sem manipulação de fórmulas", "Farmácia", "Comércio de Medicamentos"},
+	{"4744-0/99", "Comércio varejista de materiais de construção em geral", "Casa de Materiais", "Materiais de Construção"},
+	{"4530-7/03", "Comércio a varejo de peças e acessórios novos para veículos automotores", "Auto Peças", "Comércio de Autopeças"},
+	{"4520-0/01", "Serviços de manutenção e reparação mecânica de veículos automotores", "Auto Center", "Serviços Automotivos"},
+	{"6201-5/01", "Desenvolvimento de programas de computador sob encomenda", "Software", "Tecnologia da Informação"},
+	{"6204-0/00", "Consultoria em tecnologia da informação", "Tech", "Consultoria em Informática"},
+	{"6920-6/01", "Atividades de contabilidade", "Contabilidade", "Serviços Contábeis"},
+	{"7319-0/02", "Promoção de vendas", "Marketing", "Comunicação e Marketing"},
+	{"8599-6/03", "Treinamento em informática", "Escola", "Educação Profissional"},
+	{"9602-5/01", "Cabeleireiros, manicure e pedicure", "Salão", "Serviços de Beleza"},
+	{"4930-2/02", "Transporte rodoviário de carga, exceto produtos perigosos e mudanças, intermunicipal, interestadual e internacional", "Transportes", "Transportes e Logística"},
+	{"4120-4/00", "Construção de edifícios", "Construtora", "Construções e Incorporações"},
+	{"8630-5/04", "Atividade odontológica", "Odonto", "Serviços Odontológicos"},
+}
+
+var nomes = []string{
+	"Ana", "Antônio", "Beatriz", "Bruno", "Camila", "Carlos", "Daniela", "Eduardo", "Fernanda", "Felipe",
+	"Gabriela", "Gustavo", "Helena", "Igor", "Juliana", "João", "Larissa", "Lucas", "Mariana", "Marcos",
+	"Natália", "Paulo", "Patrícia", "Rafael", "Renata", "Rodrigo", "Sofia", "Thiago", "Vanessa", "Vinícius",
+}
+
+var sobrenomes = []string{
+	"Almeida", "Araújo", "Barbosa", "Cardoso", "Carvalho", "Castro", "Costa", "Dias", "Ferreira", "Gomes",
+	"Lima", "Martins", "Melo", "Monteiro", "Moreira", "Nascimento", "Oliveira", "Pereira", "Ribeiro", "Rocha",
+	"Rodrigues", "Santos", "Silva", "Souza", "Teixeira", "Vieira",
+}
+
+var logradouros = []string{
+	"Rua das Flores", "Rua XV de Novembro", "Avenida Brasil", "Rua Sete de Setembro", "Avenida Getúlio Vargas",
+	"Rua Tiradentes", "Avenida Santos Dumont", "Rua Dom Pedro II", "Rua São José", "Avenida Beira Mar",
+	"Rua Marechal Deodoro", "Avenida JK", "Rua da Independência", "Travessa das Palmeiras",
+}
+
+var bairros = []string{
+	"Centro", "Jardim América", "Vila Nova", "Boa Vista", "Santa Cruz", "São Francisco", "Industrial",
+	"Cidade Nova", "Bela Vista", "Jardim das Acácias",
+}
@@ -0,0 +1,61 @@
+package fixtures
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// GenerateCPF gera um CPF válido, sem máscara, a partir de r
+func GenerateCPF(r *rand.Rand) string {
+	for {
+		b := make([]byte, 9, 11)
+		for i := range b {
+			b[i] = byte('0' + r.Intn(10))
+		}
+		if strings.Count(string(b), string(b[0])) == len(b) {
+			continue // sequências repetidas são rejeitadas pela Receita
+		}
+		b = append(b, mod11DV(b))
+		b = append(b, mod11DV(b))
+		return string(b)
+	}
+}
+
+// IsValidCPF indica se o CPF, com ou sem máscara, tem 11 dígitos, não é uma
+// sequência repetida e tem os dois dígitos verificadores corretos
+func IsValidCPF(value string) bool {
+	v := strings.NewReplacer(".", "", "-", "").Replace(value)
+	if len(v) != 11 || strings.Trim(v, "0123456789") != "" || strings.Count(v, v[:1]) == 11 {
+		return false
+	}
+	b := []byte(v)
+	return mod11DV(b[:9]) == b[9] && mod11DV(b[:10]) == b[10]
+}
+
+// FormatCPF aplica a máscara 000.000.000-00 a um CPF de 11 dígitos
+func FormatCPF(value string) string {
+	if len(value) != 11 {
+		return value
+	}
+	return value[:3] + "." + value[3:6] + "." + value[6:9] + "-" + value[9:]
+}
+
+// mod11DV calcula o próximo dígito verificador do CPF: pesos
+// decrescentes a partir de len+1 e módulo 11, com resto menor que 2 resultando em 0
+func mod11DV(digits []byte) byte {
+	sum := 0
+	for i, d := range digits {
+		sum += int(d-'0') * (len(digits) + 1 - i)
+	}
+	if r := sum % 11; r >= 2 {
+		return byte('0' + 11 - r)
+	}
+	return '0'
+}
+
+// generateCEP gera um CEP, com máscara, dentro da faixa do estado
+func generateCEP(r *rand.Rand, u uf) string {
+	prefix := u.CEPMin + r.Intn(u.CEPMax-u.CEPMin+1)
+	return fmt.Sprintf("%05d-%03d", prefix, r.Intn(1000))
+}
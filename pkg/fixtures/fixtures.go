@@ -0,0 +1,283 @@
+// Package fixtures gera empresas fictícias completas e consistentes entre si
+// para popular ambientes de teste: CNPJ da matriz e das filiais, razão social e
+// nome fantasia, endereço com CEP da faixa do estado, CNAE, Inscrição Estadual,
+// data de abertura e sócios com CPFs válidos. A mesma semente gera sempre as
+// mesmas empresas.
+package fixtures
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+const (
+	// Layout é o formato das datas dos registros
+	Layout = "2006-01-02"
+	// MaxFiliais é o limite de filiais por empresa: as ordens vão de 0002 a 9999
+	MaxFiliais = 9998
+)
+
+var (
+	// InicioAlfanumerico é a data a partir da qual a Receita Federal emite CNPJs
+	// alfanuméricos; empresas com letras na raiz são abertas a partir dela
+	InicioAlfanumerico = time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	inicioNumerico = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ateDefault     = time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// Endereco é o endereço de um estabelecimento
+type Endereco struct {
+	Logradouro string `json:"logradouro"`
+	Numero     string `json:"numero"`
+	Bairro     string `json:"bairro"`
+	Municipio  string `json:"municipio"`
+	UF         string `json:"uf"`
+	CEP        string `json:"cep"`
+}
+
+// CNAE é uma atividade econômica, com o código da subclasse formatado
+type CNAE struct {
+	Codigo    string `json:"codigo"`
+	Descricao string `json:"descricao"`
+}
+
+// Socio é um sócio pessoa física; o CPF não tem máscara
+type Socio struct {
+	Nome         string `json:"nome"`
+	CPF          string `json:"cpf"`
+	Qualificacao string `json:"qualificacao"`
+	DataEntrada  string `json:"data_entrada"`
+}
+
+// Estabelecimento é uma filial; a matriz é a própria Empresa
+type Estabelecimento struct {
+	CNPJ              string   `json:"cnpj"`
+	NomeFantasia      string   `json:"nome_fantasia"`
+	DataAbertura      string   `json:"data_abertura"`
+	InscricaoEstadual string   `json:"inscricao_estadual"`
+	Endereco          Endereco `json:"endereco"`
+}
+
+// Empresa é a matriz com os dados da empresa, seus sócios e suas filiais.
+// Os CNPJs não têm máscara; as filiais compartilham a raiz da matriz. A Inscrição
+// Estadual fica vazia em TO, único estado cuja regra não foi implementada.
+type Empresa struct {
+	CNPJ              string            `json:"cnpj"`
+	RazaoSocial       string            `json:"razao_social"`
+	NomeFantasia      string            `json:"nome_fantasia"`
+	NaturezaJuridica  string            `json:"natureza_juridica"`
+	DataAbertura      string            `json:"data_abertura"`
+	CNAEPrincipal     CNAE              `json:"cnae_principal"`
+	CNAESecundarios   []CNAE            `json:"cnaes_secundarios"`
+	InscricaoEstadual string            `json:"inscricao_estadual"`
+	Endereco          Endereco          `json:"endereco"`
+	Socios            []Socio           `json:"socios"`
+	Filiais           []Estabelecimento `json:"filiais"`
+}
+
+// Options controla a geração
+type Options struct {
+	Seed int64
+	// Numeric gera apenas CNPJs numéricos, como os anteriores ao formato alfanumérico
+	Numeric bool
+	// MaxFiliais é o máximo de filiais por empresa; cada uma tem de 0 a MaxFiliais
+	// (limitado à constante MaxFiliais)
+	MaxFiliais int
+	// MaxSocios é o máximo de sócios por empresa; cada uma tem de 1 a MaxSocios
+	MaxSocios int
+	// Ate é a data mais recente de abertura e de entrada de sócios; zero usa 31/12/2026
+	Ate time.Time
+}
+
+// Generator produz as empresas em sequência; não é seguro para uso concorrente
+type Generator struct {
+	r      *rand.Rand
+	opts   Options
+	raizes map[string]bool
+}
+
+// New cria um gerador determinístico a partir de opts.Seed
+func New(opts Options) *Generator {
+	if opts.Ate.IsZero() {
+		opts.Ate = ateDefault
+	}
+	if opts.MaxSocios < 1 {
+		opts.MaxSocios = 1
+	}
+	opts.MaxFiliais = min(max(opts.MaxFiliais, 0), MaxFiliais)
+	return &Generator{r: rand.New(rand.NewSource(opts.Seed)), opts: opts, raizes: map[string]bool{}}
+}
+
+// Next gera a próxima empresa. As raízes não se repetem dentro de um gerador.
+func (g *Generator) Next() Empresa {
+	matriz := cnpj.GenerateWith(g.r, cnpj.GenerateOptions{Numeric: g.opts.Numeric, Matriz: true})
+	for g.raizes[cnpj.Raiz(matriz)] {
+		matriz = cnpj.GenerateWith(g.r, cnpj.GenerateOptions{Numeric: g.opts.Numeric, Matriz: true})
+	}
+	g.raizes[cnpj.Raiz(matriz)] = true
+	return g.company(matriz, nil)
+}
+
+// ForCNPJ gera a empresa fictícia de um CNPJ válido, com ou sem máscara: a mesma
+// raiz e a mesma opts.Seed resultam sempre na mesma empresa. Se o CNPJ for de
+// uma filial, ela é a única filial da empresa retornada; opts.Numeric e
+// opts.MaxFiliais não são usados.
+func ForCNPJ(value string, opts Options) (Empresa, error) {
+	if err := cnpj.Validate(value); err != nil {
+		return Empresa{}, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(cnpj.Raiz(value)))
+	opts.Seed ^= int64(h.Sum64())
+
+	matriz, err := cnpj.Branch(value, "0001")
+	if err != nil {
+		return Empresa{}, err
+	}
+	ordens := []string{}
+	if !cnpj.IsMatriz(value) {
+		ordens = append(ordens, cnpj.Ordem(value))
+	}
+	return New(opts).company(matriz, ordens), nil
+}
+
+// company gera a empresa da matriz informada. ordens são as ordens das filiais;
+// nil sorteia de 0 a MaxFiliais filiais, a partir da 0002.
+func (g *Generator) company(matriz string, ordens []string) Empresa {
+	// a data de abertura precisa ser compatível com o formato da raiz
+	inicio, fim := inicioNumerico, InicioAlfanumerico.AddDate(0, 0, -1)
+	if strings.Trim(cnpj.Raiz(matriz), "0123456789") != "" {
+		inicio, fim = InicioAlfanumerico, g.opts.Ate
+	}
+	if fim.After(g.opts.Ate) {
+		fim = g.opts.Ate
+	}
+	abertura := g.date(inicio, fim)
+
+	socios, sa := g.socios(abertura)
+	act := atividades[g.r.Intn(len(atividades))]
+	e := Empresa{
+		CNPJ:             matriz,
+		NomeFantasia:     act.Fantasia + " " + sobrenome(socios[0].Nome),
+		DataAbertura:     abertura.Format(Layout),
+		CNAEPrincipal:    CNAE{act.Codigo, act.Descricao},
+		CNAESecundarios:  []CNAE{},
+		Socios:           socios,
+		Filiais:          []Estabelecimento{},
+		NaturezaJuridica: "206-2 - Sociedade Empresária Limitada",
+	}
+
+	surnames := []string{sobrenome(socios[0].Nome)}
+	if len(socios) > 1 {
+		surnames = append(surnames, sobrenome(socios[1].Nome))
+	}
+	if sa {
+		e.NaturezaJuridica = "205-4 - Sociedade Anônima Fechada"
+		e.RazaoSocial = surnames[0] + " " + act.Ramo + " S.A."
+	} else {
+		e.RazaoSocial = strings.Join(surnames, " & ") + " " + act.Ramo + " Ltda"
+	}
+
+	for _, i := range g.r.Perm(len(atividades))[:g.r.Intn(3)] {
+		if a := atividades[i]; a.Codigo != act.Codigo {
+			e.CNAESecundarios = append(e.CNAESecundarios, CNAE{a.Codigo, a.Descricao})
+		}
+	}
+
+	estado := ufs[g.r.Intn(len(ufs))]
+	e.Endereco = g.endereco(estado)
+	e.InscricaoEstadual = generateIE(g.r, estado.Sigla)
+
+	if ordens == nil {
+		ordens = make([]string, g.r.Intn(g.opts.MaxFiliais+1))
+		for i := range ordens {
+			ordens[i] = strconv.Itoa(i + 2)
+		}
+	}
+	for _, ordem := range ordens {
+		filial, _ := cnpj.Branch(matriz, ordem)
+		// a maior parte das filiais fica no mesmo estado da matriz
+		if g.r.Intn(10) >= 7 {
+			estado = ufs[g.r.Intn(len(ufs))]
+		}
+		e.Filiais = append(e.Filiais, Estabelecimento{
+			CNPJ:              filial,
+			NomeFantasia:      e.NomeFantasia,
+			DataAbertura:      g.date(abertura, g.opts.Ate).Format(Layout),
+			InscricaoEstadual: generateIE(g.r, estado.Sigla),
+			Endereco:          g.endereco(estado),
+		})
+	}
+	return e
+}
+
+// Generate gera n empresas com as opções informadas
+func Generate(n int, opts Options) []Empresa {
+	g := New(opts)
+	out := make([]Empresa, n)
+	for i := range out {
+		out[i] = g.Next()
+	}
+	return out
+}
+
+// date sorteia uma data entre inicio e fim, inclusive; fim anterior a inicio resulta em inicio
+func (g *Generator) date(inicio, fim time.Time) time.Time {
+	days := int(fim.Sub(inicio).Hours() / 24)
+	if days <= 0 {
+		return inicio
+	}
+	return inicio.AddDate(0, 0, g.r.Intn(days+1))
+}
+
+// socios gera de 1 a MaxSocios sócios; o primeiro entra na abertura e administra
+// a empresa. Em parte dos casos com três ou mais sócios a empresa é uma S.A.,
+// indicada pelo segundo retorno.
+func (g *Generator) socios(abertura time.Time) ([]Socio, bool) {
+	n := 1 + g.r.Intn(g.opts.MaxSocios)
+	sa := n >= 3 && g.r.Intn(4) == 0
+
+	out := make([]Socio, n)
+	for i := range out {
+		s := Socio{
+			Nome:         nomes[g.r.Intn(len(nomes))] + " " + sobrenomes[g.r.Intn(len(sobrenomes))] + " " + sobrenomes[g.r.Intn(len(sobrenomes))],
+			CPF:          GenerateCPF(g.r),
+			Qualificacao: "22 - Sócio",
+			DataEntrada:  abertura.Format(Layout),
+		}
+		switch {
+		case sa && i == 0:
+			s.Qualificacao = "16 - Presidente"
+		case sa:
+			s.Qualificacao = "10 - Diretor"
+		case i == 0:
+			s.Qualificacao = "49 - Sócio-Administrador"
+		default:
+			s.DataEntrada = g.date(abertura, g.opts.Ate).Format(Layout)
+		}
+		out[i] = s
+	}
+	return out, sa
+}
+
+func (g *Generator) endereco(u uf) Endereco {
+	return Endereco{
+		Logradouro: logradouros[g.r.Intn(len(logradouros))],
+		Numero:     strconv.Itoa(1 + g.r.Intn(3000)),
+		Bairro:     bairros[g.r.Intn(len(bairros))],
+		Municipio:  u.Capital,
+		UF:         u.Sigla,
+		CEP:        generateCEP(g.r, u),
+	}
+}
+
+// sobrenome retorna o último nome de uma pessoa
+func sobrenome(nome string) string {
+	return nome[strings.LastIndexByte(nome, ' ')+1:]
+}
@@ -0,0 +1,258 @@
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlitecnpj"
+)
+
+// TestGenerate_Deterministic tests that the same seed yields the same companies
+func TestGenerate_Deterministic(t *testing.T) {
+	opts := Options{Seed: 7, MaxFiliais: 3, MaxSocios: 3}
+	a, b := Generate(20, opts), Generate(20, opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed should generate the same companies")
+	}
+	opts.Seed = 8
+	if reflect.DeepEqual(a, Generate(20, opts)) {
+		t.Error("different seeds should generate different companies")
+	}
+}
+
+var regexCEP = regexp.MustCompile(`^\d{5}-\d{3}$`)
+
+// TestGenerate_Consistent tests that every generated record is internally consistent
+func TestGenerate_Consistent(t *testing.T) {
+	cepRange := map[string]uf{}
+	for _, u := range ufs {
+		cepRange[u.Sigla] = u
+	}
+	checkEndereco := func(who string, a Endereco, ie string) {
+		t.Helper()
+		u, ok := cepRange[a.UF]
+		if !ok || a.Municipio != u.Capital {
+			t.Errorf("%s: unknown UF or municipio %+v", who, a)
+			return
+		}
+		prefix, _ := strconv.Atoi(a.CEP[:5])
+		if !regexCEP.MatchString(a.CEP) || prefix < u.CEPMin || prefix > u.CEPMax {
+			t.Errorf("%s: CEP %s outside the %s range", who, a.CEP, a.UF)
+		}
+		if a.UF == "TO" {
+			if ie != "" {
+				t.Errorf("%s: IE %s generated in TO, which has no rule", who, ie)
+			}
+		} else if regra := regrasIE[a.UF]; !strings.HasPrefix(ie, regra.prefixo) || !validIE(a.UF, ie) {
+			t.Errorf("%s: IE %s is invalid in %s", who, ie, a.UF)
+		}
+	}
+
+	raizes := map[string]bool{}
+	limit := time.Date(2026, time.September, 30, 0, 0, 0, 0, time.UTC)
+	ate := limit.Format(Layout)
+	for _, e := range Generate(300, Options{Seed: 1, MaxFiliais: 4, MaxSocios: 4, Ate: limit}) {
+		if !cnpj.IsValid(e.CNPJ) || !cnpj.IsMatriz(e.CNPJ) {
+			t.Errorf("%s should be a valid matriz", e.CNPJ)
+		}
+		if raizes[cnpj.Raiz(e.CNPJ)] {
+			t.Errorf("raiz %s repeated", cnpj.Raiz(e.CNPJ))
+		}
+		raizes[cnpj.Raiz(e.CNPJ)] = true
+
+		alnum := strings.Trim(cnpj.Raiz(e.CNPJ), "0123456789") != ""
+		if alnum != (e.DataAbertura >= InicioAlfanumerico.Format(Layout)) || e.DataAbertura > ate {
+			t.Errorf("%s: founding date %s does not match the CNPJ format", e.CNPJ, e.DataAbertura)
+		}
+		if len(e.Socios) < 1 || len(e.Socios) > 4 || len(e.Filiais) > 4 {
+			t.Errorf("%s: %d partners and %d branches out of bounds", e.CNPJ, len(e.Socios), len(e.Filiais))
+		}
+		for _, s := range e.Socios {
+			if !IsValidCPF(s.CPF) || s.DataEntrada < e.DataAbertura {
+				t.Errorf("%s: partner %+v", e.CNPJ, s)
+			}
+		}
+		if !strings.Contains(e.RazaoSocial, sobrenome(e.Socios[0].Nome)) {
+			t.Errorf("%s: razão social %q should name the first partner", e.CNPJ, e.RazaoSocial)
+		}
+		for _, c := range e.CNAESecundarios {
+			if c.Codigo == e.CNAEPrincipal.Codigo {
+				t.Errorf("%s: secondary CNAE repeats the main one", e.CNPJ)
+			}
+		}
+		checkEndereco(e.CNPJ, e.Endereco, e.InscricaoEstadual)
+
+		for i, f := range e.Filiais {
+			if !cnpj.IsValid(f.CNPJ) || cnpj.Raiz(f.CNPJ) != cnpj.Raiz(e.CNPJ) || cnpj.Ordem(f.CNPJ) != "000"+strconv.Itoa(i+2) {
+				t.Errorf("branch %s of %s", f.CNPJ, e.CNPJ)
+			}
+			if f.DataAbertura < e.DataAbertura || f.DataAbertura > ate {
+				t.Errorf("branch %s opened on %s, before the company", f.CNPJ, f.DataAbertura)
+			}
+			checkEndereco(f.CNPJ, f.Endereco, f.InscricaoEstadual)
+		}
+	}
+
+	for _, e := range Generate(50, Options{Seed: 2, Numeric: true}) {
+		if strings.Trim(e.CNPJ, "0123456789") != "" || len(e.Filiais) != 0 || len(e.Socios) != 1 {
+			t.Errorf("numeric company without branches expected: %+v", e)
+		}
+	}
+}
+
+// TestForCNPJ tests that the company of a CNPJ depends only on its raiz and the seed
+func TestForCNPJ(t *testing.T) {
+	matriz, err := ForCNPJ("12.ABC.345/0001-88", Options{Seed: 1, MaxSocios: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matriz.CNPJ != "12ABC345000188" || len(matriz.Filiais) != 0 || matriz.DataAbertura < InicioAlfanumerico.Format(Layout) {
+		t.Errorf("matriz = %+v", matriz)
+	}
+
+	filial, err := ForCNPJ("12ABC34501DE35", Options{Seed: 1, MaxSocios: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filial.RazaoSocial != matriz.RazaoSocial || filial.CNPJ != matriz.CNPJ || len(filial.Filiais) != 1 || filial.Filiais[0].CNPJ != "12ABC34501DE35" {
+		t.Errorf("branch = %+v, want the same company with one branch", filial)
+	}
+
+	other, _ := ForCNPJ("12ABC345000188", Options{Seed: 2, MaxSocios: 3})
+	if reflect.DeepEqual(other, matriz) {
+		t.Error("different seeds should generate different companies")
+	}
+	if _, err := ForCNPJ("12ABC345000189", Options{}); !errors.Is(err, cnpj.ErroDVIncorreto) {
+		t.Errorf("invalid CNPJ: err = %v", err)
+	}
+}
+
+// TestCPF tests the CPF check digits
+func TestCPF(t *testing.T) {
+	for _, v := range []string{"52998224725", "529.982.247-25", "11144477735"} {
+		if !IsValidCPF(v) {
+			t.Errorf("IsValidCPF(%s) = false, want true", v)
+		}
+	}
+	for _, v := range []string{"52998224724", "11111111111", "5299822472", "5299822472A", ""} {
+		if IsValidCPF(v) {
+			t.Errorf("IsValidCPF(%s) = true, want false", v)
+		}
+	}
+	if got := FormatCPF("52998224725"); got != "529.982.247-25" {
+		t.Errorf("FormatCPF = %s", got)
+	}
+}
+
+// validIE recalcula os DVs da inscrição a partir dos dígitos da base
+func validIE(sigla, ie string) bool {
+	regra := regrasIE[sigla]
+	if len(ie) <= regra.digitos {
+		return false
+	}
+	base := ie[:regra.digitos]
+	if sigla == "SP" {
+		base = ie[:8] + ie[9:11]
+	}
+	return string(regra.completa([]byte(base))) == ie
+}
+
+// TestIE tests the check digits of every state against examples published by the SEFAZ
+func TestIE(t *testing.T) {
+	for _, tc := range []struct{ uf, ie string }{
+		{"AC", "0100482300112"},
+		{"AL", "240000048"},
+		{"AP", "030123459"},
+		{"BA", "12345663"},
+		{"BA", "61234557"},
+		{"CE", "060000015"},
+		{"DF", "0730000100109"},
+		{"ES", "999999990"},
+		{"GO", "109876547"},
+		{"MA", "120000385"},
+		{"MG", "0623079040081"},
+		{"MT", "00130000019"},
+		{"PA", "159999995"},
+		{"PB", "060000015"},
+		{"PE", "032141840"},
+		{"PI", "012345679"},
+		{"PR", "1234567850"},
+		{"RJ", "99999993"},
+		{"RN", "200400401"},
+		{"RO", "00000000625213"},
+		{"RR", "240066281"},
+		{"RS", "2243658792"},
+		{"SC", "251040852"},
+		{"SE", "271234563"},
+		{"SP", "110042490114"},
+	} {
+		if !validIE(tc.uf, tc.ie) {
+			t.Errorf("%s: IE %s has invalid check digits", tc.uf, tc.ie)
+		}
+	}
+	for _, u := range ufs {
+		if _, ok := regrasIE[u.Sigla]; !ok && u.Sigla != "TO" {
+			t.Errorf("no IE rule for %s", u.Sigla)
+		}
+	}
+}
+
+// TestSQLWriter tests that the generated script loads into SQLite
+func TestSQLWriter(t *testing.T) {
+	companies := Generate(30, Options{Seed: 3, MaxFiliais: 2, MaxSocios: 3})
+	companies[0].RazaoSocial = "D'Ávila Comércio Ltda"
+
+	var buf bytes.Buffer
+	w := NewSQLWriter(&buf)
+	for _, e := range companies {
+		if err := w.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sqlitecnpj.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+	if _, err := db.ExecContext(ctx, buf.String()); err != nil {
+		t.Fatalf("loading the script: %v", err)
+	}
+
+	var filiais, socios int
+	for _, e := range companies {
+		filiais += len(e.Filiais)
+		socios += len(e.Socios)
+	}
+	counts := map[string]int{"empresas": len(companies), "filiais": filiais, "socios": socios}
+	for table, want := range counts {
+		var got int
+		if err := db.QueryRowContext(ctx, "SELECT count(*) FROM "+table).Scan(&got); err != nil || got != want {
+			t.Errorf("%s: %d rows (%v), want %d", table, got, err, want)
+		}
+	}
+
+	var invalid int
+	err = db.QueryRowContext(ctx, "SELECT count(*) FROM filiais f JOIN empresas e ON e.cnpj = f.cnpj_matriz WHERE NOT cnpj_valid(f.cnpj) OR cnpj_raiz(f.cnpj) <> cnpj_raiz(e.cnpj)").Scan(&invalid)
+	if err != nil || invalid != 0 {
+		t.Errorf("%d branches with an invalid CNPJ or another raiz (%v)", invalid, err)
+	}
+
+	var name string
+	if err := db.QueryRowContext(ctx, "SELECT razao_social FROM empresas WHERE cnpj = ?", companies[0].CNPJ).Scan(&name); err != nil || name != companies[0].RazaoSocial {
+		t.Errorf("quoted name = %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,209 @@
+package fixtures
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// regraIE descreve a Inscrição Estadual de um estado conforme o Sintegra: os
+// dígitos fixos do início, a quantidade de dígitos antes dos DVs (prefixo
+// incluído) e o cálculo que completa a inscrição com os DVs
+type regraIE struct {
+	prefixo  string
+	digitos  int
+	completa func(base []byte) []byte
+}
+
+// regrasIE tem a regra de cada estado, menos TO, cuja inscrição não é gerada
+var regrasIE = map[string]regraIE{
+	"AC": {"01", 11, dvsMod11([]int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})},
+	"AL": {"240", 8, dvAL},
+	"AM": {"", 8, dvAM},
+	"AP": {"03", 8, dvAP},
+	"BA": {"", 6, dvBA},
+	"CE": {"", 8, dvsMod11(pesos(8))},
+	"DF": {"07", 11, dvsMod11([]int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})},
+	"ES": {"", 8, dvsMod11(pesos(8))},
+	"GO": {"10", 8, dvGO},
+	"MA": {"12", 8, dvsMod11(pesos(8))},
+	"MG": {"", 11, dvMG},
+	"MS": {"28", 8, dvsMod11(pesos(8))},
+	"MT": {"", 10, dvsMod11([]int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2})},
+	"PA": {"15", 8, dvsMod11(pesos(8))},
+	"PB": {"", 8, dvsMod11(pesos(8))},
+	"PE": {"", 7, dvsMod11(pesos(7), pesos(8))},
+	"PI": {"", 8, dvsMod11(pesos(8))},
+	"PR": {"", 8, dvsMod11([]int{3, 2, 7, 6, 5, 4, 3, 2}, []int{4, 3, 2, 7, 6, 5, 4, 3, 2})},
+	"RJ": {"", 7, dvsMod11([]int{2, 7, 6, 5, 4, 3, 2})},
+	"RN": {"20", 8, dvRN},
+	"RO": {"", 13, dvRO},
+	"RR": {"24", 8, dvRR},
+	"RS": {"0", 9, dvsMod11([]int{2, 9, 8, 7, 6, 5, 4, 3, 2})},
+	"SC": {"", 8, dvsMod11(pesos(8))},
+	"SE": {"", 8, dvsMod11(pesos(8))},
+	"SP": {"", 10, dvSP},
+}
+
+// generateIE gera a Inscrição Estadual do estado, sem máscara, ou "" para os
+// estados sem regra em regrasIE
+func generateIE(r *rand.Rand, sigla string) string {
+	regra, ok := regrasIE[sigla]
+	if !ok {
+		return ""
+	}
+	b := append(make([]byte, 0, 16), regra.prefixo...)
+	for len(b) < regra.digitos {
+		b = append(b, byte('0'+r.Intn(10)))
+	}
+	return string(regra.completa(b))
+}
+
+// soma multiplica cada dígito pelo peso da mesma posição
+func soma(digits []byte, weights []int) int {
+	s := 0
+	for i, d := range digits {
+		s += int(d-'0') * weights[i]
+	}
+	return s
+}
+
+// pesos retorna os pesos decrescentes de n+1 a 2 para n dígitos
+func pesos(n int) []int {
+	w := make([]int, n)
+	for i := range w {
+		w[i] = n + 1 - i
+	}
+	return w
+}
+
+// dvsMod11 acrescenta um DV para cada lista de pesos: 11 menos o resto da soma
+// por 11, com resto 0 ou 1 resultando em 0. Cada DV entra na soma do seguinte.
+func dvsMod11(weights ...[]int) func([]byte) []byte {
+	return func(b []byte) []byte {
+		for _, w := range weights {
+			if r := soma(b, w) % 11; r >= 2 {
+				b = append(b, byte('0'+11-r))
+			} else {
+				b = append(b, '0')
+			}
+		}
+		return b
+	}
+}
+
+// dvVezes10 é o DV de AL e RN: a soma vezes 10, módulo 11, com 10 resultando em 0
+func dvVezes10(b []byte) []byte {
+	return append(b, byte('0'+soma(b, pesos(len(b)))*10%11%10))
+}
+
+func dvAL(b []byte) []byte { return dvVezes10(b) }
+func dvRN(b []byte) []byte { return dvVezes10(b) }
+
+// dvAM usa 11 menos a soma quando ela é menor que 11
+func dvAM(b []byte) []byte {
+	s, d := soma(b, pesos(8)), 0
+	switch r := s % 11; {
+	case s < 11:
+		d = 11 - s
+	case r >= 2:
+		d = 11 - r
+	}
+	if d >= 10 {
+		d = 0
+	}
+	return append(b, byte('0'+d))
+}
+
+// dvAP soma uma constante que depende da faixa da inscrição e, quando o DV
+// seria 11, usa o valor da faixa
+func dvAP(b []byte) []byte {
+	n, _ := strconv.Atoi(string(b))
+	p, dz := 0, 0
+	switch {
+	case n >= 3000001 && n <= 3017000:
+		p = 5
+	case n >= 3017001 && n <= 3019022:
+		p, dz = 9, 1
+	}
+	d := 11 - (p+soma(b, pesos(8)))%11
+	switch d {
+	case 10:
+		d = 0
+	case 11:
+		d = dz
+	}
+	return append(b, byte('0'+d))
+}
+
+// dvBA calcula primeiro o último DV e depois o penúltimo, que o inclui na soma.
+// Inscrições que começam com 6, 7 ou 9 usam módulo 11; as demais, módulo 10.
+func dvBA(b []byte) []byte {
+	mod := 10
+	if strings.IndexByte("679", b[0]) >= 0 {
+		mod = 11
+	}
+	dv := func(digits []byte, weights []int) byte {
+		r := soma(digits, weights) % mod
+		if r == 0 || mod == 11 && r == 1 {
+			return '0'
+		}
+		return byte('0' + mod - r)
+	}
+	segundo := dv(b, []int{7, 6, 5, 4, 3, 2})
+	primeiro := dv(append(b[:6:6], segundo), []int{8, 7, 6, 5, 4, 3, 2})
+	return append(b, primeiro, segundo)
+}
+
+// dvGO é o módulo 11, mas o resto 1 resulta em 1 na faixa de 10103105 a 10119997
+func dvGO(b []byte) []byte {
+	d := 0
+	switch r := soma(b, pesos(8)) % 11; r {
+	case 0:
+	case 1:
+		if n, _ := strconv.Atoi(string(b)); n >= 10103105 && n <= 10119997 {
+			d = 1
+		}
+	default:
+		d = 11 - r
+	}
+	return append(b, byte('0'+d))
+}
+
+// dvMG calcula o primeiro DV com um 0 depois do código do município, pesos 1 e
+// 2 alternados e a soma dos algarismos dos produtos, e o segundo com módulo 11
+func dvMG(b []byte) []byte {
+	s := 0
+	for i, c := range append([]byte{b[0], b[1], b[2], '0'}, b[3:]...) {
+		p := int(c-'0') * (1 + i%2)
+		s += p/10 + p%10
+	}
+	b = append(b, byte('0'+(10-s%10)%10))
+	return dvsMod11([]int{3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2})(b)
+}
+
+// dvRO usa 11 menos o resto e, quando o resultado tem dois algarismos, subtrai 10
+func dvRO(b []byte) []byte {
+	d := 11 - soma(b, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})%11
+	if d >= 10 {
+		d -= 10
+	}
+	return append(b, byte('0'+d))
+}
+
+// dvRR é a soma com pesos de 1 a 8, módulo 9
+func dvRR(b []byte) []byte {
+	return append(b, byte('0'+soma(b, []int{1, 2, 3, 4, 5, 6, 7, 8})%9))
+}
+
+// dvSP insere o primeiro DV na 9ª posição e acrescenta o segundo no fim
+func dvSP(b []byte) []byte {
+	ie := append(append([]byte{}, b[:8]...), ieDV(b[:8], []int{1, 3, 4, 5, 6, 7, 8, 10}))
+	ie = append(ie, b[8:10]...)
+	return append(ie, ieDV(ie, []int{3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2}))
+}
+
+// ieDV é o DV paulista: a soma ponderada módulo 11, da qual se usa o último algarismo
+func ieDV(digits []byte, weights []int) byte {
+	return byte('0' + soma(digits, weights)%11%10)
+}
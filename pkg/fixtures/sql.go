@@ -0,0 +1,116 @@
+package fixtures
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// schema cria as tabelas preenchidas por SQLWriter. Os tipos e a sintaxe servem
+// tanto ao PostgreSQL quanto ao SQLite.
+const schema = `CREATE TABLE IF NOT EXISTS empresas (
+  cnpj VARCHAR(14) PRIMARY KEY,
+  razao_social VARCHAR(150) NOT NULL,
+  nome_fantasia VARCHAR(150),
+  natureza_juridica VARCHAR(80),
+  data_abertura DATE NOT NULL,
+  cnae_principal VARCHAR(9) NOT NULL,
+  inscricao_estadual VARCHAR(14),
+  logradouro VARCHAR(150),
+  numero VARCHAR(10),
+  bairro VARCHAR(80),
+  municipio VARCHAR(80),
+  uf CHAR(2),
+  cep CHAR(9)
+);
+CREATE TABLE IF NOT EXISTS filiais (
+  cnpj VARCHAR(14) PRIMARY KEY,
+  cnpj_matriz VARCHAR(14) NOT NULL REFERENCES empresas (cnpj),
+  nome_fantasia VARCHAR(150),
+  data_abertura DATE NOT NULL,
+  inscricao_estadual VARCHAR(14),
+  logradouro VARCHAR(150),
+  numero VARCHAR(10),
+  bairro VARCHAR(80),
+  municipio VARCHAR(80),
+  uf CHAR(2),
+  cep CHAR(9)
+);
+CREATE TABLE IF NOT EXISTS cnaes_secundarios (
+  cnpj VARCHAR(14) NOT NULL REFERENCES empresas (cnpj),
+  cnae VARCHAR(9) NOT NULL,
+  PRIMARY KEY (cnpj, cnae)
+);
+CREATE TABLE IF NOT EXISTS socios (
+  cnpj VARCHAR(14) NOT NULL REFERENCES empresas (cnpj),
+  cpf CHAR(11) NOT NULL,
+  nome VARCHAR(150) NOT NULL,
+  qualificacao VARCHAR(60),
+  data_entrada DATE,
+  PRIMARY KEY (cnpj, cpf)
+);
+`
+
+// endereco são as colunas de endereço, comuns a empresas e filiais
+const endereco = "logradouro, numero, bairro, municipio, uf, cep"
+
+// SQLWriter escreve as empresas como INSERTs nas tabelas empresas, filiais,
+// cnaes_secundarios e socios, dentro de uma transação e precedidos do CREATE TABLE
+type SQLWriter struct {
+	out   *bufio.Writer
+	count int
+}
+
+// NewSQLWriter cria o writer sobre w; nada é escrito até o primeiro Write
+func NewSQLWriter(w io.Writer) *SQLWriter {
+	return &SQLWriter{out: bufio.NewWriter(w)}
+}
+
+// Write escreve os INSERTs de uma empresa
+func (s *SQLWriter) Write(e Empresa) error {
+	if s.count == 0 {
+		if _, err := s.out.WriteString(schema + "BEGIN;\n"); err != nil {
+			return err
+		}
+	}
+	s.count++
+
+	a := e.Endereco
+	s.insert("empresas (cnpj, razao_social, nome_fantasia, natureza_juridica, data_abertura, cnae_principal, inscricao_estadual, "+endereco+")",
+		e.CNPJ, e.RazaoSocial, e.NomeFantasia, e.NaturezaJuridica, e.DataAbertura, e.CNAEPrincipal.Codigo, e.InscricaoEstadual,
+		a.Logradouro, a.Numero, a.Bairro, a.Municipio, a.UF, a.CEP)
+	for _, c := range e.CNAESecundarios {
+		s.insert("cnaes_secundarios (cnpj, cnae)", e.CNPJ, c.Codigo)
+	}
+	for _, p := range e.Socios {
+		s.insert("socios (cnpj, cpf, nome, qualificacao, data_entrada)", e.CNPJ, p.CPF, p.Nome, p.Qualificacao, p.DataEntrada)
+	}
+	for _, f := range e.Filiais {
+		a := f.Endereco
+		s.insert("filiais (cnpj, cnpj_matriz, nome_fantasia, data_abertura, inscricao_estadual, "+endereco+")",
+			f.CNPJ, e.CNPJ, f.NomeFantasia, f.DataAbertura, f.InscricaoEstadual,
+			a.Logradouro, a.Numero, a.Bairro, a.Municipio, a.UF, a.CEP)
+	}
+	return nil
+}
+
+// insert escreve um INSERT na tabela, cujas colunas acompanham o nome; erros de
+// escrita ficam no bufio.Writer e aparecem no Flush de Close
+func (s *SQLWriter) insert(table string, values ...string) {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	_, _ = fmt.Fprintf(s.out, "INSERT INTO %s VALUES (%s);\n", table, strings.Join(quoted, ", "))
+}
+
+// Close encerra a transação e descarrega o buffer
+func (s *SQLWriter) Close() error {
+	if s.count > 0 {
+		if _, err := s.out.WriteString("COMMIT;\n"); err != nil {
+			return err
+		}
+	}
+	return s.out.Flush()
+}
@@ -0,0 +1,131 @@
+// Package i18n contém o catálogo de mensagens da CLI e da API em pt-BR, en e es.
+//
+// Erros com código estável (qualquer erro que implemente ErrorCode() string)
+// são traduzidos pelo código, de modo que o código permanece o mesmo em
+// qualquer idioma e apenas a mensagem muda.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang identifica um idioma suportado pelo catálogo
+type Lang string
+
+const (
+	PtBR Lang = "pt-BR"
+	En   Lang = "en"
+	Es   Lang = "es"
+
+	// Default é o idioma usado quando nenhum outro é informado ou reconhecido
+	Default = PtBR
+)
+
+// Supported lista os idiomas do catálogo
+var Supported = []Lang{PtBR, En, Es}
+
+// Parse reconhece tags como "pt_BR.UTF-8", "en-US" ou "es" e retorna o idioma
+// suportado correspondente
+func Parse(tag string) (Lang, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ReplaceAll(tag, "_", "-")
+	primary, _, _ := strings.Cut(tag, "-")
+
+	switch primary {
+	case "pt":
+		return PtBR, true
+	case "en":
+		return En, true
+	case "es":
+		return Es, true
+	}
+	return "", false
+}
+
+// Detect retorna o primeiro idioma reconhecido entre os valores informados,
+// na ordem de prioridade (por exemplo: flag --lang, LC_ALL, LANG)
+func Detect(values ...string) Lang {
+	for _, v := range values {
+		if lang, ok := Parse(v); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+// FromAcceptLanguage escolhe o idioma a partir de um cabeçalho Accept-Language,
+// respeitando os pesos q
+func FromAcceptLanguage(header string) Lang {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if tag != "" && q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if lang, ok := Parse(c.tag); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+// T retorna a mensagem da chave no idioma pedido, formatada com args.
+// Na falta de tradução usa pt-BR e, na falta da chave, a própria chave.
+func T(lang Lang, key string, args ...any) string {
+	format := key
+	if msgs, ok := catalog[key]; ok {
+		if m, ok := msgs[lang]; ok {
+			format = m
+		} else {
+			format = msgs[Default]
+		}
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Code retorna o código estável de err, ou vazio se err não tiver código
+func Code(err error) string {
+	var coded interface{ ErrorCode() string }
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ""
+}
+
+// Error traduz err pelo seu código estável. Erros sem código, ou com código
+// fora do catálogo, mantêm a mensagem original.
+func Error(lang Lang, err error) string {
+	if code := Code(err); code != "" {
+		if _, ok := catalog[code]; ok {
+			return T(lang, code)
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Lang
+		ok       bool
+	}{
+		{"pt_BR.UTF-8", PtBR, true},
+		{"pt-PT", PtBR, true},
+		{"en_US.UTF-8", En, true},
+		{"EN", En, true},
+		{"es-AR", Es, true},
+		{"es_ES@euro", Es, true},
+		{"C.UTF-8", "", false},
+		{"fr", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		lang, ok := Parse(tt.input)
+		if lang != tt.expected || ok != tt.ok {
+			t.Errorf("Parse(%q) = %q, %v, expected %q, %v", tt.input, lang, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	if got := Detect("", "C", "es_ES.UTF-8"); got != Es {
+		t.Errorf("Detect = %s, expected es", got)
+	}
+	if got := Detect("en", "es_ES.UTF-8"); got != En {
+		t.Errorf("Detect should honor priority, got %s", got)
+	}
+	if got := Detect(); got != Default {
+		t.Errorf("Detect() = %s, expected default", got)
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := map[string]Lang{
+		"es-MX,es;q=0.9,en;q=0.8":   Es,
+		"fr-FR, en;q=0.5, es;q=0.7": Es,
+		"de, fr":                    Default,
+		"":                          Default,
+		"en;q=0, es;q=0.1":          Es,
+	}
+
+	for header, expected := range tests {
+		if got := FromAcceptLanguage(header); got != expected {
+			t.Errorf("FromAcceptLanguage(%q) = %s, expected %s", header, got, expected)
+		}
+	}
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for key, msgs := range catalog {
+		for _, lang := range Supported {
+			if msgs[lang] == "" {
+				t.Errorf("message %q has no %s translation", key, lang)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(En, "validate.valido", 1, "X"); got != "[1] ✅  valid CNPJ:   X" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	if got := T(En, "chave.desconhecida"); got != "chave.desconhecida" {
+		t.Errorf("unknown key should be returned as is, got %q", got)
+	}
+}
+
+type codedError string
+
+func (e codedError) Error() string     { return "mensagem original" }
+func (e codedError) ErrorCode() string { return string(e) }
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("contexto: %w", codedError("CNPJ_DV_INVALIDO"))
+
+	if Code(err) != "CNPJ_DV_INVALIDO" {
+		t.Errorf("Code = %q", Code(err))
+	}
+	if got := Error(Es, err); got != catalog["CNPJ_DV_INVALIDO"][Es] {
+		t.Errorf("Error(es) = %q", got)
+	}
+	if got := Error(En, errors.New("sem código")); got != "sem código" {
+		t.Errorf("Error without code = %q", got)
+	}
+	if got := Error(En, codedError("DESCONHECIDO")); got != "mensagem original" {
+		t.Errorf("Error with unknown code = %q", got)
+	}
+}
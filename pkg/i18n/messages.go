@@ -0,0 +1,997 @@
+package i18n
+
+// catalog mapeia cada chave de mensagem para suas traduções. Chaves em
+// maiúsculas são códigos de erro estáveis; as demais são textos da CLI.
+var catalog = map[string]map[Lang]string{
+	// Erros da biblioteca
+	"CNPJ_DV_INVALIDO": {
+		PtBR: "não é possível calcular o DV pois o CNPJ fornecido é inválido",
+		En:   "cannot calculate the check digits because the given CNPJ is invalid",
+		Es:   "no es posible calcular el DV porque el CNPJ informado es inválido",
+	},
+	"CNPJ_VAZIO": {
+		PtBR: "o CNPJ não foi informado",
+		En:   "the CNPJ is empty",
+		Es:   "el CNPJ no fue informado",
+	},
+	"CNPJ_CARACTERE_INVALIDO": {
+		PtBR: "o CNPJ contém caracteres não permitidos",
+		En:   "the CNPJ contains characters that are not allowed",
+		Es:   "el CNPJ contiene caracteres no permitidos",
+	},
+	"CNPJ_TAMANHO_INVALIDO": {
+		PtBR: "o CNPJ deve ter 14 caracteres, sem contar a máscara",
+		En:   "the CNPJ must have 14 characters, not counting the mask",
+		Es:   "el CNPJ debe tener 14 caracteres, sin contar la máscara",
+	},
+	"CNPJ_DV_NAO_NUMERICO": {
+		PtBR: "os dígitos verificadores devem ser numéricos",
+		En:   "the check digits must be numeric",
+		Es:   "los dígitos verificadores deben ser numéricos",
+	},
+	"CNPJ_ZERADO": {
+		PtBR: "o CNPJ não pode ser zerado",
+		En:   "the CNPJ cannot be all zeroes",
+		Es:   "el CNPJ no puede ser todo ceros",
+	},
+	"CNPJ_DV_INCORRETO": {
+		PtBR: "os dígitos verificadores não conferem",
+		En:   "the check digits do not match",
+		Es:   "los dígitos verificadores no coinciden",
+	},
+
+	"CHAVE_TAMANHO_INVALIDO": {
+		PtBR: "a chave de acesso deve ter 44 caracteres, sem contar os espaços",
+		En:   "the access key must have 44 characters, not counting spaces",
+		Es:   "la clave de acceso debe tener 44 caracteres, sin contar los espacios",
+	},
+	"CHAVE_CARACTERE_INVALIDO": {
+		PtBR: "a chave de acesso só aceita letras nas 12 primeiras posições do CNPJ",
+		En:   "the access key only accepts letters in the first 12 positions of the CNPJ",
+		Es:   "la clave de acceso solo acepta letras en las 12 primeras posiciones del CNPJ",
+	},
+	"CHAVE_DV_INCORRETO": {
+		PtBR: "o dígito verificador da chave de acesso não confere",
+		En:   "the access key check digit does not match",
+		Es:   "el dígito verificador de la clave de acceso no coincide",
+	},
+	"CHAVE_UF_INVALIDA": {
+		PtBR: "o código de UF da chave de acesso não existe",
+		En:   "the access key state (UF) code does not exist",
+		Es:   "el código de UF de la clave de acceso no existe",
+	},
+	"CHAVE_MES_INVALIDO": {
+		PtBR: "o mês de emissão da chave de acesso é inválido",
+		En:   "the access key issue month is invalid",
+		Es:   "el mes de emisión de la clave de acceso es inválido",
+	},
+	"CHAVE_CNPJ_INVALIDO": {
+		PtBR: "o CNPJ do emitente é inválido",
+		En:   "the issuer CNPJ is invalid",
+		Es:   "el CNPJ del emisor es inválido",
+	},
+	"CHAVE_MODELO_INVALIDO": {
+		PtBR: "o modelo de documento da chave de acesso é desconhecido",
+		En:   "the access key document model is unknown",
+		Es:   "el modelo de documento de la clave de acceso es desconocido",
+	},
+	"CHAVE_TIPO_EMISSAO_INVALIDO": {
+		PtBR: "o tipo de emissão da chave de acesso é desconhecido",
+		En:   "the access key emission type is unknown",
+		Es:   "el tipo de emisión de la clave de acceso es desconocido",
+	},
+	"CHAVE_ANO_INVALIDO": {
+		PtBR: "o ano de emissão da chave de acesso deve estar entre 2000 e 2099",
+		En:   "the access key issue year must be between 2000 and 2099",
+		Es:   "el año de emisión de la clave de acceso debe estar entre 2000 y 2099",
+	},
+	"CHAVE_SERIE_INVALIDA": {
+		PtBR: "a série da chave de acesso deve estar entre 0 e 999",
+		En:   "the access key series must be between 0 and 999",
+		Es:   "la serie de la clave de acceso debe estar entre 0 y 999",
+	},
+	"CHAVE_NUMERO_INVALIDO": {
+		PtBR: "o número do documento deve estar entre 1 e 999.999.999",
+		En:   "the document number must be between 1 and 999,999,999",
+		Es:   "el número del documento debe estar entre 1 y 999.999.999",
+	},
+	"SQL_DIALETO_DESCONHECIDO": {
+		PtBR: "dialeto SQL desconhecido (use postgres ou sqlite)",
+		En:   "unknown SQL dialect (use postgres or sqlite)",
+		Es:   "dialecto SQL desconocido (use postgres o sqlite)",
+	},
+	"SQL_IDENTIFICADOR_INVALIDO": {
+		PtBR: "nome de tabela ou coluna inválido",
+		En:   "invalid table or column name",
+		Es:   "nombre de tabla o columna inválido",
+	},
+
+	// Erros da API
+	"REQUEST_INVALIDO": {
+		PtBR: "request inválido",
+		En:   "invalid request",
+		Es:   "solicitud inválida",
+	},
+	"JSON_INVALIDO": {
+		PtBR: "JSON inválido",
+		En:   "invalid JSON",
+		Es:   "JSON inválido",
+	},
+	"BANCO_CONSULTA": {
+		PtBR: "erro ao consultar o banco",
+		En:   "error querying the database",
+		Es:   "error al consultar la base de datos",
+	},
+	"BANCO_GRAVACAO": {
+		PtBR: "erro ao salvar no banco",
+		En:   "error saving to the database",
+		Es:   "error al guardar en la base de datos",
+	},
+	"CNPJ_NAO_ENCONTRADO": {
+		PtBR: "CNPJ não encontrado nos dados importados da Receita Federal",
+		En:   "CNPJ not found in the imported Receita Federal data",
+		Es:   "CNPJ no encontrado en los datos importados de la Receita Federal",
+	},
+	"CNPJ_UNICO_ESGOTADO": {
+		PtBR: "não foi possível gerar um CNPJ único após várias tentativas",
+		En:   "could not generate a unique CNPJ after several attempts",
+		Es:   "no fue posible generar un CNPJ único después de varios intentos",
+	},
+
+	// Mensagens comuns da CLI
+	"erro": {
+		PtBR: "Erro: %v",
+		En:   "Error: %v",
+		Es:   "Error: %v",
+	},
+	"cnpj.invalido": {
+		PtBR: "CNPJ inválido",
+		En:   "invalid CNPJ",
+		Es:   "CNPJ inválido",
+	},
+	"flag.count": {
+		PtBR: "--count deve ser maior que zero",
+		En:   "--count must be greater than zero",
+		Es:   "--count debe ser mayor que cero",
+	},
+	"output.template": {
+		PtBR: "template inválido: %v",
+		En:   "invalid template: %v",
+		Es:   "plantilla inválida: %v",
+	},
+	"output.formato": {
+		PtBR: "formato de saída desconhecido %q (use %s)",
+		En:   "unknown output format %q (use %s)",
+		Es:   "formato de salida desconocido %q (use %s)",
+	},
+	"flag.in_place": {
+		PtBR: "--in-place exige um arquivo",
+		En:   "--in-place requires a file",
+		Es:   "--in-place requiere un archivo",
+	},
+
+	// validate
+	"validate.nenhum": {
+		PtBR: "⚠️  Nenhum CNPJ foi informado. Por favor, passe pelo menos um argumento para validação.",
+		En:   "⚠️  No CNPJ was given. Please pass at least one argument to validate.",
+		Es:   "⚠️  No se informó ningún CNPJ. Por favor, pase al menos un argumento para validar.",
+	},
+	"validate.valido": {
+		PtBR: "[%d] ✅  CNPJ válido:   %s",
+		En:   "[%d] ✅  valid CNPJ:   %s",
+		Es:   "[%d] ✅  CNPJ válido:   %s",
+	},
+	"validate.invalido": {
+		PtBR: "[%d] ❌  CNPJ inválido: %s",
+		En:   "[%d] ❌  invalid CNPJ: %s",
+		Es:   "[%d] ❌  CNPJ inválido: %s",
+	},
+
+	// format
+	"format.nenhum": {
+		PtBR: "⚠️  Nenhum CNPJ foi informado. Informe pelo menos um valor para formatar.",
+		En:   "⚠️  No CNPJ was given. Provide at least one value to format.",
+		Es:   "⚠️  No se informó ningún CNPJ. Informe al menos un valor para formatear.",
+	},
+	"format.resultado": {
+		PtBR: "[%d] 🧾 Original:  %s\n    📎 Formatado: %s",
+		En:   "[%d] 🧾 Original:  %s\n    📎 Formatted: %s",
+		Es:   "[%d] 🧾 Original:  %s\n    📎 Formateado: %s",
+	},
+
+	// generate
+	"generate.gerado": {
+		PtBR: "✅  CNPJ Gerado: %s",
+		En:   "✅  Generated CNPJ: %s",
+		Es:   "✅  CNPJ Generado: %s",
+	},
+	"generate.formatado": {
+		PtBR: "📎 CNPJ Formatado: %s",
+		En:   "📎 Formatted CNPJ: %s",
+		Es:   "📎 CNPJ Formateado: %s",
+	},
+	"generate.valido": {
+		PtBR: "🔍 Validação: CNPJ gerado é válido ✅ ",
+		En:   "🔍 Validation: generated CNPJ is valid ✅ ",
+		Es:   "🔍 Validación: el CNPJ generado es válido ✅ ",
+	},
+	"generate.invalido": {
+		PtBR: "🔍 Validação: CNPJ gerado é inválido ❌ ",
+		En:   "🔍 Validation: generated CNPJ is invalid ❌ ",
+		Es:   "🔍 Validación: el CNPJ generado es inválido ❌ ",
+	},
+	"generate.shard_out": {
+		PtBR: "--shard-size requer --out",
+		En:   "--shard-size requires --out",
+		Es:   "--shard-size requiere --out",
+	},
+	"generate.unico": {
+		PtBR: "não foi possível gerar um CNPJ único após %d tentativas (%d gerados)",
+		En:   "could not generate a unique CNPJ after %d attempts (%d generated)",
+		Es:   "no fue posible generar un CNPJ único después de %d intentos (%d generados)",
+	},
+	"generate.identificador": {
+		PtBR: "nome de tabela ou coluna inválido: %q, %q",
+		En:   "invalid table or column name: %q, %q",
+		Es:   "nombre de tabla o columna inválido: %q, %q",
+	},
+	"generate.sql": {
+		PtBR: "modo SQL desconhecido %q (use insert ou copy)",
+		En:   "unknown SQL mode %q (use insert or copy)",
+		Es:   "modo SQL desconocido %q (use insert o copy)",
+	},
+
+	// csv
+	"csv.resumo": {
+		PtBR: "📊 Linhas: %d | ✅ válidos: %d | ❌ inválidos: %d | 🔧 corrigíveis: %d",
+		En:   "📊 Rows: %d | ✅ valid: %d | ❌ invalid: %d | 🔧 fixable: %d",
+		Es:   "📊 Filas: %d | ✅ válidos: %d | ❌ inválidos: %d | 🔧 corregibles: %d",
+	},
+	"csv.deteccao": {
+		PtBR: "🔎 Coluna: %s | delimitador: %s | codificação: %s",
+		En:   "🔎 Column: %s | delimiter: %s | encoding: %s",
+		Es:   "🔎 Columna: %s | delimitador: %s | codificación: %s",
+	},
+	"csv.motivo": {
+		PtBR: "   %d × %s",
+		En:   "   %d × %s",
+		Es:   "   %d × %s",
+	},
+	"csv.in_place_out": {
+		PtBR: "--in-place e --out não podem ser usados juntos",
+		En:   "--in-place and --out cannot be used together",
+		Es:   "--in-place y --out no se pueden usar juntos",
+	},
+	"csv.codificacao": {
+		PtBR: "codificação desconhecida: %s (use auto, utf-8 ou latin1)",
+		En:   "unknown encoding: %s (use auto, utf-8 or latin1)",
+		Es:   "codificación desconocida: %s (use auto, utf-8 o latin1)",
+	},
+	"csv.delimitador": {
+		PtBR: "delimitador inválido: %q",
+		En:   "invalid delimiter: %q",
+		Es:   "delimitador inválido: %q",
+	},
+
+	// json
+	"json.linha": {
+		PtBR: "linha %d %s",
+		En:   "line %d %s",
+		Es:   "línea %d %s",
+	},
+	"json.valido": {
+		PtBR: "✅ %s: %s",
+		En:   "✅ %s: %s",
+		Es:   "✅ %s: %s",
+	},
+	"json.invalido": {
+		PtBR: "❌ %s: %q → %s",
+		En:   "❌ %s: %q → %s",
+		Es:   "❌ %s: %q → %s",
+	},
+	"json.path": {
+		PtBR: "informe ao menos um seletor com --path",
+		En:   "give at least one selector with --path",
+		Es:   "informe al menos un selector con --path",
+	},
+	"json.rewrite": {
+		PtBR: "modo de reescrita desconhecido: %s (use normalize ou format)",
+		En:   "unknown rewrite mode: %s (use normalize or format)",
+		Es:   "modo de reescritura desconocido: %s (use normalize o format)",
+	},
+	"json.rewrite_out": {
+		PtBR: "--out e --in-place exigem --rewrite",
+		En:   "--out and --in-place require --rewrite",
+		Es:   "--out y --in-place requieren --rewrite",
+	},
+
+	// scan
+	"scan.valido": {
+		PtBR: "%s:%d:%d: ❌ CNPJ válido, possivelmente um dado real: %s",
+		En:   "%s:%d:%d: ❌ valid CNPJ, possibly real data: %s",
+		Es:   "%s:%d:%d: ❌ CNPJ válido, posiblemente un dato real: %s",
+	},
+	"scan.invalido": {
+		PtBR: "%s:%d:%d: ⚠️  valor com máscara de CNPJ e DV incorreto: %s",
+		En:   "%s:%d:%d: ⚠️  value with CNPJ mask and wrong check digits: %s",
+		Es:   "%s:%d:%d: ⚠️  valor con máscara de CNPJ y DV incorrecto: %s",
+	},
+	"scan.nenhum": {
+		PtBR: "✅ Nenhum CNPJ encontrado.",
+		En:   "✅ No CNPJ found.",
+		Es:   "✅ No se encontró ningún CNPJ.",
+	},
+	"scan.baseline": {
+		PtBR: "📌 Baseline com %d achado(s) gravado em %s",
+		En:   "📌 Baseline with %d finding(s) written to %s",
+		Es:   "📌 Baseline con %d hallazgo(s) guardado en %s",
+	},
+	"scan.hook": {
+		PtBR: "🪝 Hook de pre-commit instalado em %s",
+		En:   "🪝 Pre-commit hook installed at %s",
+		Es:   "🪝 Hook de pre-commit instalado en %s",
+	},
+	"scan.sem_git": {
+		PtBR: "não é um repositório git: %v",
+		En:   "not a git repository: %v",
+		Es:   "no es un repositorio git: %v",
+	},
+	"scan.hook_existente": {
+		PtBR: "já existe um hook de pre-commit em %s; acrescente '%s scan --staged' a ele",
+		En:   "a pre-commit hook already exists at %s; add '%s scan --staged' to it",
+		Es:   "ya existe un hook de pre-commit en %s; agregue '%s scan --staged' a él",
+	},
+
+	// redact
+	"redact.contagem": {
+		PtBR: "🔒 %s: %d CNPJ(s) mascarado(s)",
+		En:   "🔒 %s: %d CNPJ(s) redacted",
+		Es:   "🔒 %s: %d CNPJ(s) enmascarado(s)",
+	},
+
+	// interactive
+	"interactive.bemvindo": {
+		PtBR: "🧮 Modo interativo: digite um CNPJ para validar ou :help para ver os comandos (:q encerra).",
+		En:   "🧮 Interactive mode: type a CNPJ to validate it or :help to list the commands (:q quits).",
+		Es:   "🧮 Modo interactivo: escriba un CNPJ para validarlo o :help para ver los comandos (:q sale).",
+	},
+	"interactive.ajuda": {
+		PtBR: "Comandos:\n  :gen [n] [num]         gera n CNPJs válidos (num: apenas dígitos)\n  :explain <cnpj>        mostra o cálculo do DV passo a passo\n  :branch <cnpj> [ordem] mostra raiz e ordem, ou o CNPJ de outro estabelecimento\n  :history               lista o que foi digitado na sessão\n  :q                     encerra",
+		En:   "Commands:\n  :gen [n] [num]         generates n valid CNPJs (num: digits only)\n  :explain <cnpj>        shows the check digit calculation step by step\n  :branch <cnpj> [ordem] shows raiz and ordem, or the CNPJ of another branch\n  :history               lists what was typed in this session\n  :q                     quits",
+		Es:   "Comandos:\n  :gen [n] [num]         genera n CNPJs válidos (num: solo dígitos)\n  :explain <cnpj>        muestra el cálculo del DV paso a paso\n  :branch <cnpj> [ordem] muestra raíz y orden, o el CNPJ de otro establecimiento\n  :history               lista lo escrito en la sesión\n  :q                     sale",
+	},
+	"interactive.parcial": {
+		PtBR: "⌨️  %s (faltam %d caracteres)",
+		En:   "⌨️  %s (%d characters missing)",
+		Es:   "⌨️  %s (faltan %d caracteres)",
+	},
+	"interactive.dv": {
+		PtBR: "🔢 DV esperado: %s → %s",
+		En:   "🔢 Expected check digits: %s → %s",
+		Es:   "🔢 DV esperado: %s → %s",
+	},
+	"interactive.valido": {
+		PtBR: "✅ %s válido (%s)",
+		En:   "✅ %s valid (%s)",
+		Es:   "✅ %s válido (%s)",
+	},
+	"interactive.invalido": {
+		PtBR: "❌ %s: %s",
+		En:   "❌ %s: %s",
+		Es:   "❌ %s: %s",
+	},
+	"interactive.dvesperado": {
+		PtBR: "🔢 DV informado %s, esperado %s",
+		En:   "🔢 Check digits given %s, expected %s",
+		Es:   "🔢 DV informado %s, esperado %s",
+	},
+	"interactive.sugestoes": {
+		PtBR: "💡 Você quis dizer: %s",
+		En:   "💡 Did you mean: %s",
+		Es:   "💡 Quiso decir: %s",
+	},
+	"interactive.matriz": {
+		PtBR: "matriz",
+		En:   "head office",
+		Es:   "matriz",
+	},
+	"interactive.filial": {
+		PtBR: "filial %s",
+		En:   "branch %s",
+		Es:   "sucursal %s",
+	},
+	"interactive.comando": {
+		PtBR: "❓ Comando desconhecido: %s (use :help)",
+		En:   "❓ Unknown command: %s (use :help)",
+		Es:   "❓ Comando desconocido: %s (use :help)",
+	},
+	"interactive.uso": {
+		PtBR: "ℹ️  Uso: %s",
+		En:   "ℹ️  Usage: %s",
+		Es:   "ℹ️  Uso: %s",
+	},
+	"interactive.explain.titulo": {
+		PtBR: "🔢 DV%d: caractere, valor (ASCII − 48) × peso",
+		En:   "🔢 DV%d: character, value (ASCII − 48) × weight",
+		Es:   "🔢 DV%d: carácter, valor (ASCII − 48) × peso",
+	},
+	"interactive.explain.resultado": {
+		PtBR: "   soma %d, resto da divisão por 11 = %d → DV%d = %d",
+		En:   "   sum %d, remainder of division by 11 = %d → DV%d = %d",
+		Es:   "   suma %d, resto de la división por 11 = %d → DV%d = %d",
+	},
+	"interactive.branch": {
+		PtBR: "🏢 Raiz %s, ordem %s (%s)",
+		En:   "🏢 Raiz %s, ordem %s (%s)",
+		Es:   "🏢 Raíz %s, orden %s (%s)",
+	},
+	"interactive.branch.novo": {
+		PtBR: "🏬 Estabelecimento %s: %s",
+		En:   "🏬 Establishment %s: %s",
+		Es:   "🏬 Establecimiento %s: %s",
+	},
+
+	// lists
+	"lists.matriz": {
+		PtBR: "(matriz)",
+		En:   "(head office)",
+		Es:   "(matriz)",
+	},
+	"lists.quase": {
+		PtBR: "🔁 %s %s ↔ %s %s",
+		En:   "🔁 %s %s ↔ %s %s",
+		Es:   "🔁 %s %s ↔ %s %s",
+	},
+
+	// stats
+	"stats.total": {
+		PtBR: "📊 Total: %d | distintos: %d | duplicados: %d (%.1f%%)",
+		En:   "📊 Total: %d | distinct: %d | duplicates: %d (%.1f%%)",
+		Es:   "📊 Total: %d | distintos: %d | duplicados: %d (%.1f%%)",
+	},
+	"stats.validade": {
+		PtBR: "✅ Válidos: %d (%.1f%%) | ❌ inválidos: %d (%.1f%%)",
+		En:   "✅ Valid: %d (%.1f%%) | ❌ invalid: %d (%.1f%%)",
+		Es:   "✅ Válidos: %d (%.1f%%) | ❌ inválidos: %d (%.1f%%)",
+	},
+	"stats.tipo": {
+		PtBR: "🔢 Numéricos (legado): %d (%.1f%%) | 🔤 alfanuméricos: %d (%.1f%%)",
+		En:   "🔢 Numeric (legacy): %d (%.1f%%) | 🔤 alphanumeric: %d (%.1f%%)",
+		Es:   "🔢 Numéricos (legado): %d (%.1f%%) | 🔤 alfanuméricos: %d (%.1f%%)",
+	},
+	"stats.mascara": {
+		PtBR: "🎭 Com máscara: %d (%.1f%%) | sem máscara: %d (%.1f%%)",
+		En:   "🎭 Masked: %d (%.1f%%) | unmasked: %d (%.1f%%)",
+		Es:   "🎭 Con máscara: %d (%.1f%%) | sin máscara: %d (%.1f%%)",
+	},
+	"stats.repetidos": {
+		PtBR: "🔁 Mais repetidos:",
+		En:   "🔁 Most repeated:",
+		Es:   "🔁 Más repetidos:",
+	},
+	"stats.empresas": {
+		PtBR: "🏢 Empresas com mais estabelecimentos:",
+		En:   "🏢 Companies with the most branches:",
+		Es:   "🏢 Empresas con más establecimientos:",
+	},
+	"stats.posicoes": {
+		PtBR: "📍 Caracteres mais frequentes por posição:",
+		En:   "📍 Most frequent characters per position:",
+		Es:   "📍 Caracteres más frecuentes por posición:",
+	},
+	"stats.html.titulo": {
+		PtBR: "Perfil da coluna de CNPJ",
+		En:   "CNPJ column profile",
+		Es:   "Perfil de la columna de CNPJ",
+	},
+	"stats.html.total": {
+		PtBR: "Total",
+		En:   "Total",
+		Es:   "Total",
+	},
+	"stats.html.validos": {
+		PtBR: "Válidos",
+		En:   "Valid",
+		Es:   "Válidos",
+	},
+	"stats.html.invalidos": {
+		PtBR: "Inválidos",
+		En:   "Invalid",
+		Es:   "Inválidos",
+	},
+	"stats.html.vazios": {
+		PtBR: "Vazios",
+		En:   "Empty",
+		Es:   "Vacíos",
+	},
+	"stats.html.motivos": {
+		PtBR: "Inválidos por motivo",
+		En:   "Invalid by reason",
+		Es:   "Inválidos por motivo",
+	},
+	"stats.html.numericos": {
+		PtBR: "Numéricos (legado)",
+		En:   "Numeric (legacy)",
+		Es:   "Numéricos (legado)",
+	},
+	"stats.html.alfanumericos": {
+		PtBR: "Alfanuméricos",
+		En:   "Alphanumeric",
+		Es:   "Alfanuméricos",
+	},
+	"stats.html.com_mascara": {
+		PtBR: "Com máscara",
+		En:   "Masked",
+		Es:   "Con máscara",
+	},
+	"stats.html.sem_mascara": {
+		PtBR: "Sem máscara",
+		En:   "Unmasked",
+		Es:   "Sin máscara",
+	},
+	"stats.html.distintos": {
+		PtBR: "Distintos",
+		En:   "Distinct",
+		Es:   "Distintos",
+	},
+	"stats.html.duplicados": {
+		PtBR: "Duplicados",
+		En:   "Duplicates",
+		Es:   "Duplicados",
+	},
+	"stats.html.mais_repetidos": {
+		PtBR: "Mais repetidos",
+		En:   "Most repeated",
+		Es:   "Más repetidos",
+	},
+	"stats.html.empresas": {
+		PtBR: "Empresas com mais estabelecimentos",
+		En:   "Companies with the most branches",
+		Es:   "Empresas con más establecimientos",
+	},
+	"stats.html.posicoes": {
+		PtBR: "Frequência de caracteres por posição",
+		En:   "Character frequency per position",
+		Es:   "Frecuencia de caracteres por posición",
+	},
+	"stats.html.posicao": {
+		PtBR: "Posição",
+		En:   "Position",
+		Es:   "Posición",
+	},
+	"stats.html.quantidade": {
+		PtBR: "Quantidade",
+		En:   "Count",
+		Es:   "Cantidad",
+	},
+	"stats.html.estabelecimentos": {
+		PtBR: "Estabelecimentos",
+		En:   "Branches",
+		Es:   "Establecimientos",
+	},
+	"stats.html.valor": {
+		PtBR: "Valor",
+		En:   "Value",
+		Es:   "Valor",
+	},
+	"stats.html.caractere": {
+		PtBR: "Caractere",
+		En:   "Character",
+		Es:   "Carácter",
+	},
+	"stats.html.motivo": {
+		PtBR: "Motivo",
+		En:   "Reason",
+		Es:   "Motivo",
+	},
+
+	// sql e conexão com bancos
+	"db.flags": {
+		PtBR: "informe --sqlite ou --pg-host, --pg-user, --pg-password e --pg-database",
+		En:   "provide --sqlite or --pg-host, --pg-user, --pg-password and --pg-database",
+		Es:   "informe --sqlite o --pg-host, --pg-user, --pg-password y --pg-database",
+	},
+	"sql.tabela": {
+		PtBR: "é necessário informar --table e --column",
+		En:   "--table and --column are required",
+		Es:   "es necesario informar --table y --column",
+	},
+	"sql.sqlite": {
+		PtBR: "é necessário informar --sqlite com o arquivo do banco",
+		En:   "--sqlite with the database file is required",
+		Es:   "es necesario informar --sqlite con el archivo de la base",
+	},
+	"sql.dryrun": {
+		PtBR: "🔎 %s.%s: %d linhas, %d válidas, %d nulas, %d seriam rejeitadas pela restrição",
+		En:   "🔎 %s.%s: %d rows, %d valid, %d null, %d would be rejected by the constraint",
+		Es:   "🔎 %s.%s: %d filas, %d válidas, %d nulas, %d serían rechazadas por la restricción",
+	},
+	"sql.exemplos": {
+		PtBR: "📋 Exemplos de valores inválidos:",
+		En:   "📋 Sample invalid values:",
+		Es:   "📋 Ejemplos de valores inválidos:",
+	},
+	"sql.pronto": {
+		PtBR: "✅ A restrição pode ser aplicada sem erros",
+		En:   "✅ The constraint can be applied without errors",
+		Es:   "✅ La restricción puede aplicarse sin errores",
+	},
+	"db.conectar": {
+		PtBR: "erro ao conectar no banco: %v",
+		En:   "error connecting to the database: %v",
+		Es:   "error al conectar a la base de datos: %v",
+	},
+	"db.ping": {
+		PtBR: "não foi possível pingar o banco: %v",
+		En:   "could not ping the database: %v",
+		Es:   "no fue posible hacer ping a la base de datos: %v",
+	},
+
+	// db
+	"db.invalido": {
+		PtBR: "❌ %s: %q — %s",
+		En:   "❌ %s: %q — %s",
+		Es:   "❌ %s: %q — %s",
+	},
+	"db.resumo": {
+		PtBR: "🔎 %d linhas: %d válidas, %d nulas, %d inválidas (%d corrigidas pela normalização)",
+		En:   "🔎 %d rows: %d valid, %d null, %d invalid (%d fixed by normalization)",
+		Es:   "🔎 %d filas: %d válidas, %d nulas, %d inválidas (%d corregidas por la normalización)",
+	},
+	"db.alteracao": {
+		PtBR: "✏️ %s: %q → %s",
+		En:   "✏️ %s: %q → %s",
+		Es:   "✏️ %s: %q → %s",
+	},
+	"db.normalizado": {
+		PtBR: "✅ %d linhas lidas, %d normalizadas, %d inválidas mantidas, %d nulas",
+		En:   "✅ %d rows read, %d normalized, %d invalid left unchanged, %d null",
+		Es:   "✅ %d filas leídas, %d normalizadas, %d inválidas sin cambios, %d nulas",
+	},
+	"db.normalizado.dryrun": {
+		PtBR: "🔎 %d linhas lidas, %d seriam normalizadas, %d inválidas seriam mantidas, %d nulas (nada foi gravado)",
+		En:   "🔎 %d rows read, %d would be normalized, %d invalid would be left unchanged, %d null (nothing was written)",
+		Es:   "🔎 %d filas leídas, %d serían normalizadas, %d inválidas quedarían sin cambios, %d nulas (no se grabó nada)",
+	},
+	"db.log": {
+		PtBR: "é necessário informar --log com o arquivo do log de rollback",
+		En:   "--log with the rollback log file is required",
+		Es:   "es necesario informar --log con el archivo del log de rollback",
+	},
+	"db.checkpoint": {
+		PtBR: "--resume exige --checkpoint",
+		En:   "--resume requires --checkpoint",
+		Es:   "--resume requiere --checkpoint",
+	},
+	"db.checkpoint.outro": {
+		PtBR: "o checkpoint %s é de outra coluna (%s.%s)",
+		En:   "checkpoint %s belongs to another column (%s.%s)",
+		Es:   "el checkpoint %s es de otra columna (%s.%s)",
+	},
+	"db.retomar": {
+		PtBR: "os lotes confirmados estão em %s; use --resume para continuar",
+		En:   "committed batches are recorded in %s; use --resume to continue",
+		Es:   "los lotes confirmados están en %s; use --resume para continuar",
+	},
+	"db.restaurados": {
+		PtBR: "↩️ %d linhas restauradas",
+		En:   "↩️ %d rows restored",
+		Es:   "↩️ %d filas restauradas",
+	},
+	"db.readiness.nome": {
+		PtBR: "pelo nome",
+		En:   "by name",
+		Es:   "por el nombre",
+	},
+	"db.readiness.conteudo": {
+		PtBR: "pelo conteúdo, %d de %d valores",
+		En:   "by content, %d of %d values",
+		Es:   "por el contenido, %d de %d valores",
+	},
+	"db.readiness.pronta": {
+		PtBR: "✅ %s (%s, %s): pronta para CNPJs alfanuméricos",
+		En:   "✅ %s (%s, %s): ready for alphanumeric CNPJs",
+		Es:   "✅ %s (%s, %s): lista para CNPJs alfanuméricos",
+	},
+	"db.readiness.problemas": {
+		PtBR: "❌ %s (%s, %s):",
+		En:   "❌ %s (%s, %s):",
+		Es:   "❌ %s (%s, %s):",
+	},
+	"db.readiness.nenhuma": {
+		PtBR: "🔎 Nenhuma coluna de CNPJ encontrada",
+		En:   "🔎 No CNPJ column found",
+		Es:   "🔎 No se encontró ninguna columna de CNPJ",
+	},
+	"db.readiness.plano": {
+		PtBR: "🛠️ Plano de migração:",
+		En:   "🛠️ Migration plan:",
+		Es:   "🛠️ Plan de migración:",
+	},
+
+	// lsp
+	"lsp.hover.titulo": {
+		PtBR: "**CNPJ** `%s`",
+		En:   "**CNPJ** `%s`",
+		Es:   "**CNPJ** `%s`",
+	},
+	"lsp.hover.valido": {
+		PtBR: "✅ Válido",
+		En:   "✅ Valid",
+		Es:   "✅ Válido",
+	},
+	"lsp.hover.invalido": {
+		PtBR: "❌ Inválido: %s",
+		En:   "❌ Invalid: %s",
+		Es:   "❌ Inválido: %s",
+	},
+	"lsp.hover.raiz": {
+		PtBR: "Raiz `%s` · ordem `%s` %s",
+		En:   "Root `%s` · branch number `%s` %s",
+		Es:   "Raíz `%s` · orden `%s` %s",
+	},
+	"lsp.hover.matriz": {
+		PtBR: "(matriz)",
+		En:   "(head office)",
+		Es:   "(matriz)",
+	},
+	"lsp.hover.filial": {
+		PtBR: "(filial)",
+		En:   "(branch)",
+		Es:   "(sucursal)",
+	},
+	"lsp.hover.dv": {
+		PtBR: "DV correto: `%s`",
+		En:   "Correct check digits: `%s`",
+		Es:   "DV correcto: `%s`",
+	},
+	"lsp.acao.dv": {
+		PtBR: "Corrigir o DV: %s",
+		En:   "Fix the check digits: %s",
+		Es:   "Corregir el DV: %s",
+	},
+	"lsp.acao.mascara": {
+		PtBR: "Aplicar a máscara: %s",
+		En:   "Apply the mask: %s",
+		Es:   "Aplicar la máscara: %s",
+	},
+	"lsp.acao.semmascara": {
+		PtBR: "Remover a máscara: %s",
+		En:   "Remove the mask: %s",
+		Es:   "Quitar la máscara: %s",
+	},
+	"lsp.acao.ficticio": {
+		PtBR: "Trocar por CNPJ fictício: %s",
+		En:   "Replace with a fake CNPJ: %s",
+		Es:   "Reemplazar por un CNPJ ficticio: %s",
+	},
+
+	// fixtures
+	"fixtures.empresa": {
+		PtBR: "🏢 %s — %s",
+		En:   "🏢 %s — %s",
+		Es:   "🏢 %s — %s",
+	},
+	"fixtures.detalhes": {
+		PtBR: "   CNAE %s · aberta em %s · IE %s",
+		En:   "   CNAE %s · opened on %s · state registration %s",
+		Es:   "   CNAE %s · abierta el %s · IE %s",
+	},
+	"fixtures.socios": {
+		PtBR: "   👥 Sócios: %s",
+		En:   "   👥 Partners: %s",
+		Es:   "   👥 Socios: %s",
+	},
+	"fixtures.filial": {
+		PtBR: "   🏬 Filial %s · %s",
+		En:   "   🏬 Branch %s · %s",
+		Es:   "   🏬 Sucursal %s · %s",
+	},
+	"fixtures.filiais": {
+		PtBR: "--filiais deve estar entre 0 e %d",
+		En:   "--filiais must be between 0 and %d",
+		Es:   "--filiais debe estar entre 0 y %d",
+	},
+	"fixtures.socios_flag": {
+		PtBR: "--socios deve ser maior que zero",
+		En:   "--socios must be greater than zero",
+		Es:   "--socios debe ser mayor que cero",
+	},
+
+	// import-rf
+	"importrf.lendo": {
+		PtBR: "📥 Importando %s...",
+		En:   "📥 Importing %s...",
+		Es:   "📥 Importando %s...",
+	},
+	"importrf.arquivo": {
+		PtBR: "✅ %s: %d linhas (%s)",
+		En:   "✅ %s: %d rows (%s)",
+		Es:   "✅ %s: %d filas (%s)",
+	},
+	"importrf.vazio": {
+		PtBR: "nenhum arquivo de Dados Abertos em %s",
+		En:   "no Open Data files in %s",
+		Es:   "ningún archivo de Datos Abiertos en %s",
+	},
+	"importrf.indices": {
+		PtBR: "🗂️  Criando os índices...",
+		En:   "🗂️  Creating the indexes...",
+		Es:   "🗂️  Creando los índices...",
+	},
+	"importrf.concluido": {
+		PtBR: "🏁 %d linhas importadas em %s",
+		En:   "🏁 %d rows imported in %s",
+		Es:   "🏁 %d filas importadas en %s",
+	},
+
+	// lookup
+	"lookup.empresa": {
+		PtBR: "🏢 %s — %s (%s)",
+		En:   "🏢 %s — %s (%s)",
+		Es:   "🏢 %s — %s (%s)",
+	},
+	"lookup.matriz": {
+		PtBR: "matriz",
+		En:   "head office",
+		Es:   "casa matriz",
+	},
+	"lookup.filial": {
+		PtBR: "filial",
+		En:   "branch",
+		Es:   "sucursal",
+	},
+	"lookup.fantasia": {
+		PtBR: "   Nome fantasia: %s",
+		En:   "   Trade name: %s",
+		Es:   "   Nombre comercial: %s",
+	},
+	"lookup.situacao": {
+		PtBR: "   Situação: %s desde %s · início das atividades em %s",
+		En:   "   Status: %s since %s · started operating on %s",
+		Es:   "   Situación: %s desde %s · inicio de actividades el %s",
+	},
+	"lookup.natureza": {
+		PtBR: "   Natureza jurídica: %s · porte: %s · capital social: R$ %.2f",
+		En:   "   Legal nature: %s · size: %s · share capital: R$ %.2f",
+		Es:   "   Naturaleza jurídica: %s · tamaño: %s · capital social: R$ %.2f",
+	},
+	"lookup.cnae": {
+		PtBR: "   CNAE principal: %s",
+		En:   "   Main CNAE: %s",
+		Es:   "   CNAE principal: %s",
+	},
+	"lookup.cnaes": {
+		PtBR: "   CNAEs secundários: %s",
+		En:   "   Secondary CNAEs: %s",
+		Es:   "   CNAE secundarios: %s",
+	},
+	"lookup.endereco": {
+		PtBR: "   Endereço: %s",
+		En:   "   Address: %s",
+		Es:   "   Dirección: %s",
+	},
+	"lookup.simples": {
+		PtBR: "   Simples Nacional: %s · MEI: %s",
+		En:   "   Simples Nacional: %s · MEI: %s",
+		Es:   "   Simples Nacional: %s · MEI: %s",
+	},
+	"lookup.sim": {
+		PtBR: "sim",
+		En:   "yes",
+		Es:   "sí",
+	},
+	"lookup.nao": {
+		PtBR: "não",
+		En:   "no",
+		Es:   "no",
+	},
+	"lookup.socios": {
+		PtBR: "   👥 Sócios: %s",
+		En:   "   👥 Partners: %s",
+		Es:   "   👥 Socios: %s",
+	},
+	"lookup.nao_encontrado": {
+		PtBR: "❓ %s não está nos dados importados",
+		En:   "❓ %s is not in the imported data",
+		Es:   "❓ %s no está en los datos importados",
+	},
+	"lookup.invalido": {
+		PtBR: "❌ %s: %s",
+		En:   "❌ %s: %s",
+		Es:   "❌ %s: %s",
+	},
+
+	// mock-lookup
+	"mocklookup.iniciado": {
+		PtBR: "🧪 Servidor de consulta simulado ouvindo em %s",
+		En:   "🧪 Mock lookup server listening on %s",
+		Es:   "🧪 Servidor de consulta simulado escuchando en %s",
+	},
+	"mocklookup.error_rate": {
+		PtBR: "--error-rate deve estar entre 0 e 1",
+		En:   "--error-rate must be between 0 and 1",
+		Es:   "--error-rate debe estar entre 0 y 1",
+	},
+	"mocklookup.error_status": {
+		PtBR: "--error-status deve ser um status HTTP de erro (4xx ou 5xx)",
+		En:   "--error-status must be an HTTP error status (4xx or 5xx)",
+		Es:   "--error-status debe ser un estado HTTP de error (4xx o 5xx)",
+	},
+
+	// chave
+	"chave.valida": {
+		PtBR: "[%d] ✅ %s",
+		En:   "[%d] ✅ %s",
+		Es:   "[%d] ✅ %s",
+	},
+	"chave.invalida": {
+		PtBR: "[%d] ❌ %s: %s",
+		En:   "[%d] ❌ %s: %s",
+		Es:   "[%d] ❌ %s: %s",
+	},
+	"chave.documento": {
+		PtBR: "   📄 %s nº %s, série %s",
+		En:   "   📄 %s no. %s, series %s",
+		Es:   "   📄 %s nº %s, serie %s",
+	},
+	"chave.emissao": {
+		PtBR: "   📅 %s, %02d/%d · emissão %s",
+		En:   "   📅 %s, %02d/%d · emission %s",
+		Es:   "   📅 %s, %02d/%d · emisión %s",
+	},
+	"chave.emitente": {
+		PtBR: "   🏢 Emitente: %s",
+		En:   "   🏢 Issuer: %s",
+		Es:   "   🏢 Emisor: %s",
+	},
+	"chave.nenhuma": {
+		PtBR: "⚠️  Nenhuma chave de acesso foi informada. Por favor, passe pelo menos um argumento.",
+		En:   "⚠️  No access key was given. Please pass at least one argument.",
+		Es:   "⚠️  No se informó ninguna clave de acceso. Por favor, pase al menos un argumento.",
+	},
+	"chave.mes": {
+		PtBR: "--mes deve estar no formato AAAA-MM: %q",
+		En:   "--mes must be in the YYYY-MM format: %q",
+		Es:   "--mes debe estar en el formato AAAA-MM: %q",
+	},
+
+	// api
+	"api.flags": {
+		PtBR: "é necessário informar --pg-host, --pg-user, --pg-password e --pg-database",
+		En:   "--pg-host, --pg-user, --pg-password and --pg-database are required",
+		Es:   "es necesario informar --pg-host, --pg-user, --pg-password y --pg-database",
+	},
+	"api.iniciado": {
+		PtBR: "🚀 Servidor iniciado em http://localhost:4400",
+		En:   "🚀 Server started at http://localhost:4400",
+		Es:   "🚀 Servidor iniciado en http://localhost:4400",
+	},
+
+	// conformance
+	"conformance.aprovado": {
+		PtBR: "[%d] ✅  %q dv=%q valido=%v",
+		En:   "[%d] ✅  %q dv=%q valid=%v",
+		Es:   "[%d] ✅  %q dv=%q valido=%v",
+	},
+	"conformance.reprovado": {
+		PtBR: "[%d] ❌  %q dv=%q valido=%v, esperado dv=%q valido=%v (%s)",
+		En:   "[%d] ❌  %q dv=%q valid=%v, expected dv=%q valid=%v (%s)",
+		Es:   "[%d] ❌  %q dv=%q valido=%v, esperado dv=%q valido=%v (%s)",
+	},
+	"conformance.resumo": {
+		PtBR: "📋 %s (v%d): %d aprovados, %d reprovados",
+		En:   "📋 %s (v%d): %d passed, %d failed",
+		Es:   "📋 %s (v%d): %d aprobados, %d reprobados",
+	},
+	"conformance.exec": {
+		PtBR: "Erro ao iniciar a implementação externa: %v",
+		En:   "Error starting the external implementation: %v",
+		Es:   "Error al iniciar la implementación externa: %v",
+	},
+	"conformance.exec_vazio": {
+		PtBR: "--exec requer o comando da implementação externa",
+		En:   "--exec requires the command of the external implementation",
+		Es:   "--exec requiere el comando de la implementación externa",
+	},
+}
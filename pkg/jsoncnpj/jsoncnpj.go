@@ -0,0 +1,250 @@
+// Package jsoncnpj localiza e valida CNPJs dentro de documentos JSON e NDJSON
+// usando seletores no estilo JSONPath, com reescrita que preserva o documento original.
+package jsoncnpj
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Mode define como os valores encontrados são reescritos
+type Mode int
+
+const (
+	// Keep mantém o documento como está; apenas valida
+	Keep Mode = iota
+	// Normalize troca cada CNPJ válido pela forma sem máscara, em maiúsculas
+	Normalize
+	// Format troca cada CNPJ válido pela forma com máscara
+	Format
+)
+
+// ParseMode reconhece "normalize" e "format"; vazio e "keep" retornam Keep
+func ParseMode(name string) (Mode, bool) {
+	switch name {
+	case "", "keep":
+		return Keep, true
+	case "normalize":
+		return Normalize, true
+	case "format":
+		return Format, true
+	}
+	return Keep, false
+}
+
+// Match é um valor selecionado no documento
+type Match struct {
+	// Path é o caminho concreto do valor, ex.: $.fornecedores[2].documento
+	Path string
+	// Value é o valor original; números são convertidos para texto
+	Value string
+	Valid bool
+	// Err é o motivo da rejeição, nil quando o valor é válido
+	Err error
+
+	start, end int
+}
+
+// Find percorre o documento e valida todos os valores de texto ou número
+// selecionados por alguma das expressões. Objetos, arrays, null e booleanos
+// selecionados são ignorados.
+func Find(doc []byte, selectors ...*Selector) ([]Match, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	type frame struct {
+		obj       bool
+		expectKey bool
+	}
+	var (
+		stack   []frame
+		path    []pathElem
+		matches []Match
+	)
+
+	// advance avança o último contêiner depois que um valor foi consumido
+	advance := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := &stack[len(stack)-1]
+		if top.obj {
+			top.expectKey = true
+		} else {
+			path[len(path)-1].index++
+		}
+	}
+
+	for {
+		before := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		after := dec.InputOffset()
+
+		if len(stack) > 0 && stack[len(stack)-1].obj && stack[len(stack)-1].expectKey {
+			if key, ok := tok.(string); ok {
+				path[len(path)-1] = pathElem{key: key, isKey: true}
+				stack[len(stack)-1].expectKey = false
+				continue
+			}
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{':
+				stack = append(stack, frame{obj: true, expectKey: true})
+				path = append(path, pathElem{isKey: true})
+			case '[':
+				stack = append(stack, frame{})
+				path = append(path, pathElem{index: 0})
+			default:
+				stack = stack[:len(stack)-1]
+				path = path[:len(path)-1]
+				advance()
+			}
+			continue
+		case string, json.Number:
+			if selected(selectors, path) {
+				start := before + int64(bytes.IndexFunc(doc[before:after], func(r rune) bool {
+					return r != ' ' && r != '\t' && r != '\n' && r != '\r' && r != ':' && r != ','
+				}))
+				m := Match{Path: formatPath(path), Value: fmt.Sprint(t), start: int(start), end: int(after)}
+				m.Err = cnpj.Validate(m.Value)
+				m.Valid = m.Err == nil
+				matches = append(matches, m)
+			}
+		}
+		advance()
+	}
+	return matches, nil
+}
+
+func selected(selectors []*Selector, path []pathElem) bool {
+	for _, s := range selectors {
+		if s.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rewrite substitui os CNPJs válidos encontrados conforme o modo, mantendo a
+// ordem das chaves, a indentação e todo o resto do documento intactos.
+// Valores inválidos não são alterados.
+func Rewrite(doc []byte, matches []Match, mode Mode) []byte {
+	if mode == Keep {
+		return doc
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(doc))
+	last := 0
+	for _, m := range matches {
+		if !m.Valid {
+			continue
+		}
+		value := cnpj.UnformattedCNPJ(m.Value)
+		if mode == Format {
+			value = cnpj.FormatCNPJ(value)
+		}
+		quoted, _ := json.Marshal(value)
+
+		out.Write(doc[last:m.start])
+		out.Write(quoted)
+		last = m.end
+	}
+	out.Write(doc[last:])
+	return out.Bytes()
+}
+
+// Options controla o processamento de um fluxo
+type Options struct {
+	Selectors []*Selector
+	Mode      Mode
+	// NDJSON trata cada linha como um documento independente
+	NDJSON bool
+}
+
+// Result é um valor encontrado durante Process; Line é a linha do documento
+// no NDJSON e zero em um documento JSON único
+type Result struct {
+	Line int
+	Match
+}
+
+// Process lê o documento (ou, no NDJSON, cada linha por vez), informa cada valor
+// selecionado a report e, se w não for nil, escreve o documento reescrito
+func Process(r io.Reader, w io.Writer, opts Options, report func(Result) error) error {
+	if len(opts.Selectors) == 0 {
+		return errors.New("nenhum seletor informado")
+	}
+
+	handle := func(line int, doc []byte) ([]byte, error) {
+		matches, err := Find(doc, opts.Selectors...)
+		if err != nil {
+			if line > 0 {
+				return nil, fmt.Errorf("linha %d: %w", line, err)
+			}
+			return nil, err
+		}
+		for _, m := range matches {
+			if report == nil {
+				break
+			}
+			if err := report(Result{Line: line, Match: m}); err != nil {
+				return nil, err
+			}
+		}
+		return Rewrite(doc, matches, opts.Mode), nil
+	}
+
+	if !opts.NDJSON {
+		doc, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		out, err := handle(0, doc)
+		if err != nil || w == nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	}
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	for line := 1; ; line++ {
+		raw, err := br.ReadBytes('\n')
+		if len(raw) > 0 {
+			doc := raw
+			if len(bytes.TrimSpace(raw)) > 0 {
+				var herr error
+				if doc, herr = handle(line, raw); herr != nil {
+					return herr
+				}
+			}
+			if w != nil {
+				if _, werr := w.Write(doc); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
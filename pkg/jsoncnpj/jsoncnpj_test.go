@@ -0,0 +1,140 @@
+package jsoncnpj
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+const nota = `{
+  "nota": {
+    "numero": 10,
+    "emitente": {"cnpj": "12.abc.345/01de-35", "nome": "Emitente"}
+  },
+  "fornecedores": [
+    {"documento": "11222333000181"},
+    {"documento": "12ABC34501DE36", "outros": {"documento": "x"}},
+    {"documento": 11222333000181}
+  ],
+  "a b": {"cnpj": "11.222.333/0001-81"}
+}`
+
+func TestCompile(t *testing.T) {
+	valid := []string{"$", "$.a", "$.a.b", "$['a b'].c", `$["a"]`, "$.a[0]", "$.a[*].b", "$.*", "$..cnpj", "$..*", "$..['a b']"}
+	for _, expr := range valid {
+		if _, err := Compile(expr); err != nil {
+			t.Errorf("Compile(%q) unexpected error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"", "a.b", "$.", "$.a[", "$.a[-1]", "$.a[x]", "$['a", "$a", "$..[0]"}
+	for _, expr := range invalid {
+		if _, err := Compile(expr); !errors.Is(err, ErrSeletorInvalido) {
+			t.Errorf("Compile(%q) expected ErrSeletorInvalido, got %v", expr, err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		expr  string
+		paths []string
+	}{
+		{"$.nota.emitente.cnpj", []string{"$.nota.emitente.cnpj"}},
+		{"$.fornecedores[*].documento", []string{"$.fornecedores[0].documento", "$.fornecedores[1].documento", "$.fornecedores[2].documento"}},
+		{"$.fornecedores[1].documento", []string{"$.fornecedores[1].documento"}},
+		{"$..documento", []string{"$.fornecedores[0].documento", "$.fornecedores[1].documento", "$.fornecedores[1].outros.documento", "$.fornecedores[2].documento"}},
+		{"$['a b'].cnpj", []string{"$['a b'].cnpj"}},
+		{"$.nota", nil},
+	}
+
+	for _, tt := range tests {
+		matches, err := Find([]byte(nota), MustCompile(tt.expr))
+		if err != nil {
+			t.Fatalf("Find(%q): %v", tt.expr, err)
+		}
+		var paths []string
+		for _, m := range matches {
+			paths = append(paths, m.Path)
+		}
+		if strings.Join(paths, ",") != strings.Join(tt.paths, ",") {
+			t.Errorf("Find(%q) = %v, expected %v", tt.expr, paths, tt.paths)
+		}
+	}
+}
+
+func TestFindValidation(t *testing.T) {
+	matches, err := Find([]byte(nota), MustCompile("$..documento"), MustCompile("$.nota.emitente.cnpj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]error{
+		"$.nota.emitente.cnpj":               cnpj.ErroCaractereInvalido,
+		"$.fornecedores[0].documento":        nil,
+		"$.fornecedores[1].documento":        cnpj.ErroDVIncorreto,
+		"$.fornecedores[1].outros.documento": cnpj.ErroCaractereInvalido,
+		"$.fornecedores[2].documento":        nil,
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %d matches, got %d", len(expected), len(matches))
+	}
+	for _, m := range matches {
+		if !errors.Is(m.Err, expected[m.Path]) || m.Valid != (m.Err == nil) {
+			t.Errorf("%s: err = %v, expected %v", m.Path, m.Err, expected[m.Path])
+		}
+	}
+}
+
+func TestRewritePreservesDocument(t *testing.T) {
+	sel := MustCompile("$..documento")
+	matches, _ := Find([]byte(nota), sel, MustCompile("$.nota.emitente.cnpj"))
+
+	got := string(Rewrite([]byte(nota), matches, Format))
+	// o emitente em minúsculas é inválido, como em cnpj.Validate, e fica como está
+	expected := strings.NewReplacer(
+		`"11222333000181"`, `"11.222.333/0001-81"`,
+		`11222333000181}`, `"11.222.333/0001-81"}`,
+	).Replace(nota)
+	if got != expected {
+		t.Errorf("Rewrite(Format) =\n%s\nexpected\n%s", got, expected)
+	}
+
+	if got := Rewrite([]byte(nota), matches, Keep); string(got) != nota {
+		t.Error("Rewrite(Keep) changed the document")
+	}
+}
+
+func TestProcessNDJSON(t *testing.T) {
+	input := "{\"cnpj\":\"12.ABC.345/01DE-35\",\"n\":1}\n\n{\"cnpj\":\"12ABC34501DE36\"}\n{\"cnpj\":\"11.222.333/0001-81\"}"
+
+	var out bytes.Buffer
+	var results []Result
+	err := Process(strings.NewReader(input), &out, Options{
+		Selectors: []*Selector{MustCompile("$.cnpj")},
+		Mode:      Normalize,
+		NDJSON:    true,
+	}, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "{\"cnpj\":\"12ABC34501DE35\",\"n\":1}\n\n{\"cnpj\":\"12ABC34501DE36\"}\n{\"cnpj\":\"11222333000181\"}"
+	if out.String() != expected {
+		t.Errorf("output = %q, expected %q", out.String(), expected)
+	}
+	if len(results) != 3 || results[1].Line != 3 || results[1].Valid {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	err = Process(strings.NewReader("{}\n{oops\n"), nil, Options{Selectors: []*Selector{MustCompile("$.cnpj")}, NDJSON: true}, nil)
+	if err == nil || !strings.Contains(err.Error(), "linha 2") {
+		t.Errorf("expected error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package jsoncnpj
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrSeletorInvalido = errors.New("seletor inválido")
+
+type segmentKind int
+
+const (
+	segChild segmentKind = iota
+	segIndex
+	segWildcard
+	segRecursive
+)
+
+// segment é um passo do seletor; em segRecursive, name vazio significa qualquer chave
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+// Selector é uma expressão no estilo JSONPath já compilada. São aceitos:
+//
+//	$            raiz do documento
+//	.nome        chave de objeto (também ['nome'] ou ["nome"])
+//	[n]          posição de um array, a partir de 0
+//	[*] ou .*    qualquer chave ou posição
+//	..nome       a chave em qualquer profundidade (também ..*)
+type Selector struct {
+	expr     string
+	segments []segment
+}
+
+// Compile interpreta uma expressão como $.nota.emitente.cnpj ou $.fornecedores[*].documento
+func Compile(expr string) (*Selector, error) {
+	s := &Selector{expr: expr}
+	fail := func(pos int, reason string) (*Selector, error) {
+		return nil, fmt.Errorf("%w: %q, posição %d: %s", ErrSeletorInvalido, expr, pos, reason)
+	}
+
+	if !strings.HasPrefix(expr, "$") {
+		return fail(0, "a expressão deve começar com $")
+	}
+
+	for i := 1; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			recursive := strings.HasPrefix(expr[i:], "..")
+			if recursive {
+				i += 2
+			} else {
+				i++
+			}
+
+			j := i
+			for j < len(expr) && expr[j] != '.' && expr[j] != '[' {
+				j++
+			}
+			name := expr[i:j]
+			if name == "" {
+				if recursive && j < len(expr) && expr[j] == '[' {
+					// ..['nome'] ou ..[*]: o colchete seguinte define o nome
+					seg, next, err := parseBracket(expr, j)
+					if err != nil {
+						return fail(j, err.Error())
+					}
+					if seg.kind == segIndex {
+						return fail(j, "posição não é aceita após ..")
+					}
+					if seg.kind == segChild {
+						s.segments = append(s.segments, segment{kind: segRecursive, name: seg.name})
+					} else {
+						s.segments = append(s.segments, segment{kind: segRecursive})
+					}
+					i = next
+					continue
+				}
+				return fail(i, "nome de chave vazio")
+			}
+
+			switch {
+			case recursive && name == "*":
+				s.segments = append(s.segments, segment{kind: segRecursive})
+			case recursive:
+				s.segments = append(s.segments, segment{kind: segRecursive, name: name})
+			case name == "*":
+				s.segments = append(s.segments, segment{kind: segWildcard})
+			default:
+				s.segments = append(s.segments, segment{kind: segChild, name: name})
+			}
+			i = j
+		case '[':
+			seg, next, err := parseBracket(expr, i)
+			if err != nil {
+				return fail(i, err.Error())
+			}
+			s.segments = append(s.segments, seg)
+			i = next
+		default:
+			return fail(i, fmt.Sprintf("caractere inesperado %q", expr[i]))
+		}
+	}
+	return s, nil
+}
+
+// MustCompile é como Compile, mas entra em pânico se a expressão for inválida
+func MustCompile(expr string) *Selector {
+	s, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseBracket interpreta [*], [n], ['nome'] ou ["nome"] a partir de expr[i] == '['
+func parseBracket(expr string, i int) (segment, int, error) {
+	end := strings.IndexByte(expr[i:], ']')
+	if end < 0 {
+		return segment{}, 0, errors.New("colchete não fechado")
+	}
+
+	inner := expr[i+1 : i+end]
+	if q := inner; len(q) >= 2 && (q[0] == '\'' || q[0] == '"') {
+		// O nome entre aspas pode conter ']', então procura a aspa de fechamento
+		quote := q[0]
+		closing := strings.IndexByte(expr[i+2:], quote)
+		if closing < 0 || i+2+closing+1 >= len(expr) || expr[i+2+closing+1] != ']' {
+			return segment{}, 0, errors.New("nome entre aspas mal formado")
+		}
+		name := expr[i+2 : i+2+closing]
+		return segment{kind: segChild, name: name}, i + 2 + closing + 2, nil
+	}
+
+	next := i + end + 1
+	if inner == "*" {
+		return segment{kind: segWildcard}, next, nil
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil || n < 0 {
+		return segment{}, 0, fmt.Errorf("posição inválida %q", inner)
+	}
+	return segment{kind: segIndex, index: n}, next, nil
+}
+
+// String retorna a expressão original
+func (s *Selector) String() string {
+	return s.expr
+}
+
+// pathElem é um passo de um caminho concreto: chave de objeto ou posição de array
+type pathElem struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// matches indica se o caminho concreto é selecionado pela expressão
+func (s *Selector) matches(path []pathElem) bool {
+	return matchSegments(s.segments, path)
+}
+
+func matchSegments(segs []segment, path []pathElem) bool {
+	if len(segs) == 0 {
+		return len(path) == 0
+	}
+
+	seg := segs[0]
+	if seg.kind == segRecursive {
+		for k := range path {
+			if (seg.name == "" || (path[k].isKey && path[k].key == seg.name)) && matchSegments(segs[1:], path[k+1:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+	switch p := path[0]; seg.kind {
+	case segChild:
+		if !p.isKey || p.key != seg.name {
+			return false
+		}
+	case segIndex:
+		if p.isKey || p.index != seg.index {
+			return false
+		}
+	}
+	return matchSegments(segs[1:], path[1:])
+}
+
+// formatPath escreve o caminho concreto na notação dos seletores, ex.: $.fornecedores[2].documento
+func formatPath(path []pathElem) string {
+	var b strings.Builder
+	b.WriteByte('$')
+	for _, p := range path {
+		switch {
+		case !p.isKey:
+			b.WriteString("[" + strconv.Itoa(p.index) + "]")
+		case isPlainKey(p.key):
+			b.WriteString("." + p.key)
+		default:
+			b.WriteString("['" + p.key + "']")
+		}
+	}
+	return b.String()
+}
+
+func isPlainKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,247 @@
+// Package lists normaliza listas de CNPJs vindas de sistemas diferentes e
+// oferece deduplicação, agrupamento por empresa, operações de conjunto e a
+// detecção de valores quase iguais, que costumam ser erros de digitação.
+package lists
+
+import (
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Normalize é a forma usada nas comparações: sem máscara e em maiúsculas
+func Normalize(value string) string {
+	return cnpj.UnformattedCNPJ(value)
+}
+
+// Entry é um valor distinto de uma lista
+type Entry struct {
+	Value string
+	// Originals são as grafias encontradas, na ordem em que apareceram
+	Originals   []string
+	Occurrences int
+	Valid       bool
+}
+
+// Dedupe agrupa os valores pela forma normalizada, na ordem da primeira ocorrência.
+// Valores que ficam vazios após a normalização são descartados.
+func Dedupe(values []string) []Entry {
+	var out []Entry
+	index := map[string]int{}
+	for _, v := range values {
+		n := Normalize(v)
+		if n == "" {
+			continue
+		}
+
+		i, ok := index[n]
+		if !ok {
+			i = len(out)
+			index[n] = i
+			out = append(out, Entry{Value: n, Valid: cnpj.IsValid(n)})
+		}
+
+		e := &out[i]
+		e.Occurrences++
+		if !contains(e.Originals, v) {
+			e.Originals = append(e.Originals, v)
+		}
+	}
+	return out
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// unique retorna os valores normalizados distintos, na ordem da primeira ocorrência
+func unique(values []string) []string {
+	entries := Dedupe(values)
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.Value
+	}
+	return out
+}
+
+func set(values []string) map[string]bool {
+	s := make(map[string]bool, len(values))
+	for _, v := range values {
+		s[Normalize(v)] = true
+	}
+	return s
+}
+
+// Union retorna os valores presentes em qualquer uma das listas
+func Union(lists ...[]string) []string {
+	var all []string
+	for _, l := range lists {
+		all = append(all, l...)
+	}
+	return unique(all)
+}
+
+// Intersect retorna os valores da primeira lista presentes em todas as outras
+func Intersect(first []string, others ...[]string) []string {
+	sets := make([]map[string]bool, len(others))
+	for i, o := range others {
+		sets[i] = set(o)
+	}
+
+	var out []string
+	for _, v := range unique(first) {
+		inAll := true
+		for _, s := range sets {
+			if !s[v] {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Diff retorna os valores da primeira lista ausentes de todas as outras
+func Diff(first []string, others ...[]string) []string {
+	exclude := set(nil)
+	for _, o := range others {
+		for v := range set(o) {
+			exclude[v] = true
+		}
+	}
+
+	var out []string
+	for _, v := range unique(first) {
+		if !exclude[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Group reúne os estabelecimentos de uma mesma empresa
+type Group struct {
+	Raiz string
+	// Matriz é o CNPJ de ordem 0001, vazio se não estiver na lista
+	Matriz  string
+	Filiais []string
+}
+
+// GroupByRaiz agrupa os CNPJs completos (14 caracteres) pela raiz, na ordem da
+// primeira ocorrência. Valores incompletos são ignorados.
+func GroupByRaiz(values []string) []Group {
+	var out []Group
+	index := map[string]int{}
+	for _, v := range unique(values) {
+		if len(v) != 14 {
+			continue
+		}
+
+		raiz := cnpj.Raiz(v)
+		i, ok := index[raiz]
+		if !ok {
+			i = len(out)
+			index[raiz] = i
+			out = append(out, Group{Raiz: raiz})
+		}
+
+		if cnpj.IsMatriz(v) && out[i].Matriz == "" {
+			out[i].Matriz = v
+		} else {
+			out[i].Filiais = append(out[i].Filiais, v)
+		}
+	}
+	return out
+}
+
+// NearDupe é um par de valores a uma edição de distância: um caractere trocado,
+// inserido, removido ou dois vizinhos invertidos
+type NearDupe struct {
+	A, B           string
+	AValid, BValid bool
+}
+
+// NearDupes encontra os pares de valores distintos a uma edição de distância.
+// Em vez de comparar todos os pares, indexa cada valor pelas variantes com um
+// caractere removido: valores a uma edição de distância sempre compartilham uma
+// dessas variantes, o que mantém o custo proporcional ao tamanho da lista.
+func NearDupes(values []string) []NearDupe {
+	distinct := unique(values)
+
+	variants := map[string][]int{}
+	for i, v := range distinct {
+		seen := map[string]bool{v: true}
+		variants[v] = append(variants[v], i)
+		for j := range v {
+			d := v[:j] + v[j+1:]
+			if !seen[d] {
+				seen[d] = true
+				variants[d] = append(variants[d], i)
+			}
+		}
+	}
+
+	type pair struct{ a, b int }
+	reported := map[pair]bool{}
+	var out []NearDupe
+	for i, v := range distinct {
+		for j := range v {
+			for _, k := range variants[v[:j]+v[j+1:]] {
+				if k <= i || reported[pair{i, k}] || !oneEdit(v, distinct[k]) {
+					continue
+				}
+				reported[pair{i, k}] = true
+				out = append(out, NearDupe{A: v, B: distinct[k], AValid: cnpj.IsValid(v), BValid: cnpj.IsValid(distinct[k])})
+			}
+		}
+		// Valores um caractere mais curtos coincidem com o próprio valor
+		for _, k := range variants[v] {
+			if k > i && !reported[pair{i, k}] && oneEdit(v, distinct[k]) {
+				reported[pair{i, k}] = true
+				out = append(out, NearDupe{A: v, B: distinct[k], AValid: cnpj.IsValid(v), BValid: cnpj.IsValid(distinct[k])})
+			}
+		}
+	}
+	return out
+}
+
+// oneEdit indica se a e b, distintos, diferem por exatamente uma edição
+func oneEdit(a, b string) bool {
+	if a == b {
+		return false
+	}
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	switch len(a) - len(b) {
+	case 0:
+		var diffs []int
+		for i := 0; i < len(a); i++ {
+			if a[i] != b[i] {
+				diffs = append(diffs, i)
+				if len(diffs) > 2 {
+					return false
+				}
+			}
+		}
+		if len(diffs) == 1 {
+			return true
+		}
+		i, j := diffs[0], diffs[1]
+		return j == i+1 && a[i] == b[j] && a[j] == b[i]
+	case 1:
+		i := 0
+		for i < len(b) && a[i] == b[i] {
+			i++
+		}
+		return a[i+1:] == b[i:]
+	}
+	return false
+}
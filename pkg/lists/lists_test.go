@@ -0,0 +1,97 @@
+package lists
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+	got := Dedupe([]string{"12.ABC.345/01DE-35", "12abc34501de35", "", "11222333000181", "12ABC34501DE35", " - "})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", got)
+	}
+	if got[0].Value != "12ABC34501DE35" || got[0].Occurrences != 3 || len(got[0].Originals) != 3 || !got[0].Valid {
+		t.Errorf("unexpected entry: %+v", got[0])
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := []string{"12.ABC.345/01DE-35", "11222333000181", "AAAAAAAAAAAA00"}
+	b := []string{"11.222.333/0001-81", "BBBBBBBBBBBB00"}
+	c := []string{"11222333000181", "12abc34501de35"}
+
+	if got := Union(a, b); !reflect.DeepEqual(got, []string{"12ABC34501DE35", "11222333000181", "AAAAAAAAAAAA00", "BBBBBBBBBBBB00"}) {
+		t.Errorf("Union = %v", got)
+	}
+	if got := Intersect(a, b, c); !reflect.DeepEqual(got, []string{"11222333000181"}) {
+		t.Errorf("Intersect = %v", got)
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, []string{"12ABC34501DE35", "AAAAAAAAAAAA00"}) {
+		t.Errorf("Diff = %v", got)
+	}
+}
+
+func TestGroupByRaiz(t *testing.T) {
+	got := GroupByRaiz([]string{"11222333000262", "11.222.333/0001-81", "12ABC34501DE35", "123"})
+	expected := []Group{
+		{Raiz: "11222333", Matriz: "11222333000181", Filiais: []string{"11222333000262"}},
+		{Raiz: "12ABC345", Filiais: []string{"12ABC34501DE35"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("GroupByRaiz = %+v, expected %+v", got, expected)
+	}
+}
+
+func TestNearDupes(t *testing.T) {
+	values := []string{
+		"12ABC34501DE35", // válido
+		"12ABC34501DE53", // DV invertido
+		"12ABC34501OE35", // D trocado por O
+		"12ABC3450DE35",  // caractere removido
+		"11222333000181", // sem parentes
+		"12ABC34501DE35", // repetido, não é quase igual
+	}
+
+	got := NearDupes(values)
+	pairs := map[[2]string]bool{}
+	for _, d := range got {
+		pairs[[2]string{d.A, d.B}] = true
+		if d.A == "12ABC34501DE35" && !d.AValid {
+			t.Errorf("expected A to be valid in %+v", d)
+		}
+	}
+
+	for _, p := range [][2]string{
+		{"12ABC34501DE35", "12ABC34501DE53"},
+		{"12ABC34501DE35", "12ABC34501OE35"},
+		{"12ABC34501DE35", "12ABC3450DE35"},
+	} {
+		if !pairs[p] {
+			t.Errorf("missing pair %v in %+v", p, got)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("expected 3 pairs, got %+v", got)
+	}
+}
+
+func TestOneEdit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		ok   bool
+	}{
+		{"ABCD", "ABCD", false},
+		{"ABCD", "ABXD", true},
+		{"ABCD", "BACD", true},
+		{"ABCD", "ABC", true},
+		{"ABCD", "BCD", true},
+		{"ABCD", "ACBE", false},
+		{"ABCD", "AB", false},
+		{"ABCD", "ADCB", false},
+	}
+	for _, tt := range tests {
+		if got := oneEdit(tt.a, tt.b); got != tt.ok {
+			t.Errorf("oneEdit(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+var (
+	// regexSemMascara casa os candidatos sem máscara; com máscara, cnpj.Extract já
+	// devolve inclusive os de DV incorreto
+	regexSemMascara = cnpj.NewTextPattern(`[A-Z\d]{12}\d{2}`)
+	// regexNome indica uma linha ou cabeçalho que fala de CNPJ
+	regexNome = regexp.MustCompile(`(?i)cnpj|cgc`)
+)
+
+// Candidate é um possível CNPJ em um documento
+type Candidate struct {
+	Value string
+	// Start e End são as posições em bytes no documento
+	Start, End int
+	Masked     bool
+	// Err é o motivo da rejeição por cnpj.Validate, ou nil se o CNPJ é válido
+	Err error
+}
+
+// Find encontra os CNPJs de um documento. Valores com máscara são sempre
+// considerados; sem máscara, um valor com DV incorreto só é considerado quando a
+// própria linha ou a primeira linha do documento (o cabeçalho de um CSV, por
+// exemplo) citam CNPJ, pois 14 dígitos soltos também podem ser datas ou códigos.
+func Find(text string) []Candidate {
+	header, _, _ := strings.Cut(strings.TrimLeft(text, "\r\n"), "\n")
+	inHeader := regexNome.MatchString(header)
+
+	var out []Candidate
+	start := 0
+	for start <= len(text) {
+		end := strings.IndexByte(text[start:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		line := text[start:end]
+
+		for _, m := range cnpj.Extract(line) {
+			if m.Masked {
+				out = append(out, candidate(line, start, m.Start, m.End, true))
+			}
+		}
+		context := inHeader || regexNome.MatchString(line)
+		for _, loc := range regexSemMascara.FindAllIndex(line) {
+			c := candidate(line, start, loc[0], loc[1], false)
+			if c.Err == nil || context {
+				out = append(out, c)
+			}
+		}
+		start = end + 1
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+	return out
+}
+
+func candidate(line string, offset, start, end int, masked bool) Candidate {
+	v := line[start:end]
+	return Candidate{Value: v, Start: offset + start, End: offset + end, Masked: masked, Err: cnpj.Validate(v)}
+}
+
+// document é o texto de um documento aberto e o início de cada linha, para
+// converter posições em bytes nas posições do protocolo e vice-versa
+type document struct {
+	text  string
+	lines []int
+}
+
+func newDocument(text string) *document {
+	d := &document{text: text, lines: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	return d
+}
+
+// position converte uma posição em bytes em linha e unidade UTF-16
+func (d *document) position(offset int) Position {
+	line := sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > offset }) - 1
+	var units int
+	for _, r := range d.text[d.lines[line]:offset] {
+		units += utf16.RuneLen(r)
+	}
+	return Position{Line: line, Character: units}
+}
+
+// offset converte uma posição do protocolo em posição em bytes, limitada ao fim da linha
+func (d *document) offset(p Position) int {
+	if p.Line < 0 {
+		return 0
+	}
+	if p.Line >= len(d.lines) {
+		return len(d.text)
+	}
+	i := d.lines[p.Line]
+	for units := 0; i < len(d.text) && d.text[i] != '\n'; {
+		r, size := utf8.DecodeRuneInString(d.text[i:])
+		if units+utf16.RuneLen(r) > p.Character {
+			break
+		}
+		units += utf16.RuneLen(r)
+		i += size
+	}
+	return i
+}
+
+func (d *document) rangeOf(c Candidate) Range {
+	return Range{Start: d.position(c.Start), End: d.position(c.End)}
+}
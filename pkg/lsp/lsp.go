@@ -0,0 +1,352 @@
+// Package lsp implementa um servidor do Language Server Protocol que valida os
+// CNPJs de qualquer documento aberto no editor (YAML, CSV, JSON, código),
+// usando apenas a biblioteca padrão para o JSON-RPC sobre stdio.
+//
+// O servidor publica diagnósticos para CNPJs inválidos, mostra no hover o valor
+// formatado, a raiz, a ordem e o DV correto e oferece code actions para corrigir
+// o DV, aplicar ou remover a máscara e trocar o valor por um CNPJ fictício.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/redact"
+)
+
+// Source identifica os diagnósticos do servidor no editor
+const Source = "cnpj"
+
+var (
+	ErrSemShutdown = errors.New("o cliente encerrou o servidor sem enviar shutdown")
+	ErrCabecalho   = errors.New("mensagem sem Content-Length válido")
+
+	errSemParametros      = errors.New("parâmetros inválidos")
+	errMetodoDesconhecido = errors.New("método desconhecido")
+)
+
+// Server mantém os documentos abertos e responde às mensagens do cliente.
+// As mensagens são tratadas em ordem, uma de cada vez.
+type Server struct {
+	lang        i18n.Lang
+	docs        map[string]*document
+	fake        *redact.Redactor
+	out         *bufio.Writer
+	initialized bool
+	shutdown    bool
+}
+
+// NewServer cria um servidor cujas mensagens (diagnósticos, hover e títulos das
+// code actions) são escritas no idioma informado
+func NewServer(lang i18n.Lang) *Server {
+	fake, _ := redact.New(redact.Fake, nil)
+	return &Server{lang: lang, docs: map[string]*document{}, fake: fake}
+}
+
+// Serve lê as mensagens de r e escreve as respostas e notificações em w até
+// receber exit ou até r terminar. Retorna ErrSemShutdown se exit chegar antes
+// de shutdown, caso em que o processo deve terminar com código 1.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	s.out = bufio.NewWriter(w)
+
+	for {
+		body, err := readMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var m message
+		err = json.Unmarshal(body, &m)
+		switch {
+		case err != nil:
+			err = s.replyError(json.RawMessage("null"), codeParseError, err.Error())
+		case m.Method == "exit" && !s.shutdown:
+			return ErrSemShutdown
+		case m.Method == "exit":
+			return nil
+		default:
+			err = s.handle(m)
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.out.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// readMessage lê uma mensagem no formato do protocolo: cabeçalhos no estilo
+// HTTP, uma linha em branco e o corpo com o tamanho de Content-Length
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	n, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || n < 0 {
+		return nil, ErrCabecalho
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (s *Server) write(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.out.Write(body)
+	return err
+}
+
+func (s *Server) reply(id json.RawMessage, result any) error {
+	return s.write(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) replyError(id json.RawMessage, code int, text string) error {
+	return s.write(errorResponse{JSONRPC: "2.0", ID: id, Error: responseError{Code: code, Message: text}})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// handle trata uma mensagem. Parâmetros inválidos e métodos desconhecidos viram
+// respostas de erro (ou são ignorados em notificações); só erros de escrita
+// interrompem o servidor.
+func (s *Server) handle(m message) error {
+	request := len(m.ID) > 0
+	if !s.initialized && m.Method != "initialize" {
+		if request {
+			return s.replyError(m.ID, codeNotInitialized, "servidor não inicializado")
+		}
+		return nil
+	}
+
+	result, err := s.dispatch(m)
+	invalid := errors.Is(err, errSemParametros) || errors.Is(err, errMetodoDesconhecido)
+	switch {
+	case err != nil && !invalid:
+		return err
+	case !request:
+		return nil
+	case errors.Is(err, errMetodoDesconhecido):
+		return s.replyError(m.ID, codeMethodNotFound, "método desconhecido: "+m.Method)
+	case err != nil:
+		return s.replyError(m.ID, codeInvalidParams, err.Error())
+	}
+	return s.reply(m.ID, result)
+}
+
+func (s *Server) dispatch(m message) (any, error) {
+	switch m.Method {
+	case "initialize":
+		s.initialized = true
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   1, // documento inteiro a cada alteração
+				"hoverProvider":      true,
+				"codeActionProvider": map[string]any{"codeActionKinds": []string{KindQuickFix, KindRewrite}},
+			},
+			"serverInfo": map[string]string{"name": "alfanumeric-cnpj"},
+		}, nil
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := decode(m.Params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.open(p.TextDocument.URI, p.TextDocument.Text)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := decode(m.Params, &p); err != nil || len(p.ContentChanges) == 0 {
+			return nil, errSemParametros
+		}
+		return nil, s.open(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := decode(m.Params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+
+	case "textDocument/hover":
+		var p hoverParams
+		if err := decode(m.Params, &p); err != nil {
+			return nil, err
+		}
+		if h := s.Hover(p.TextDocument.URI, p.Position); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := decode(m.Params, &p); err != nil {
+			return nil, err
+		}
+		return s.CodeActions(p.TextDocument.URI, p.Range), nil
+	}
+	return nil, errMetodoDesconhecido
+}
+
+func decode(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return errSemParametros
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("%w: %v", errSemParametros, err)
+	}
+	return nil
+}
+
+// open guarda o texto do documento e publica os seus diagnósticos
+func (s *Server) open(uri, text string) error {
+	s.docs[uri] = newDocument(text)
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: s.Diagnostics(uri)})
+}
+
+// Diagnostics retorna um diagnóstico por CNPJ inválido do documento, com o
+// código estável do erro e o motivo no idioma do servidor
+func (s *Server) Diagnostics(uri string) []Diagnostic {
+	out := []Diagnostic{}
+	doc := s.docs[uri]
+	if doc == nil {
+		return out
+	}
+	for _, c := range Find(doc.text) {
+		if c.Err != nil {
+			out = append(out, s.diagnostic(doc, c))
+		}
+	}
+	return out
+}
+
+func (s *Server) diagnostic(doc *document, c Candidate) Diagnostic {
+	return Diagnostic{
+		Range:    doc.rangeOf(c),
+		Severity: SeverityError,
+		Code:     i18n.Code(c.Err),
+		Source:   Source,
+		Message:  i18n.Error(s.lang, c.Err),
+	}
+}
+
+// at retorna o candidato sob a posição, se houver
+func at(doc *document, offset int) (Candidate, bool) {
+	for _, c := range Find(doc.text) {
+		if c.Start <= offset && offset <= c.End {
+			return c, true
+		}
+	}
+	return Candidate{}, false
+}
+
+// Hover descreve o CNPJ sob a posição: valor formatado, validade, raiz, ordem e
+// o DV correto. Retorna nil fora de um CNPJ.
+func (s *Server) Hover(uri string, pos Position) *Hover {
+	doc := s.docs[uri]
+	if doc == nil {
+		return nil
+	}
+	c, ok := at(doc, doc.offset(pos))
+	if !ok {
+		return nil
+	}
+
+	v := cnpj.UnformattedCNPJ(c.Value)
+	lines := []string{i18n.T(s.lang, "lsp.hover.titulo", cnpj.FormatCNPJ(v))}
+	if c.Err == nil {
+		lines = append(lines, i18n.T(s.lang, "lsp.hover.valido"))
+	} else {
+		lines = append(lines, i18n.T(s.lang, "lsp.hover.invalido", i18n.Error(s.lang, c.Err)))
+	}
+
+	tipo := i18n.T(s.lang, "lsp.hover.filial")
+	if cnpj.IsMatriz(v) {
+		tipo = i18n.T(s.lang, "lsp.hover.matriz")
+	}
+	lines = append(lines, i18n.T(s.lang, "lsp.hover.raiz", cnpj.Raiz(v), cnpj.Ordem(v), tipo))
+	if dv, err := cnpj.CalculateDV(v[:12]); err == nil {
+		lines = append(lines, i18n.T(s.lang, "lsp.hover.dv", dv))
+	}
+
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: strings.Join(lines, "\n\n")},
+		Range:    doc.rangeOf(c),
+	}
+}
+
+// CodeActions oferece, para cada CNPJ no trecho, a correção do DV (quando só ele
+// está errado), a aplicação ou remoção da máscara e a troca por um CNPJ fictício
+// derivado do original, que preserva a máscara e a ordem do estabelecimento
+func (s *Server) CodeActions(uri string, rng Range) []CodeAction {
+	out := []CodeAction{}
+	doc := s.docs[uri]
+	if doc == nil {
+		return out
+	}
+	start, end := doc.offset(rng.Start), doc.offset(rng.End)
+
+	for _, c := range Find(doc.text) {
+		if c.End < start || c.Start > end {
+			continue
+		}
+		edit := func(title, kind, text string) CodeAction {
+			return CodeAction{
+				Title: title,
+				Kind:  kind,
+				Edit:  &WorkspaceEdit{Changes: map[string][]TextEdit{uri: {{Range: doc.rangeOf(c), NewText: text}}}},
+			}
+		}
+		v := cnpj.UnformattedCNPJ(c.Value)
+
+		if errors.Is(c.Err, cnpj.ErroDVIncorreto) {
+			dv, _ := cnpj.CalculateDV(v[:12])
+			fixed := v[:12] + dv
+			if c.Masked {
+				fixed = cnpj.FormatCNPJ(fixed)
+			}
+			a := edit(i18n.T(s.lang, "lsp.acao.dv", fixed), KindQuickFix, fixed)
+			a.Diagnostics = []Diagnostic{s.diagnostic(doc, c)}
+			a.IsPreferred = true
+			out = append(out, a)
+		}
+		if c.Masked {
+			out = append(out, edit(i18n.T(s.lang, "lsp.acao.semmascara", v), KindRewrite, v))
+		} else {
+			formatted := cnpj.FormatCNPJ(v)
+			out = append(out, edit(i18n.T(s.lang, "lsp.acao.mascara", formatted), KindRewrite, formatted))
+		}
+		fake := s.fake.Replace(c.Value)
+		out = append(out, edit(i18n.T(s.lang, "lsp.acao.ficticio", fake), KindRewrite, fake))
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+)
+
+// TestFind tests which values are taken as CNPJs in a document
+func TestFind(t *testing.T) {
+	text := "nome: Loja\n" +
+		"cnpj: 12ABC34501DE00\n" +
+		"data: 20240101120000\n" +
+		"filial: 12.ABC.345/01DE-00\n" +
+		"matriz: 11222333000181\n" +
+		"id_12ABC34501DE35\n"
+
+	var got []string
+	for _, c := range Find(text) {
+		got = append(got, fmt.Sprintf("%s:%v", text[c.Start:c.End], c.Err == nil))
+	}
+	want := []string{"12ABC34501DE00:false", "12.ABC.345/01DE-00:false", "11222333000181:true", "12ABC34501DE35:true"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Find = %v, want %v", got, want)
+	}
+
+	// in a CSV whose header names the column, every row counts
+	csv := "razao;cnpj\nLoja;20240101120000\n"
+	if c := Find(csv); len(c) != 1 || c[0].Value != "20240101120000" {
+		t.Errorf("Find(csv) = %+v, want the unmasked value", c)
+	}
+}
+
+// TestPositions tests the conversion between byte offsets and UTF-16 positions
+func TestPositions(t *testing.T) {
+	doc := newDocument("a\nçã 😀 x\n")
+	tests := []struct {
+		offset int
+		pos    Position
+	}{
+		{0, Position{0, 0}},
+		{2, Position{1, 0}},
+		{6, Position{1, 2}},
+		{11, Position{1, 5}},
+		{12, Position{1, 6}},
+		{13, Position{1, 7}},
+		{14, Position{2, 0}},
+	}
+	for _, tt := range tests {
+		if got := doc.position(tt.offset); got != tt.pos {
+			t.Errorf("position(%d) = %+v, want %+v", tt.offset, got, tt.pos)
+		}
+		if got := doc.offset(tt.pos); got != tt.offset {
+			t.Errorf("offset(%+v) = %d, want %d", tt.pos, got, tt.offset)
+		}
+	}
+	if got := doc.offset(Position{1, 99}); got != 13 {
+		t.Errorf("offset past the end of the line = %d, want 13", got)
+	}
+}
+
+type rawMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func frame(t *testing.T, buf *bytes.Buffer, v any) {
+	t.Helper()
+	body, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func request(id int, method string, params any) map[string]any {
+	return map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notify(method string, params any) map[string]any {
+	return map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+// serve runs a session and returns the messages written by the server
+func serve(t *testing.T, msgs ...map[string]any) ([]rawMessage, error) {
+	t.Helper()
+	var in, out bytes.Buffer
+	for _, m := range msgs {
+		frame(t, &in, m)
+	}
+	err := NewServer(i18n.PtBR).Serve(&in, &out)
+
+	var got []rawMessage
+	r := bufio.NewReader(&out)
+	for {
+		body, readErr := readMessage(r)
+		if readErr != nil {
+			break
+		}
+		var m rawMessage
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m)
+	}
+	return got, err
+}
+
+// TestServe tests a full session: diagnostics, hover and code actions
+func TestServe(t *testing.T) {
+	const uri = "file:///tmp/empresas.yaml"
+	text := "empresas:\n  - cnpj: \"12.ABC.345/01DE-00\"\n  - cnpj: 11222333000181\n"
+	doc := map[string]any{"uri": uri}
+
+	got, err := serve(t,
+		request(1, "initialize", map[string]any{}),
+		notify("initialized", map[string]any{}),
+		notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": "yaml", "version": 1, "text": text}}),
+		request(2, "textDocument/hover", map[string]any{"textDocument": doc, "position": Position{1, 15}}),
+		request(3, "textDocument/codeAction", map[string]any{"textDocument": doc, "range": Range{Position{1, 15}, Position{1, 15}}, "context": map[string]any{"diagnostics": []any{}}}),
+		request(4, "textDocument/hover", map[string]any{"textDocument": doc, "position": Position{0, 2}}),
+		request(5, "workspace/symbol", map[string]any{}),
+		request(6, "shutdown", nil),
+		notify("exit", nil),
+	)
+	if err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if len(got) != 7 {
+		t.Fatalf("got %d messages, want 7: %+v", len(got), got)
+	}
+
+	var diags publishDiagnosticsParams
+	if err := json.Unmarshal(got[1].Params, &diags); err != nil || got[1].Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("second message should publish diagnostics: %+v", got[1])
+	}
+	if len(diags.Diagnostics) != 1 {
+		t.Fatalf("diagnostics = %+v, want one", diags.Diagnostics)
+	}
+	d := diags.Diagnostics[0]
+	if d.Code != cnpj.ErroDVIncorreto.Code || d.Range != (Range{Position{1, 11}, Position{1, 29}}) {
+		t.Errorf("diagnostic = %+v", d)
+	}
+
+	var hover Hover
+	if err := json.Unmarshal(got[2].Result, &hover); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{"12.ABC.345/01DE-00", "12ABC345", "01DE", "`35`"} {
+		if !strings.Contains(hover.Contents.Value, part) {
+			t.Errorf("hover %q should contain %q", hover.Contents.Value, part)
+		}
+	}
+
+	var actions []CodeAction
+	if err := json.Unmarshal(got[3].Result, &actions); err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("code actions = %+v, want fix DV, remove mask and fake", actions)
+	}
+	wantEdits := []string{"12.ABC.345/01DE-35", "12ABC34501DE00"}
+	for i, want := range wantEdits {
+		if edits := actions[i].Edit.Changes[uri]; len(edits) != 1 || edits[0].NewText != want || edits[0].Range != d.Range {
+			t.Errorf("action %q edits = %+v, want %s", actions[i].Title, edits, want)
+		}
+	}
+	if actions[0].Kind != KindQuickFix || !actions[0].IsPreferred || len(actions[0].Diagnostics) != 1 {
+		t.Errorf("DV fix should be the preferred quick fix: %+v", actions[0])
+	}
+	if fake := actions[2].Edit.Changes[uri][0].NewText; !cnpj.IsValid(fake) || !strings.HasSuffix(cnpj.Ordem(fake), "01DE") {
+		t.Errorf("fake %s should be valid and keep the ordem", fake)
+	}
+
+	if string(got[4].Result) != "null" {
+		t.Errorf("hover outside a CNPJ = %s, want null", got[4].Result)
+	}
+	if got[5].Error == nil || got[5].Error.Code != codeMethodNotFound {
+		t.Errorf("unknown method should fail with %d: %+v", codeMethodNotFound, got[5])
+	}
+	if string(got[6].ID) != "6" || string(got[6].Result) != "null" {
+		t.Errorf("shutdown response = %+v", got[6])
+	}
+}
+
+// TestServe_Lifecycle tests requests before initialize and exit without shutdown
+func TestServe_Lifecycle(t *testing.T) {
+	got, err := serve(t,
+		request(1, "textDocument/hover", map[string]any{}),
+		request(2, "initialize", map[string]any{}),
+		notify("exit", nil),
+	)
+	if !errors.Is(err, ErrSemShutdown) {
+		t.Errorf("exit without shutdown = %v, want ErrSemShutdown", err)
+	}
+	if len(got) != 2 || got[0].Error == nil || got[0].Error.Code != codeNotInitialized {
+		t.Errorf("request before initialize should fail: %+v", got)
+	}
+
+	var in bytes.Buffer
+	in.WriteString("Content-Type: text/plain\r\n\r\n{}")
+	if err := NewServer(i18n.PtBR).Serve(&in, &bytes.Buffer{}); !errors.Is(err, ErrCabecalho) {
+		t.Errorf("missing Content-Length = %v, want ErrCabecalho", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package mocklookup é um servidor HTTP que imita as rotas e o formato JSON das
+// APIs públicas de consulta de CNPJ (BrasilAPI e ReceitaWS), para testes de
+// integração sem acesso à rede. Todo CNPJ válido tem uma empresa fictícia,
+// sempre a mesma para a mesma semente; os inválidos resultam em 400.
+//
+// Latência, falhas e limite de requisições são configuráveis, para exercitar a
+// lógica de novas tentativas dos clientes.
+package mocklookup
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/fixtures"
+)
+
+// Rotas imitadas; o CNPJ pode vir com ou sem máscara
+const (
+	BrasilAPIRoute = "GET /api/cnpj/v1/{cnpj...}"
+	ReceitaWSRoute = "GET /v1/cnpj/{cnpj...}"
+)
+
+// DefaultWindow é a janela do limite de requisições quando nenhuma é informada
+const DefaultWindow = time.Minute
+
+// Options controla o comportamento do servidor
+type Options struct {
+	// Seed escolhe o conjunto de empresas fictícias
+	Seed int64
+	// Latency atrasa todas as respostas; Jitter soma a ela um atraso sorteado entre 0 e Jitter
+	Latency, Jitter time.Duration
+	// ErrorRate é a fração das requisições, entre 0 e 1, que falham com ErrorStatus
+	ErrorRate float64
+	// ErrorStatus é o status das falhas injetadas; zero usa 500
+	ErrorStatus int
+	// FailFirst faz as primeiras FailFirst consultas de cada CNPJ falharem com ErrorStatus
+	FailFirst int
+	// RateLimit é o máximo de requisições por Window; acima dele a resposta é 429
+	// com Retry-After. Zero desliga o limite.
+	RateLimit int
+	// Window é a janela do RateLimit; zero usa DefaultWindow
+	Window time.Duration
+	// NotFound são CNPJs válidos que resultam em 404
+	NotFound []string
+}
+
+// Server é o http.Handler com as rotas imitadas
+type Server struct {
+	opts     Options
+	mux      *http.ServeMux
+	notFound map[string]bool
+
+	mu          sync.Mutex
+	r           *rand.Rand
+	attempts    map[string]int
+	windowStart time.Time
+	windowCount int
+	now         func() time.Time
+}
+
+// New cria o servidor
+func New(opts Options) *Server {
+	if opts.ErrorStatus == 0 {
+		opts.ErrorStatus = http.StatusInternalServerError
+	}
+	if opts.Window <= 0 {
+		opts.Window = DefaultWindow
+	}
+	s := &Server{
+		opts:     opts,
+		mux:      http.NewServeMux(),
+		notFound: map[string]bool{},
+		r:        rand.New(rand.NewSource(opts.Seed)),
+		attempts: map[string]int{},
+		now:      time.Now,
+	}
+	for _, v := range opts.NotFound {
+		s.notFound[cnpj.UnformattedCNPJ(v)] = true
+	}
+	s.mux.HandleFunc(BrasilAPIRoute, s.handle(brasilAPI{}))
+	s.mux.HandleFunc(ReceitaWSRoute, s.handle(receitaWS{}))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// provider monta os corpos das respostas no formato de cada API
+type provider interface {
+	found(e fixtures.Empresa, est fixtures.Estabelecimento) any
+	failure(status int, value string) any
+}
+
+func (s *Server) handle(p provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		value := r.PathValue("cnpj")
+		if !s.wait(r) {
+			return
+		}
+
+		if retry, limited := s.limited(); limited {
+			w.Header().Set("Retry-After", strconv.Itoa(retry))
+			writeJSON(w, http.StatusTooManyRequests, p.failure(http.StatusTooManyRequests, value))
+			return
+		}
+		if s.fail(cnpj.UnformattedCNPJ(value)) {
+			writeJSON(w, s.opts.ErrorStatus, p.failure(s.opts.ErrorStatus, value))
+			return
+		}
+
+		if !cnpj.IsValid(value) {
+			writeJSON(w, http.StatusBadRequest, p.failure(http.StatusBadRequest, value))
+			return
+		}
+		if s.notFound[cnpj.UnformattedCNPJ(value)] {
+			writeJSON(w, http.StatusNotFound, p.failure(http.StatusNotFound, value))
+			return
+		}
+
+		e, err := fixtures.ForCNPJ(value, fixtures.Options{Seed: s.opts.Seed, MaxSocios: 3})
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, p.failure(http.StatusBadRequest, value))
+			return
+		}
+		writeJSON(w, http.StatusOK, p.found(e, establishment(e)))
+	}
+}
+
+// wait aplica a latência configurada; retorna falso se o cliente desistiu antes
+func (s *Server) wait(r *http.Request) bool {
+	delay := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		s.mu.Lock()
+		delay += time.Duration(s.r.Int63n(int64(s.opts.Jitter) + 1))
+		s.mu.Unlock()
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// limited conta a requisição na janela atual e indica se ela passou do limite,
+// com os segundos até a próxima janela
+func (s *Server) limited() (int, bool) {
+	if s.opts.RateLimit <= 0 {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.opts.Window {
+		s.windowStart, s.windowCount = now, 0
+	}
+	s.windowCount++
+	if s.windowCount <= s.opts.RateLimit {
+		return 0, false
+	}
+	left := s.windowStart.Add(s.opts.Window).Sub(now)
+	return max(1, int(math.Ceil(left.Seconds()))), true
+}
+
+// fail decide se a requisição recebe uma falha injetada: as primeiras FailFirst
+// de cada CNPJ e, depois delas, uma fração ErrorRate sorteada
+func (s *Server) fail(value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts[value]++
+	if s.attempts[value] <= s.opts.FailFirst {
+		return true
+	}
+	return s.opts.ErrorRate > 0 && s.r.Float64() < s.opts.ErrorRate
+}
+
+// establishment é o estabelecimento consultado: a filial, se houver, ou a matriz
+func establishment(e fixtures.Empresa) fixtures.Estabelecimento {
+	if len(e.Filiais) > 0 {
+		return e.Filiais[0]
+	}
+	return fixtures.Estabelecimento{
+		CNPJ:              e.CNPJ,
+		NomeFantasia:      e.NomeFantasia,
+		DataAbertura:      e.DataAbertura,
+		InscricaoEstadual: e.InscricaoEstadual,
+		Endereco:          e.Endereco,
+	}
+}
@@ -0,0 +1,123 @@
+package mocklookup
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// get faz a requisição e decodifica o corpo JSON
+func get(t *testing.T, s *Server, path string) (int, http.Header, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s: %v (%s)", path, err, rec.Body.String())
+	}
+	return rec.Code, rec.Header(), body
+}
+
+// TestBrasilAPI tests the BrasilAPI route and response shape
+func TestBrasilAPI(t *testing.T) {
+	s := New(Options{Seed: 1, NotFound: []string{"11.222.333/0001-81"}})
+
+	status, _, body := get(t, s, "/api/cnpj/v1/12.ABC.345/01DE-35")
+	if status != http.StatusOK || body["cnpj"] != "12ABC34501DE35" || body["descricao_identificador_matriz_filial"] != "FILIAL" {
+		t.Fatalf("status %d, body %v", status, body)
+	}
+	for _, field := range []string{"razao_social", "cnae_fiscal", "natureza_juridica", "cep", "uf", "municipio", "qsa", "cnaes_secundarios"} {
+		if _, ok := body[field]; !ok {
+			t.Errorf("missing field %s", field)
+		}
+	}
+
+	_, _, again := get(t, s, "/api/cnpj/v1/12ABC34501DE35")
+	_, _, matriz := get(t, s, "/api/cnpj/v1/12ABC345000188")
+	if again["razao_social"] != body["razao_social"] || matriz["razao_social"] != body["razao_social"] || matriz["identificador_matriz_filial"] != 1.0 {
+		t.Errorf("the same raiz should always describe the same company: %v / %v", again["razao_social"], matriz)
+	}
+	if _, _, other := get(t, New(Options{Seed: 2}), "/api/cnpj/v1/12ABC34501DE35"); other["razao_social"] == body["razao_social"] && other["cep"] == body["cep"] {
+		t.Error("another seed should describe another company")
+	}
+
+	if status, _, body := get(t, s, "/api/cnpj/v1/12ABC34501DE36"); status != http.StatusBadRequest || body["type"] != "bad_request" {
+		t.Errorf("invalid CNPJ: %d %v", status, body)
+	}
+	if status, _, body := get(t, s, "/api/cnpj/v1/11222333000181"); status != http.StatusNotFound || body["type"] != "not_found" {
+		t.Errorf("not found: %d %v", status, body)
+	}
+}
+
+// TestReceitaWS tests the ReceitaWS route and response shape
+func TestReceitaWS(t *testing.T) {
+	s := New(Options{Seed: 1})
+
+	status, _, body := get(t, s, "/v1/cnpj/12ABC345000188")
+	if status != http.StatusOK || body["status"] != "OK" || body["cnpj"] != "12.ABC.345/0001-88" || body["tipo"] != "MATRIZ" {
+		t.Fatalf("status %d, body %v", status, body)
+	}
+	atividades, _ := body["atividade_principal"].([]any)
+	if len(atividades) != 1 || len(body["abertura"].(string)) != 10 {
+		t.Errorf("atividade_principal %v, abertura %v", body["atividade_principal"], body["abertura"])
+	}
+
+	if status, _, body := get(t, s, "/v1/cnpj/123"); status != http.StatusBadRequest || body["status"] != "ERROR" {
+		t.Errorf("invalid CNPJ: %d %v", status, body)
+	}
+}
+
+// TestFailures tests the injected failures and the rate limit
+func TestFailures(t *testing.T) {
+	s := New(Options{FailFirst: 2, ErrorStatus: http.StatusServiceUnavailable})
+	for i, want := range []int{503, 503, 200, 200} {
+		if status, _, _ := get(t, s, "/api/cnpj/v1/12ABC34501DE35"); status != want {
+			t.Errorf("attempt %d: status %d, want %d", i+1, status, want)
+		}
+	}
+	if status, _, _ := get(t, s, "/api/cnpj/v1/12ABC345000188"); status != 503 {
+		t.Errorf("the first attempt of another CNPJ should fail, got %d", status)
+	}
+
+	s = New(Options{ErrorRate: 1})
+	if status, _, body := get(t, s, "/v1/cnpj/12ABC345000188"); status != 500 || body["status"] != "ERROR" {
+		t.Errorf("error rate 1: %d %v", status, body)
+	}
+
+	now := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+	s = New(Options{RateLimit: 2, Window: time.Minute})
+	s.now = func() time.Time { return now }
+	for i, want := range []int{200, 200, 429} {
+		status, header, _ := get(t, s, "/api/cnpj/v1/12ABC34501DE35")
+		if status != want {
+			t.Errorf("request %d: status %d, want %d", i+1, status, want)
+		}
+		if want == 429 && header.Get("Retry-After") != "60" {
+			t.Errorf("Retry-After = %q", header.Get("Retry-After"))
+		}
+	}
+	now = now.Add(time.Minute)
+	if status, _, _ := get(t, s, "/api/cnpj/v1/12ABC34501DE35"); status != 200 {
+		t.Errorf("a new window should accept requests, got %d", status)
+	}
+}
+
+// TestLatency tests that the latency delays the response and yields to a cancelled request
+func TestLatency(t *testing.T) {
+	s := New(Options{Latency: 30 * time.Millisecond})
+	start := time.Now()
+	get(t, s, "/api/cnpj/v1/12ABC34501DE35")
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("response after %s, want at least 30ms", elapsed)
+	}
+
+	s = New(Options{Latency: time.Hour})
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	if _, err := client.Get(srv.URL + "/api/cnpj/v1/12ABC34501DE35"); err == nil {
+		t.Error("request should time out")
+	}
+}
@@ -0,0 +1,308 @@
+package mocklookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/fixtures"
+)
+
+// writeJSON escreve o corpo com o status informado
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// brasilAPI imita GET /api/cnpj/v1/{cnpj} da BrasilAPI
+type brasilAPI struct{}
+
+type brasilAPICNAE struct {
+	Codigo    int    `json:"codigo"`
+	Descricao string `json:"descricao"`
+}
+
+type brasilAPISocio struct {
+	IdentificadorDeSocio    int    `json:"identificador_de_socio"`
+	NomeSocio               string `json:"nome_socio"`
+	CNPJCPFDoSocio          string `json:"cnpj_cpf_do_socio"`
+	CodigoQualificacaoSocio int    `json:"codigo_qualificacao_socio"`
+	QualificacaoSocio       string `json:"qualificacao_socio"`
+	DataEntradaSociedade    string `json:"data_entrada_sociedade"`
+	FaixaEtaria             string `json:"faixa_etaria"`
+}
+
+type brasilAPIEmpresa struct {
+	CNPJ                               string           `json:"cnpj"`
+	IdentificadorMatrizFilial          int              `json:"identificador_matriz_filial"`
+	DescricaoIdentificadorMatrizFilial string           `json:"descricao_identificador_matriz_filial"`
+	RazaoSocial                        string           `json:"razao_social"`
+	NomeFantasia                       string           `json:"nome_fantasia"`
+	SituacaoCadastral                  int              `json:"situacao_cadastral"`
+	DescricaoSituacaoCadastral         string           `json:"descricao_situacao_cadastral"`
+	DataSituacaoCadastral              string           `json:"data_situacao_cadastral"`
+	DataInicioAtividade                string           `json:"data_inicio_atividade"`
+	CNAEFiscal                         int              `json:"cnae_fiscal"`
+	CNAEFiscalDescricao                string           `json:"cnae_fiscal_descricao"`
+	CNAEsSecundarios                   []brasilAPICNAE  `json:"cnaes_secundarios"`
+	CodigoNaturezaJuridica             int              `json:"codigo_natureza_juridica"`
+	NaturezaJuridica                   string           `json:"natureza_juridica"`
+	CodigoPorte                        int              `json:"codigo_porte"`
+	Porte                              string           `json:"porte"`
+	CapitalSocial                      float64          `json:"capital_social"`
+	DescricaoTipoDeLogradouro          string           `json:"descricao_tipo_de_logradouro"`
+	Logradouro                         string           `json:"logradouro"`
+	Numero                             string           `json:"numero"`
+	Complemento                        string           `json:"complemento"`
+	Bairro                             string           `json:"bairro"`
+	CEP                                string           `json:"cep"`
+	UF                                 string           `json:"uf"`
+	Municipio                          string           `json:"municipio"`
+	DDDTelefone1                       string           `json:"ddd_telefone_1"`
+	Email                              *string          `json:"email"`
+	OpcaoPeloSimples                   bool             `json:"opcao_pelo_simples"`
+	OpcaoPeloMEI                       bool             `json:"opcao_pelo_mei"`
+	QSA                                []brasilAPISocio `json:"qsa"`
+}
+
+type brasilAPIErro struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+func (brasilAPI) found(e fixtures.Empresa, est fixtures.Estabelecimento) any {
+	natureza, naturezaDesc := splitCode(e.NaturezaJuridica)
+	porte, porteDesc := porte(e)
+	tipo, logradouro := splitLogradouro(est.Endereco.Logradouro)
+
+	out := brasilAPIEmpresa{
+		CNPJ:                               est.CNPJ,
+		IdentificadorMatrizFilial:          2,
+		DescricaoIdentificadorMatrizFilial: "FILIAL",
+		RazaoSocial:                        strings.ToUpper(e.RazaoSocial),
+		NomeFantasia:                       strings.ToUpper(est.NomeFantasia),
+		SituacaoCadastral:                  2,
+		DescricaoSituacaoCadastral:         "ATIVA",
+		DataSituacaoCadastral:              est.DataAbertura,
+		DataInicioAtividade:                est.DataAbertura,
+		CNAEFiscal:                         atoi(digits(e.CNAEPrincipal.Codigo)),
+		CNAEFiscalDescricao:                e.CNAEPrincipal.Descricao,
+		CNAEsSecundarios:                   []brasilAPICNAE{},
+		CodigoNaturezaJuridica:             atoi(digits(natureza)),
+		NaturezaJuridica:                   naturezaDesc,
+		CodigoPorte:                        porte,
+		Porte:                              porteDesc,
+		DescricaoTipoDeLogradouro:          strings.ToUpper(tipo),
+		Logradouro:                         strings.ToUpper(logradouro),
+		Numero:                             est.Endereco.Numero,
+		Bairro:                             strings.ToUpper(est.Endereco.Bairro),
+		CEP:                                digits(est.Endereco.CEP),
+		UF:                                 est.Endereco.UF,
+		Municipio:                          strings.ToUpper(est.Endereco.Municipio),
+		OpcaoPeloSimples:                   porte == 1,
+		QSA:                                []brasilAPISocio{},
+	}
+	if cnpj.IsMatriz(est.CNPJ) {
+		out.IdentificadorMatrizFilial, out.DescricaoIdentificadorMatrizFilial = 1, "MATRIZ"
+	}
+	for _, c := range e.CNAESecundarios {
+		out.CNAEsSecundarios = append(out.CNAEsSecundarios, brasilAPICNAE{Codigo: atoi(digits(c.Codigo)), Descricao: c.Descricao})
+	}
+	for _, s := range e.Socios {
+		code, desc := splitCode(s.Qualificacao)
+		out.QSA = append(out.QSA, brasilAPISocio{
+			IdentificadorDeSocio:    2,
+			NomeSocio:               strings.ToUpper(s.Nome),
+			CNPJCPFDoSocio:          maskCPF(s.CPF),
+			CodigoQualificacaoSocio: atoi(code),
+			QualificacaoSocio:       desc,
+			DataEntradaSociedade:    s.DataEntrada,
+		})
+	}
+	return out
+}
+
+func (brasilAPI) failure(status int, value string) any {
+	switch status {
+	case http.StatusBadRequest:
+		return brasilAPIErro{"BadRequestError", fmt.Sprintf("CNPJ %s inválido.", value), "bad_request"}
+	case http.StatusNotFound:
+		return brasilAPIErro{"NotFoundError", fmt.Sprintf("CNPJ %s não encontrado.", value), "not_found"}
+	case http.StatusTooManyRequests:
+		return brasilAPIErro{"TooManyRequestsError", "Limite de requisições excedido.", "rate_limit"}
+	}
+	return brasilAPIErro{"InternalError", "Erro ao consultar o CNPJ.", "service_error"}
+}
+
+// receitaWS imita GET /v1/cnpj/{cnpj} da ReceitaWS
+type receitaWS struct{}
+
+type receitaWSAtividade struct {
+	Code string `json:"code"`
+	Text string `json:"text"`
+}
+
+type receitaWSSocio struct {
+	Nome string `json:"nome"`
+	Qual string `json:"qual"`
+}
+
+type receitaWSEmpresa struct {
+	Status                string               `json:"status"`
+	UltimaAtualizacao     string               `json:"ultima_atualizacao"`
+	CNPJ                  string               `json:"cnpj"`
+	Tipo                  string               `json:"tipo"`
+	Porte                 string               `json:"porte"`
+	Nome                  string               `json:"nome"`
+	Fantasia              string               `json:"fantasia"`
+	Abertura              string               `json:"abertura"`
+	AtividadePrincipal    []receitaWSAtividade `json:"atividade_principal"`
+	AtividadesSecundarias []receitaWSAtividade `json:"atividades_secundarias"`
+	NaturezaJuridica      string               `json:"natureza_juridica"`
+	Logradouro            string               `json:"logradouro"`
+	Numero                string               `json:"numero"`
+	Complemento           string               `json:"complemento"`
+	CEP                   string               `json:"cep"`
+	Bairro                string               `json:"bairro"`
+	Municipio             string               `json:"municipio"`
+	UF                    string               `json:"uf"`
+	Email                 string               `json:"email"`
+	Telefone              string               `json:"telefone"`
+	EFR                   string               `json:"efr"`
+	Situacao              string               `json:"situacao"`
+	DataSituacao          string               `json:"data_situacao"`
+	MotivoSituacao        string               `json:"motivo_situacao"`
+	SituacaoEspecial      string               `json:"situacao_especial"`
+	DataSituacaoEspecial  string               `json:"data_situacao_especial"`
+	CapitalSocial         string               `json:"capital_social"`
+	QSA                   []receitaWSSocio     `json:"qsa"`
+}
+
+type receitaWSErro struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func (receitaWS) found(e fixtures.Empresa, est fixtures.Estabelecimento) any {
+	_, porteDesc := porte(e)
+	out := receitaWSEmpresa{
+		Status:                "OK",
+		UltimaAtualizacao:     est.DataAbertura + "T00:00:00.000Z",
+		CNPJ:                  cnpj.FormatCNPJ(est.CNPJ),
+		Tipo:                  "FILIAL",
+		Porte:                 porteDesc,
+		Nome:                  strings.ToUpper(e.RazaoSocial),
+		Fantasia:              strings.ToUpper(est.NomeFantasia),
+		Abertura:              brDate(est.DataAbertura),
+		AtividadePrincipal:    []receitaWSAtividade{{Code: cnaeCode(e.CNAEPrincipal.Codigo), Text: e.CNAEPrincipal.Descricao}},
+		AtividadesSecundarias: []receitaWSAtividade{},
+		NaturezaJuridica:      e.NaturezaJuridica,
+		Logradouro:            strings.ToUpper(est.Endereco.Logradouro),
+		Numero:                est.Endereco.Numero,
+		CEP:                   cepMask(est.Endereco.CEP),
+		Bairro:                strings.ToUpper(est.Endereco.Bairro),
+		Municipio:             strings.ToUpper(est.Endereco.Municipio),
+		UF:                    est.Endereco.UF,
+		Situacao:              "ATIVA",
+		DataSituacao:          brDate(est.DataAbertura),
+		CapitalSocial:         "0.00",
+		QSA:                   []receitaWSSocio{},
+	}
+	if cnpj.IsMatriz(est.CNPJ) {
+		out.Tipo = "MATRIZ"
+	}
+	for _, c := range e.CNAESecundarios {
+		out.AtividadesSecundarias = append(out.AtividadesSecundarias, receitaWSAtividade{Code: cnaeCode(c.Codigo), Text: c.Descricao})
+	}
+	for _, s := range e.Socios {
+		code, desc := splitCode(s.Qualificacao)
+		out.QSA = append(out.QSA, receitaWSSocio{Nome: strings.ToUpper(s.Nome), Qual: code + "-" + desc})
+	}
+	return out
+}
+
+func (receitaWS) failure(status int, _ string) any {
+	switch status {
+	case http.StatusBadRequest:
+		return receitaWSErro{"ERROR", "CNPJ inválido"}
+	case http.StatusNotFound:
+		return receitaWSErro{"ERROR", "CNPJ não encontrado"}
+	case http.StatusTooManyRequests:
+		return receitaWSErro{"ERROR", "Too many requests, please try again later."}
+	}
+	return receitaWSErro{"ERROR", "Erro ao consultar o CNPJ"}
+}
+
+// porte classifica as limitadas como microempresas optantes pelo Simples e as
+// sociedades anônimas como demais
+func porte(e fixtures.Empresa) (int, string) {
+	if strings.HasPrefix(e.NaturezaJuridica, "205") {
+		return 5, "DEMAIS"
+	}
+	return 1, "MICRO EMPRESA"
+}
+
+// splitCode separa "206-2 - Sociedade Empresária Limitada" em código e descrição
+func splitCode(v string) (string, string) {
+	code, desc, _ := strings.Cut(v, " - ")
+	return code, desc
+}
+
+// splitLogradouro separa o tipo ("Rua", "Avenida") do nome do logradouro
+func splitLogradouro(v string) (string, string) {
+	tipo, nome, _ := strings.Cut(v, " ")
+	return tipo, nome
+}
+
+func digits(v string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, v)
+}
+
+func atoi(v string) int {
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+// maskCPF oculta o CPF como na publicação da Receita: ***456789**
+func maskCPF(cpf string) string {
+	if len(cpf) != 11 {
+		return cpf
+	}
+	return "***" + cpf[3:9] + "**"
+}
+
+// brDate converte AAAA-MM-DD em DD/MM/AAAA
+func brDate(v string) string {
+	if len(v) != 10 {
+		return v
+	}
+	return v[8:] + "/" + v[5:7] + "/" + v[:4]
+}
+
+// cnaeCode formata a subclasse como a ReceitaWS: 47.11-3-02
+func cnaeCode(v string) string {
+	d := digits(v)
+	if len(d) != 7 {
+		return v
+	}
+	return d[:2] + "." + d[2:4] + "-" + d[4:5] + "-" + d[5:]
+}
+
+// cepMask formata o CEP como a ReceitaWS: 01.311-902
+func cepMask(v string) string {
+	d := digits(v)
+	if len(d) != 8 {
+		return v
+	}
+	return d[:2] + "." + d[2:5] + "-" + d[5:]
+}
@@ -0,0 +1,195 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+)
+
+// postgresMetadata lê colunas, restrições CHECK (de tabelas e domínios) e
+// índices de todos os schemas de usuário
+func postgresMetadata(ctx context.Context, db *sql.DB) (metadata, error) {
+	var meta metadata
+
+	err := each(ctx, db, `
+SELECT c.table_schema, c.table_name, c.column_name, c.data_type,
+       coalesce(c.character_maximum_length, 0), coalesce(c.domain_name, '')
+  FROM information_schema.columns c
+  JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+ WHERE t.table_type = 'BASE TABLE' AND c.table_schema NOT IN ('pg_catalog', 'information_schema')
+ ORDER BY c.table_schema, c.table_name, c.ordinal_position`, func(rows *sql.Rows) error {
+		var ci columnInfo
+		if err := rows.Scan(&ci.schema, &ci.table, &ci.name, &ci.dataType, &ci.length, &ci.domain); err != nil {
+			return err
+		}
+		meta.columns = append(meta.columns, ci)
+		return nil
+	})
+	if err != nil {
+		return meta, err
+	}
+
+	err = each(ctx, db, `
+SELECT n.nspname, coalesce(cl.relname, ''), coalesce(ty.typname, ''), con.conname, pg_get_constraintdef(con.oid)
+  FROM pg_constraint con
+  LEFT JOIN pg_class cl ON cl.oid = con.conrelid
+  LEFT JOIN pg_type ty ON ty.oid = con.contypid
+  JOIN pg_namespace n ON n.oid = con.connamespace
+ WHERE con.contype = 'c' AND n.nspname NOT IN ('pg_catalog', 'information_schema')`, func(rows *sql.Rows) error {
+		var c constraintInfo
+		if err := rows.Scan(&c.schema, &c.table, &c.domain, &c.name, &c.def); err != nil {
+			return err
+		}
+		meta.constraints = append(meta.constraints, c)
+		return nil
+	})
+	if err != nil {
+		return meta, err
+	}
+
+	err = each(ctx, db, `
+SELECT schemaname, tablename, indexname, indexdef
+  FROM pg_indexes
+ WHERE schemaname NOT IN ('pg_catalog', 'information_schema')`, func(rows *sql.Rows) error {
+		var ix indexInfo
+		if err := rows.Scan(&ix.schema, &ix.table, &ix.name, &ix.def); err != nil {
+			return err
+		}
+		meta.indexes = append(meta.indexes, ix)
+		return nil
+	})
+	return meta, err
+}
+
+// sqliteMetadata lê as tabelas de sqlite_master, as colunas com pragma
+// table_info, as restrições CHECK do CREATE TABLE, os gatilhos e os índices
+func sqliteMetadata(ctx context.Context, db *sql.DB) (metadata, error) {
+	var meta metadata
+
+	type table struct{ name, sql string }
+	var tables []table
+	err := each(ctx, db, `
+SELECT name, sql FROM sqlite_master
+ WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND sql NOT LIKE 'CREATE VIRTUAL%'
+ ORDER BY name`, func(rows *sql.Rows) error {
+		var t table
+		if err := rows.Scan(&t.name, &t.sql); err != nil {
+			return err
+		}
+		tables = append(tables, t)
+		return nil
+	})
+	if err != nil {
+		return meta, err
+	}
+
+	for _, t := range tables {
+		err := each(ctx, db, `SELECT name, type FROM pragma_table_info(?) ORDER BY cid`, func(rows *sql.Rows) error {
+			ci := columnInfo{table: t.name}
+			if err := rows.Scan(&ci.name, &ci.dataType); err != nil {
+				return err
+			}
+			meta.columns = append(meta.columns, ci)
+			return nil
+		}, t.name)
+		if err != nil {
+			return meta, err
+		}
+
+		for _, c := range extractChecks(t.sql) {
+			c.table = t.name
+			meta.constraints = append(meta.constraints, c)
+		}
+	}
+
+	err = each(ctx, db, `
+SELECT type, name, tbl_name, sql FROM sqlite_master
+ WHERE type IN ('index', 'trigger') AND sql IS NOT NULL`, func(rows *sql.Rows) error {
+		var kind, name, tbl, def string
+		if err := rows.Scan(&kind, &name, &tbl, &def); err != nil {
+			return err
+		}
+		if kind == "index" {
+			meta.indexes = append(meta.indexes, indexInfo{table: tbl, name: name, def: def})
+		} else {
+			meta.constraints = append(meta.constraints, constraintInfo{table: tbl, name: name, def: def, trigger: true})
+		}
+		return nil
+	})
+	return meta, err
+}
+
+// each executa a consulta e chama fn para cada linha
+func each(ctx context.Context, db *sql.DB, query string, fn func(*sql.Rows) error, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+var (
+	regexCheck          = regexp.MustCompile(`(?i)\bcheck\s*\(`)
+	regexNomeConstraint = regexp.MustCompile(`(?i)\bconstraint\s+("[^"]+"|\S+)\s*$`)
+)
+
+// extractChecks separa as cláusulas CHECK de um CREATE TABLE do SQLite. O nome
+// é o da cláusula CONSTRAINT que a precede, quando houver.
+func extractChecks(createTable string) []constraintInfo {
+	var out []constraintInfo
+	for offset := 0; ; {
+		loc := regexCheck.FindStringIndex(createTable[offset:])
+		if loc == nil {
+			return out
+		}
+		start, open := offset+loc[0], offset+loc[1]-1
+
+		end := matchParen(createTable, open)
+		if end < 0 {
+			return out
+		}
+
+		c := constraintInfo{def: createTable[start : end+1]}
+		if m := regexNomeConstraint.FindStringSubmatch(createTable[:start]); m != nil {
+			c.name = strings.Trim(m[1], `"`)
+		}
+		out = append(out, c)
+		offset = end + 1
+	}
+}
+
+// matchParen retorna a posição do parêntese que fecha s[open], ignorando
+// parênteses dentro de literais, ou -1
+func matchParen(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,151 @@
+package readiness
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+var regexIdentSimples = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ident só delimita o identificador quando necessário, para um plano legível
+func ident(name string) string {
+	if regexIdentSimples.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+func (c Column) table() string {
+	if c.Schema == "" {
+		return ident(c.Tabela)
+	}
+	return ident(c.Schema) + "." + ident(c.Tabela)
+}
+
+// plan monta a migração das colunas com problemas: remove as restrições e os
+// índices que supõem dígitos, troca o tipo e recria a validação com as funções
+// geradas por sqlddl
+func plan(dialect sqlddl.Dialect, cols []Column, meta metadata) []string {
+	out := []string{}
+	for _, c := range sortedColumns(cols) {
+		if c.Ready() {
+			continue
+		}
+		out = append(out, fmt.Sprintf("-- %s.%s (%s)", c.table(), ident(c.Coluna), c.Tipo))
+		if dialect == sqlddl.Postgres {
+			out = append(out, postgresPlan(c, meta)...)
+		} else {
+			out = append(out, sqlitePlan(c, meta)...)
+		}
+		out = append(out, "")
+	}
+	return out
+}
+
+func postgresPlan(c Column, meta metadata) []string {
+	var (
+		before, after []string
+		column        = ident(c.Coluna)
+	)
+	for _, p := range c.Problemas {
+		switch p.Codigo {
+		case TipoNumerico:
+			before = append(before, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE varchar(14) USING lpad(%s::text, 14, '0');", c.table(), column, column))
+		case TamanhoInsuficiente:
+			before = append(before, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE varchar(14);", c.table(), column))
+		}
+	}
+
+	var out []string
+	for _, p := range c.Problemas {
+		switch {
+		case p.TipoObjeto == ObjetoDominio:
+			// A restrição vale para todas as colunas do domínio
+			domain := qualified(c.Schema, p.Objeto)
+			for _, ci := range meta.constraints {
+				if ci.name == p.Objeto && ci.domain != "" {
+					domain = qualified(ci.schema, ci.domain)
+				}
+			}
+			out = append(out, fmt.Sprintf("ALTER DOMAIN %s DROP CONSTRAINT %s;", domain, ident(p.Objeto)))
+			if len(before) > 0 {
+				// A coluna deixa de usar o domínio ao trocar de tipo
+				continue
+			}
+			after = append(after, fmt.Sprintf("ALTER DOMAIN %s ADD CONSTRAINT %s CHECK (cnpj_is_valid(VALUE)) NOT VALID;", domain, ident(p.Objeto)))
+		case p.Codigo == CheckSoDigitos:
+			out = append(out, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", c.table(), ident(p.Objeto)))
+			after = append(after, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (cnpj_is_valid(%s)) NOT VALID;", c.table(), ident(p.Objeto), column))
+		case p.Codigo == IndiceNumerico:
+			out = append(out, fmt.Sprintf("DROP INDEX %s;", qualified(c.Schema, p.Objeto)))
+			after = append(after, fmt.Sprintf("CREATE INDEX %s ON %s (%s);", ident(p.Objeto), c.table(), column))
+		}
+	}
+	out = append(out, before...)
+	if len(after) > 0 {
+		out = append(out, "-- recria a validação com as funções de 'sql ddl' e os índices sem conversão numérica")
+		out = append(out, after...)
+	}
+	return out
+}
+
+func sqlitePlan(c Column, meta metadata) []string {
+	var (
+		out, after []string
+		column     = ident(c.Coluna)
+		numeric    bool
+		checks     []Problem
+	)
+	for _, p := range c.Problemas {
+		switch p.Codigo {
+		case TipoNumerico:
+			numeric = true
+		case CheckSoDigitos:
+			if p.TipoObjeto == ObjetoGatilho {
+				out = append(out, fmt.Sprintf("DROP TRIGGER %s;", ident(p.Objeto)))
+				continue
+			}
+			checks = append(checks, p)
+		case IndiceNumerico:
+			out = append(out, fmt.Sprintf("DROP INDEX %s;", ident(p.Objeto)))
+			after = append(after, fmt.Sprintf("CREATE INDEX %s ON %s (%s);", ident(p.Objeto), c.table(), column))
+		}
+	}
+
+	for _, p := range checks {
+		name := "CHECK"
+		if p.Objeto != "" {
+			name = p.Objeto
+		}
+		out = append(out, fmt.Sprintf("-- a restrição %s faz parte do CREATE TABLE e o SQLite não permite removê-la:", name),
+			"-- recrie a tabela sem ela (https://www.sqlite.org/lang_altertable.html#otheralter), validando",
+			fmt.Sprintf("-- com a expressão gerada por 'sql ddl --dialect sqlite --column %s'", c.Coluna))
+	}
+
+	if numeric {
+		// DROP COLUMN falha com índices na coluna: eles são removidos e recriados
+		for _, ix := range meta.indexes {
+			if ix.table == c.Tabela && mentions(ix.def, c.Coluna) && !AssumesDigits(ix.def) {
+				out = append(out, fmt.Sprintf("DROP INDEX %s;", ident(ix.name)))
+				after = append(after, ix.def+";")
+			}
+		}
+		tmp := ident(c.Coluna + "__texto")
+		out = append(out,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TEXT;", c.table(), tmp),
+			fmt.Sprintf("UPDATE %s SET %s = CASE WHEN typeof(%s) IN ('integer', 'real') THEN printf('%%014d', %s) ELSE %s END;", c.table(), tmp, column, column, column),
+			fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", c.table(), column),
+			fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", c.table(), tmp, column),
+		)
+	}
+	return append(out, after...)
+}
+
+func qualified(schema, name string) string {
+	if schema == "" {
+		return ident(name)
+	}
+	return ident(schema) + "." + ident(name)
+}
@@ -0,0 +1,350 @@
+// Package readiness verifica se um schema está pronto para CNPJs alfanuméricos:
+// localiza as colunas de CNPJ pelo nome ou por amostragem do conteúdo e aponta
+// tipos numéricos, tamanhos insuficientes, restrições que só aceitam dígitos e
+// índices que convertem o valor para número, com um plano de migração.
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+// Códigos dos problemas encontrados
+const (
+	TipoNumerico        = "TIPO_NUMERICO"
+	TamanhoInsuficiente = "TAMANHO_INSUFICIENTE"
+	CheckSoDigitos      = "CHECK_SO_DIGITOS"
+	IndiceNumerico      = "INDICE_NUMERICO"
+)
+
+// DefaultSample é a quantidade de valores lidos por coluna na amostragem
+const DefaultSample = 100
+
+// regexNome reconhece colunas de CNPJ pelo nome
+var regexNome = regexp.MustCompile(`(?i)cnpj|cgc`)
+
+// digitOnly reconhece trechos de SQL que supõem um CNPJ só com dígitos:
+// expressões regulares e GLOBs de dígitos repetidos e conversões para número.
+// Classes como [0-9]{2} (os DVs) ou [0-9A-Z] não são suspeitas.
+var digitOnly = []*regexp.Regexp{
+	regexp.MustCompile(`\[0-9\]\s*(\{\s*(1[0-4]|[89])|[+*])`),
+	regexp.MustCompile(`\\d\s*(\{\s*(1[0-4]|[89])|[+*])`),
+	regexp.MustCompile(`\[\[:digit:\]\]\s*(\{|[+*])`),
+	regexp.MustCompile(`\[\^0-9\]|\[\^\\d\]|\\D`),
+	regexp.MustCompile(`(\[0-9\]){8,}`),
+	regexp.MustCompile(`(?i)::\s*(bigint|int[248]?|integer|numeric|decimal)\b`),
+	regexp.MustCompile(`(?i)\bcast\s*\(.*\bas\s+(bigint|int|integer|numeric|decimal)\b`),
+}
+
+// AssumesDigits indica se o trecho de SQL (restrição, índice ou gatilho) supõe
+// que o CNPJ tem apenas dígitos
+func AssumesDigits(def string) bool {
+	for _, re := range digitOnly {
+		if re.MatchString(def) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tipos de objeto de um Problem
+const (
+	ObjetoRestricao = "restricao"
+	ObjetoDominio   = "dominio"
+	ObjetoGatilho   = "gatilho"
+	ObjetoIndice    = "indice"
+)
+
+// Problem é um impedimento para gravar CNPJs alfanuméricos na coluna
+type Problem struct {
+	Codigo string `json:"codigo"`
+	// Objeto é a restrição, o índice ou o gatilho envolvido; vazio para o tipo
+	Objeto string `json:"objeto,omitempty"`
+	// TipoObjeto é um dos valores Objeto*; vazio para o tipo
+	TipoObjeto string `json:"tipo_objeto,omitempty"`
+	Detalhe    string `json:"detalhe"`
+}
+
+// Column é uma coluna de CNPJ encontrada no schema
+type Column struct {
+	Schema string `json:"schema,omitempty"`
+	Tabela string `json:"tabela"`
+	Coluna string `json:"coluna"`
+	Tipo   string `json:"tipo"`
+	// PorNome indica que a coluna foi reconhecida pelo nome; senão, pelo conteúdo
+	PorNome     bool      `json:"por_nome"`
+	Amostra     int       `json:"amostra"`
+	ParecemCNPJ int       `json:"parecem_cnpj"` //cnpjvet:ignore é uma contagem
+	Problemas   []Problem `json:"problemas"`
+}
+
+// Ready indica que a coluna não tem problemas
+func (c Column) Ready() bool {
+	return len(c.Problemas) == 0
+}
+
+// QualifiedTable retorna a tabela com o schema, quando houver
+func (c Column) QualifiedTable() string {
+	if c.Schema == "" {
+		return c.Tabela
+	}
+	return c.Schema + "." + c.Tabela
+}
+
+// Report é o resultado de Inspect
+type Report struct {
+	Dialeto sqlddl.Dialect `json:"dialeto"`
+	Colunas []Column       `json:"colunas"`
+	// Plano são os comandos SQL da migração, com comentários iniciados por --
+	Plano []string `json:"plano"`
+}
+
+// Ready indica que nenhuma coluna tem problemas
+func (r Report) Ready() bool {
+	for _, c := range r.Colunas {
+		if !c.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Options controla a inspeção
+type Options struct {
+	// Sample é a quantidade de valores lidos por coluna; zero usa DefaultSample e
+	// um valor negativo desliga a amostragem, mantendo só a detecção pelo nome
+	Sample int
+	// Tables restringe a inspeção a estas tabelas
+	Tables []string
+}
+
+// columnInfo, constraintInfo e indexInfo são os metadados lidos do banco
+type columnInfo struct {
+	schema, table, name, dataType string
+	length                        int
+	domain                        string
+}
+
+type constraintInfo struct {
+	// domain é preenchido nas restrições de domínio do PostgreSQL, que usam VALUE
+	schema, table, domain, name, def string
+	// trigger indica um gatilho do SQLite
+	trigger bool
+}
+
+type indexInfo struct {
+	schema, table, name, def string
+}
+
+type metadata struct {
+	columns     []columnInfo
+	constraints []constraintInfo
+	indexes     []indexInfo
+}
+
+// Inspect lê os metadados do banco, localiza as colunas de CNPJ e monta o relatório
+func Inspect(ctx context.Context, db *sql.DB, dialect sqlddl.Dialect, opts Options) (Report, error) {
+	report := Report{Dialeto: dialect, Colunas: []Column{}, Plano: []string{}}
+	if opts.Sample == 0 {
+		opts.Sample = DefaultSample
+	}
+
+	var (
+		meta metadata
+		err  error
+	)
+	switch dialect {
+	case sqlddl.Postgres:
+		meta, err = postgresMetadata(ctx, db)
+	case sqlddl.SQLite:
+		meta, err = sqliteMetadata(ctx, db)
+	default:
+		return report, sqlddl.ErrDialetoDesconhecido
+	}
+	if err != nil {
+		return report, err
+	}
+
+	tables := map[string]bool{}
+	for _, t := range opts.Tables {
+		tables[strings.ToLower(t)] = true
+	}
+
+	for _, ci := range meta.columns {
+		if len(tables) > 0 && !tables[strings.ToLower(ci.table)] && !tables[strings.ToLower(ci.schema+"."+ci.table)] {
+			continue
+		}
+
+		col := Column{Schema: ci.schema, Tabela: ci.table, Coluna: ci.name, Tipo: typeName(ci), PorNome: regexNome.MatchString(ci.name)}
+		if opts.Sample > 0 && sampleable(dialect, ci) {
+			if col.Amostra, col.ParecemCNPJ, err = sample(ctx, db, ci, opts.Sample); err != nil {
+				return report, err
+			}
+		}
+		if !col.PorNome && (col.Amostra == 0 || col.ParecemCNPJ*2 < col.Amostra) {
+			continue
+		}
+
+		col.Problemas = problems(dialect, ci, meta)
+		report.Colunas = append(report.Colunas, col)
+	}
+
+	report.Plano = plan(dialect, report.Colunas, meta)
+	return report, nil
+}
+
+func typeName(ci columnInfo) string {
+	t := ci.dataType
+	if ci.length > 0 {
+		t = fmt.Sprintf("%s(%d)", t, ci.length)
+	}
+	if ci.domain != "" {
+		t = ci.domain + " (" + t + ")"
+	}
+	return t
+}
+
+// quote delimita um identificador vindo dos metadados
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (ci columnInfo) qualifiedTable() string {
+	if ci.schema == "" {
+		return quote(ci.table)
+	}
+	return quote(ci.schema) + "." + quote(ci.table)
+}
+
+// sampleable indica se vale a pena ler valores da coluna: apenas tipos de texto
+// e numéricos, que podem conter um CNPJ
+func sampleable(dialect sqlddl.Dialect, ci columnInfo) bool {
+	if dialect == sqlddl.SQLite {
+		return sqliteAffinity(ci.dataType) != "REAL"
+	}
+	return postgresNumeric(ci.dataType) || strings.Contains(ci.dataType, "char") || ci.dataType == "text"
+}
+
+// sample lê até n valores não nulos e conta quantos parecem CNPJ
+func sample(ctx context.Context, db *sql.DB, ci columnInfo, n int) (int, int, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s IS NOT NULL LIMIT %d", quote(ci.name), ci.qualifiedTable(), quote(ci.name), n)
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	total, looks := 0, 0
+	for rows.Next() {
+		var v sql.NullString
+		if err := rows.Scan(&v); err != nil {
+			return 0, 0, err
+		}
+		total++
+		if LooksLikeCNPJ(v.String) {
+			looks++
+		}
+	}
+	return total, looks, rows.Err()
+}
+
+// LooksLikeCNPJ reconhece um CNPJ válido com ou sem máscara, em qualquer caixa,
+// inclusive os numéricos gravados como número, que perdem os zeros à esquerda
+func LooksLikeCNPJ(value string) bool {
+	v := strings.TrimSpace(value)
+	if n := len(v); n >= 10 && n < 14 && strings.Trim(v, "0123456789") == "" {
+		v = strings.Repeat("0", 14-n) + v
+	}
+	return cnpj.IsValid(cnpj.UnformattedCNPJ(v))
+}
+
+// mentions indica se o trecho de SQL cita a coluna
+func mentions(def, column string) bool {
+	re := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9_])"?` + regexp.QuoteMeta(column) + `"?([^A-Za-z0-9_]|$)`)
+	return re.MatchString(def)
+}
+
+// problems aponta o que impede a coluna de receber CNPJs alfanuméricos
+func problems(dialect sqlddl.Dialect, ci columnInfo, meta metadata) []Problem {
+	out := []Problem{}
+
+	switch {
+	case dialect == sqlddl.Postgres && postgresNumeric(ci.dataType):
+		out = append(out, Problem{Codigo: TipoNumerico, Detalhe: fmt.Sprintf("o tipo %s não aceita letras", ci.dataType)})
+	case dialect == sqlddl.Postgres && strings.Contains(ci.dataType, "char") && ci.length > 0 && ci.length < 14:
+		out = append(out, Problem{Codigo: TamanhoInsuficiente, Detalhe: fmt.Sprintf("o tamanho %d é menor que os 14 caracteres do CNPJ", ci.length)})
+	case dialect == sqlddl.SQLite && sqliteAffinity(ci.dataType) != "TEXT" && sqliteAffinity(ci.dataType) != "BLOB":
+		out = append(out, Problem{Codigo: TipoNumerico, Detalhe: fmt.Sprintf("a afinidade %s converte CNPJs numéricos em número e perde os zeros à esquerda", sqliteAffinity(ci.dataType))})
+	}
+
+	for _, c := range meta.constraints {
+		applies := c.domain != "" && c.domain == ci.domain ||
+			c.domain == "" && c.schema == ci.schema && c.table == ci.table && mentions(c.def, ci.name)
+		if !applies || !AssumesDigits(c.def) {
+			continue
+		}
+		p := Problem{Codigo: CheckSoDigitos, Objeto: c.name, TipoObjeto: ObjetoRestricao}
+		kind := "a restrição"
+		switch {
+		case c.domain != "":
+			p.TipoObjeto, kind = ObjetoDominio, "o domínio "+c.domain
+		case c.trigger:
+			p.TipoObjeto, kind = ObjetoGatilho, "o gatilho"
+		}
+		p.Detalhe = fmt.Sprintf("%s aceita apenas dígitos: %s", kind, c.def)
+		out = append(out, p)
+	}
+
+	for _, ix := range meta.indexes {
+		if ix.schema == ci.schema && ix.table == ci.table && mentions(ix.def, ci.name) && AssumesDigits(ix.def) {
+			out = append(out, Problem{Codigo: IndiceNumerico, Objeto: ix.name, TipoObjeto: ObjetoIndice, Detalhe: ix.def})
+		}
+	}
+	return out
+}
+
+// postgresNumeric indica os tipos numéricos do information_schema
+func postgresNumeric(dataType string) bool {
+	switch dataType {
+	case "smallint", "integer", "bigint", "numeric", "decimal", "real", "double precision":
+		return true
+	}
+	return false
+}
+
+// sqliteAffinity aplica as regras de afinidade de tipo do SQLite
+func sqliteAffinity(declared string) string {
+	t := strings.ToUpper(declared)
+	switch {
+	case strings.Contains(t, "INT"):
+		return "INTEGER"
+	case strings.Contains(t, "CHAR") || strings.Contains(t, "CLOB") || strings.Contains(t, "TEXT"):
+		return "TEXT"
+	case t == "" || strings.Contains(t, "BLOB"):
+		return "BLOB"
+	case strings.Contains(t, "REAL") || strings.Contains(t, "FLOA") || strings.Contains(t, "DOUB"):
+		return "REAL"
+	}
+	return "NUMERIC"
+}
+
+// sortedColumns ordena as colunas por tabela e nome, para um plano estável
+func sortedColumns(cols []Column) []Column {
+	out := append([]Column(nil), cols...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].QualifiedTable() != out[j].QualifiedTable() {
+			return out[i].QualifiedTable() < out[j].QualifiedTable()
+		}
+		return out[i].Coluna < out[j].Coluna
+	})
+	return out
+}
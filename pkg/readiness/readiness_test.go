@@ -0,0 +1,192 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	_ "modernc.org/sqlite"
+)
+
+func TestAssumesDigits(t *testing.T) {
+	cases := map[string]bool{
+		`CHECK ((cnpj ~ '^[0-9]{14}$'::text))`: true,
+		`CHECK (cnpj ~ '^\d+$')`:               true,
+		`CHECK (cnpj NOT GLOB '*[^0-9]*')`:     true,
+		`CHECK (cnpj GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]')`: true,
+		`CREATE INDEX ix ON public.f USING btree (((cnpj)::bigint))`:                                 true,
+		`CREATE INDEX ix ON f (CAST(cnpj AS INTEGER))`:                                               true,
+		`CHECK (length(cnpj) = 14)`:                                                                  false,
+		`CHECK (cnpj ~ '^[0-9A-Z]{12}[0-9]{2}$')`:                                                    false,
+		`CREATE INDEX ix ON public.f USING btree (cnpj)`:                                             false,
+		`CHECK (cnpj_is_valid(cnpj))`:                                                                false,
+		`CHECK ` + sqlddl.IsValidExpr(sqlddl.SQLite, "cnpj"):                                         false,
+		`CHECK ` + sqlddl.IsValidExpr(sqlddl.Postgres, "cnpj"):                                       false,
+	}
+	for def, expected := range cases {
+		if got := AssumesDigits(def); got != expected {
+			t.Errorf("AssumesDigits(%q) = %v, expected %v", def, got, expected)
+		}
+	}
+}
+
+func TestExtractChecks(t *testing.T) {
+	checks := extractChecks(`CREATE TABLE t (a TEXT CHECK (length(a) = 14), b TEXT, CONSTRAINT "b_digitos" CHECK (b NOT GLOB '*[^0-9]*' AND b <> ')'))`)
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %+v", checks)
+	}
+	if checks[0].name != "" || checks[0].def != "CHECK (length(a) = 14)" {
+		t.Errorf("unexpected first check %+v", checks[0])
+	}
+	if checks[1].name != "b_digitos" || !strings.HasSuffix(checks[1].def, "b <> ')')") {
+		t.Errorf("unexpected second check %+v", checks[1])
+	}
+}
+
+func TestLooksLikeCNPJ(t *testing.T) {
+	for v, expected := range map[string]bool{
+		"12ABC34501DE35":     true,
+		"12.abc.345/01de-35": true,
+		"11222333000181":     true,
+		"191":                false,
+		"1000191":            false,
+		"11222333000182":     false,
+		"":                   false,
+	} {
+		if got := LooksLikeCNPJ(v); got != expected {
+			t.Errorf("LooksLikeCNPJ(%q) = %v", v, got)
+		}
+	}
+	// CNPJ numérico gravado como número perde os zeros à esquerda
+	if !LooksLikeCNPJ("6990590000123") {
+		t.Error("expected zero-padded numeric CNPJ to be recognized")
+	}
+}
+
+func TestInspectSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	defer func() { _ = db.Close() }()
+	ctx := context.Background()
+
+	for _, stmt := range []string{
+		`CREATE TABLE empresas (id INTEGER PRIMARY KEY, cnpj BIGINT, nome TEXT)`,
+		`CREATE INDEX empresas_cnpj ON empresas (cnpj)`,
+		`CREATE TABLE fornecedores (id INTEGER PRIMARY KEY, documento TEXT, obs TEXT)`,
+		`CREATE INDEX fornecedores_doc_num ON fornecedores (CAST(documento AS INTEGER))`,
+		`CREATE TRIGGER fornecedores_digitos BEFORE INSERT ON fornecedores
+		 WHEN NEW.documento GLOB '*[^0-9]*' BEGIN SELECT RAISE(ABORT, 'somente dígitos'); END`,
+		`CREATE TABLE clientes (id INTEGER PRIMARY KEY, cgc TEXT CHECK (cgc NOT GLOB '*[^0-9]*'))`,
+		`CREATE TABLE pronta (id INTEGER PRIMARY KEY, cnpj TEXT)`,
+		`INSERT INTO empresas (cnpj, nome) VALUES (11222333000181, 'a'), (6990590000123, 'b')`,
+		`INSERT INTO fornecedores (documento, obs) VALUES ('11222333000181', 'x'), ('06990590000123', 'y'), (NULL, 'z')`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	report, err := Inspect(ctx, db, sqlddl.SQLite, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := map[string][]string{}
+	for _, c := range report.Colunas {
+		key := c.Tabela + "." + c.Coluna
+		codes[key] = []string{}
+		for _, p := range c.Problemas {
+			codes[key] = append(codes[key], p.Codigo)
+		}
+	}
+	expected := map[string]string{
+		"empresas.cnpj":          TipoNumerico,
+		"fornecedores.documento": CheckSoDigitos + "," + IndiceNumerico,
+		"clientes.cgc":           CheckSoDigitos,
+		"pronta.cnpj":            "",
+	}
+	if len(codes) != len(expected) {
+		t.Errorf("unexpected columns: %v", codes)
+	}
+	for key, want := range expected {
+		if got, ok := codes[key]; !ok || strings.Join(got, ",") != want {
+			t.Errorf("%s: problems %v, expected %q", key, got, want)
+		}
+	}
+	if report.Ready() {
+		t.Error("report should not be ready")
+	}
+
+	// Aplica o plano, exceto o CHECK de clientes, que exige recriar a tabela
+	for _, stmt := range report.Plano {
+		if stmt == "" || strings.HasPrefix(stmt, "--") {
+			continue
+		}
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("plan step %q failed: %v", stmt, err)
+		}
+	}
+
+	after, err := Inspect(ctx, db, sqlddl.SQLite, Options{Tables: []string{"empresas", "fornecedores"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after.Colunas) != 2 || !after.Ready() {
+		t.Errorf("expected migrated tables to be ready: %+v", after.Colunas)
+	}
+
+	var value string
+	if err := db.QueryRow(`SELECT cnpj FROM empresas WHERE nome = 'b'`).Scan(&value); err != nil || value != "06990590000123" {
+		t.Errorf("expected zero-padded value, got %q (%v)", value, err)
+	}
+	if _, err := db.Exec(`INSERT INTO empresas (cnpj) VALUES ('12ABC34501DE35')`); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresPlan(t *testing.T) {
+	meta := metadata{
+		columns: []columnInfo{
+			{schema: "public", table: "empresas", name: "cnpj", dataType: "numeric", domain: ""},
+			{schema: "public", table: "Clientes", name: "cnpj", dataType: "character", length: 11},
+			{schema: "fiscal", table: "notas", name: "emitente", dataType: "character varying", length: 14, domain: "cnpj_legado"},
+		},
+		constraints: []constraintInfo{
+			{schema: "public", table: "empresas", name: "empresas_cnpj_check", def: "CHECK ((cnpj IS NOT NULL))"},
+			{schema: "public", table: "Clientes", name: "clientes_cnpj_digitos", def: "CHECK ((cnpj ~ '^[0-9]+$'::text))"},
+			{schema: "fiscal", domain: "cnpj_legado", name: "cnpj_legado_check", def: "CHECK ((VALUE ~ '^\\d{14}$'::text))"},
+		},
+		indexes: []indexInfo{
+			{schema: "public", table: "Clientes", name: "clientes_cnpj_num", def: "CREATE INDEX clientes_cnpj_num ON public.\"Clientes\" USING btree (((cnpj)::bigint))"},
+		},
+	}
+
+	var cols []Column
+	for _, ci := range meta.columns {
+		cols = append(cols, Column{Schema: ci.schema, Tabela: ci.table, Coluna: ci.name, Tipo: typeName(ci), Problemas: problems(sqlddl.Postgres, ci, meta)})
+	}
+	got := strings.Join(plan(sqlddl.Postgres, cols, meta), "\n")
+
+	for _, want := range []string{
+		`ALTER TABLE public.empresas ALTER COLUMN cnpj TYPE varchar(14) USING lpad(cnpj::text, 14, '0');`,
+		`ALTER TABLE public."Clientes" DROP CONSTRAINT clientes_cnpj_digitos;`,
+		`DROP INDEX public.clientes_cnpj_num;`,
+		`ALTER TABLE public."Clientes" ALTER COLUMN cnpj TYPE varchar(14);`,
+		`ALTER TABLE public."Clientes" ADD CONSTRAINT clientes_cnpj_digitos CHECK (cnpj_is_valid(cnpj)) NOT VALID;`,
+		`CREATE INDEX clientes_cnpj_num ON public."Clientes" (cnpj);`,
+		`ALTER DOMAIN fiscal.cnpj_legado DROP CONSTRAINT cnpj_legado_check;`,
+		`ALTER DOMAIN fiscal.cnpj_legado ADD CONSTRAINT cnpj_legado_check CHECK (cnpj_is_valid(VALUE)) NOT VALID;`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("plan is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "empresas_cnpj_check") {
+		t.Errorf("constraint without digit assumptions should be kept:\n%s", got)
+	}
+}
@@ -0,0 +1,213 @@
+package receita
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/csvcnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+// ErrTipoDesconhecido indica um arquivo cujo nome não corresponde a nenhum tipo de Dados Abertos
+var ErrTipoDesconhecido = errors.New("tipo de arquivo de Dados Abertos não reconhecido pelo nome")
+
+// Result é o resultado da importação de um arquivo
+type Result struct {
+	Arquivo string `json:"arquivo"`
+	Tipo    Kind   `json:"tipo"`
+	Linhas  int64  `json:"linhas"`
+}
+
+// Prepare cria as tabelas rf_* que ainda não existem. Com reset, as tabelas são
+// apagadas antes, descartando uma importação anterior.
+func Prepare(ctx context.Context, db *sql.DB, reset bool) error {
+	for _, k := range Kinds {
+		t := tables[k]
+		if reset {
+			if _, err := db.ExecContext(ctx, "DROP TABLE IF EXISTS "+t.name); err != nil {
+				return err
+			}
+		}
+		if _, err := db.ExecContext(ctx, t.createTable()); err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+// CreateIndexes cria os índices das consultas por CNAE, município e sócio
+func CreateIndexes(ctx context.Context, db *sql.DB) error {
+	for _, stmt := range indexes {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportFile importa um arquivo de Dados Abertos, compactado ou não. Em um ZIP
+// cada arquivo é importado conforme o seu nome ou, se ele não for reconhecido,
+// conforme o nome do ZIP.
+func ImportFile(ctx context.Context, db *sql.DB, d sqlddl.Dialect, path string, batch int) ([]Result, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".zip") {
+		kind, ok := DetectKind(path)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", path, ErrTipoDesconhecido)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		n, err := Import(ctx, db, d, kind, f, batch)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return []Result{{Arquivo: path, Tipo: kind, Linhas: n}}, nil
+	}
+
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = z.Close()
+	}()
+
+	var results []Result
+	for _, entry := range z.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		kind, ok := DetectKind(entry.Name)
+		if !ok {
+			if kind, ok = DetectKind(path); !ok {
+				return results, fmt.Errorf("%s: %s: %w", path, entry.Name, ErrTipoDesconhecido)
+			}
+		}
+
+		name := path + ":" + entry.Name
+		n, err := importEntry(ctx, db, d, kind, entry, batch)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", name, err)
+		}
+		results = append(results, Result{Arquivo: name, Tipo: kind, Linhas: n})
+	}
+	return results, nil
+}
+
+func importEntry(ctx context.Context, db *sql.DB, d sqlddl.Dialect, kind Kind, entry *zip.File, batch int) (int64, error) {
+	r, err := entry.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return Import(ctx, db, d, kind, r, batch)
+}
+
+// Import carrega um arquivo do tipo kind, em Latin-1 e sem cabeçalho, em lotes
+// de batch linhas por transação (zero usa DefaultBatch), e retorna as linhas
+// gravadas. Se a importação falhar no meio, os lotes já confirmados permanecem.
+func Import(ctx context.Context, db *sql.DB, d sqlddl.Dialect, kind Kind, r io.Reader, batch int) (int64, error) {
+	t, ok := tables[kind]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrTipoDesconhecido, kind)
+	}
+	if batch <= 0 {
+		batch = DefaultBatch
+	}
+
+	in := csv.NewReader(csvcnpj.NewDecoder(r, csvcnpj.Latin1))
+	in.Comma = ';'
+	in.LazyQuotes = true
+	in.ReuseRecord = true
+	in.FieldsPerRecord = len(t.columns)
+
+	var (
+		tx         *sql.Tx
+		stmt       *sql.Stmt
+		read, done int64
+		err        error
+	)
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	values := make([]any, len(t.columns))
+	for {
+		record, readErr := in.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return done, readErr
+		}
+
+		if tx == nil {
+			if tx, err = db.BeginTx(ctx, nil); err != nil {
+				return done, err
+			}
+			if stmt, err = tx.PrepareContext(ctx, t.insert(d)); err != nil {
+				return done, err
+			}
+		}
+
+		for i, c := range t.columns {
+			values[i] = convert(c.typ, record[i])
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			line, _ := in.FieldPos(0)
+			return done, fmt.Errorf("linha %d: %w", line, err)
+		}
+		read++
+
+		if read%int64(batch) == 0 {
+			err, tx = tx.Commit(), nil
+			if err != nil {
+				return done, err
+			}
+			done = read
+		}
+	}
+
+	if tx != nil {
+		err, tx = tx.Commit(), nil
+		if err != nil {
+			return done, err
+		}
+	}
+	return read, nil
+}
+
+// convert prepara o valor de um campo para a coluna: campos vazios viram NULL,
+// datas AAAAMMDD viram AAAA-MM-DD (e as zeradas, NULL) e o capital social perde
+// a vírgula decimal
+func convert(typ, value string) any {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return nil
+	case typ == "DATE":
+		if len(value) != 8 || strings.Trim(value, "0123456789") != "" || strings.Trim(value, "0") == "" {
+			return nil
+		}
+		return value[:4] + "-" + value[4:6] + "-" + value[6:]
+	case strings.HasPrefix(typ, "NUMERIC"):
+		return strings.ReplaceAll(value, ",", ".")
+	}
+	return value
+}
@@ -0,0 +1,258 @@
+package receita
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+// ErrNaoEncontrado indica um CNPJ válido que não está nos dados importados
+var ErrNaoEncontrado = &cnpj.Error{Code: "CNPJ_NAO_ENCONTRADO", Message: "CNPJ não encontrado nos dados importados da Receita Federal"}
+
+// Codigo é um código das tabelas da Receita com a sua descrição, vazia quando
+// a tabela de códigos não foi importada
+type Codigo struct {
+	Codigo    string `json:"codigo"`
+	Descricao string `json:"descricao,omitempty"`
+}
+
+// String retorna "código - descrição", ou apenas o código
+func (c Codigo) String() string {
+	if c.Descricao == "" {
+		return c.Codigo
+	}
+	return c.Codigo + " - " + c.Descricao
+}
+
+// Endereco é o endereço do estabelecimento como publicado, sem máscara no CEP
+type Endereco struct {
+	TipoLogradouro string `json:"tipo_logradouro"`
+	Logradouro     string `json:"logradouro"`
+	Numero         string `json:"numero"`
+	Complemento    string `json:"complemento"`
+	Bairro         string `json:"bairro"`
+	CEP            string `json:"cep"`
+	UF             string `json:"uf"`
+	Municipio      Codigo `json:"municipio"`
+}
+
+// SimplesNacional é a opção pelo Simples Nacional e pelo MEI
+type SimplesNacional struct {
+	Optante         bool   `json:"optante"`
+	DataOpcao       string `json:"data_opcao,omitempty"`
+	DataExclusao    string `json:"data_exclusao,omitempty"`
+	MEI             bool   `json:"mei"`
+	DataOpcaoMEI    string `json:"data_opcao_mei,omitempty"`
+	DataExclusaoMEI string `json:"data_exclusao_mei,omitempty"`
+}
+
+// Socio é um sócio da empresa; o CPF vem parcialmente oculto na publicação
+type Socio struct {
+	Nome         string `json:"nome"`
+	CPFCNPJ      string `json:"cpf_cnpj"`
+	Tipo         Codigo `json:"tipo"`
+	Qualificacao Codigo `json:"qualificacao"`
+	DataEntrada  string `json:"data_entrada"`
+	FaixaEtaria  Codigo `json:"faixa_etaria"`
+}
+
+// Empresa é o estabelecimento consultado com os dados da empresa, do Simples e
+// dos sócios. As datas estão no formato AAAA-MM-DD.
+type Empresa struct {
+	CNPJ                string           `json:"cnpj"`
+	Matriz              bool             `json:"matriz"`
+	RazaoSocial         string           `json:"razao_social"`
+	NomeFantasia        string           `json:"nome_fantasia"`
+	Situacao            Codigo           `json:"situacao_cadastral"`
+	DataSituacao        string           `json:"data_situacao_cadastral"`
+	MotivoSituacao      Codigo           `json:"motivo_situacao_cadastral"`
+	DataInicioAtividade string           `json:"data_inicio_atividade"`
+	NaturezaJuridica    Codigo           `json:"natureza_juridica"`
+	Porte               Codigo           `json:"porte"`
+	CapitalSocial       float64          `json:"capital_social"`
+	CNAEPrincipal       Codigo           `json:"cnae_principal"`
+	CNAESecundarios     []Codigo         `json:"cnaes_secundarios"`
+	Endereco            Endereco         `json:"endereco"`
+	Telefones           []string         `json:"telefones"`
+	Email               string           `json:"email"`
+	Simples             *SimplesNacional `json:"simples,omitempty"`
+	Socios              []Socio          `json:"socios"`
+}
+
+// Descrições dos domínios que o leiaute da Receita define no próprio documento,
+// sem tabela de códigos
+var (
+	situacoes = map[string]string{"1": "NULA", "2": "ATIVA", "3": "SUSPENSA", "4": "INAPTA", "8": "BAIXADA"}
+	portes    = map[string]string{
+		"0": "NÃO INFORMADO", "1": "MICRO EMPRESA", "3": "EMPRESA DE PEQUENO PORTE", "5": "DEMAIS",
+	}
+	tiposSocio   = map[string]string{"1": "PESSOA JURÍDICA", "2": "PESSOA FÍSICA", "3": "ESTRANGEIRO"}
+	faixasEtaria = map[string]string{
+		"0": "NÃO SE APLICA", "1": "0 A 12 ANOS", "2": "13 A 20 ANOS", "3": "21 A 30 ANOS", "4": "31 A 40 ANOS",
+		"5": "41 A 50 ANOS", "6": "51 A 60 ANOS", "7": "61 A 70 ANOS", "8": "71 A 80 ANOS", "9": "MAIORES DE 80 ANOS",
+	}
+)
+
+// domain descreve um código pelos domínios acima, que ignoram zeros à esquerda
+func domain(m map[string]string, code string) Codigo {
+	key := strings.TrimLeft(code, "0")
+	if key == "" && code != "" {
+		key = "0"
+	}
+	return Codigo{Codigo: code, Descricao: m[key]}
+}
+
+// text lê colunas que podem ser NULL, de texto ou data, como string vazia ou AAAA-MM-DD
+type text struct{ p *string }
+
+func (t text) Scan(v any) error {
+	switch v := v.(type) {
+	case nil:
+		*t.p = ""
+	case string:
+		*t.p = v
+	case []byte:
+		*t.p = string(v)
+	case time.Time:
+		*t.p = v.Format(time.DateOnly)
+	default:
+		*t.p = fmt.Sprint(v)
+	}
+	return nil
+}
+
+// texts adapta os destinos de Scan
+func texts(dest ...*string) []any {
+	out := make([]any, len(dest))
+	for i, p := range dest {
+		out[i] = text{p}
+	}
+	return out
+}
+
+// Find consulta o CNPJ, com ou sem máscara, nos dados importados. Retorna o erro
+// de validação para um CNPJ inválido e ErrNaoEncontrado se o estabelecimento
+// não foi importado.
+func Find(ctx context.Context, db *sql.DB, d sqlddl.Dialect, value string) (Empresa, error) {
+	if err := cnpj.Validate(value); err != nil {
+		return Empresa{}, err
+	}
+	v := cnpj.UnformattedCNPJ(value)
+	basico, ordem, dv := v[:8], v[8:12], v[12:]
+
+	e := Empresa{CNPJ: v, CNAESecundarios: []Codigo{}, Telefones: []string{}, Socios: []Socio{}}
+	var (
+		matriz, situacao, porte, secundarios string
+		ddd1, tel1, ddd2, tel2               string
+		capital                              sql.NullFloat64
+	)
+	a := &e.Endereco
+	dest := texts(
+		&matriz, &e.NomeFantasia, &situacao, &e.DataSituacao, &e.MotivoSituacao.Codigo, &e.MotivoSituacao.Descricao,
+		&e.DataInicioAtividade, &e.CNAEPrincipal.Codigo, &e.CNAEPrincipal.Descricao, &secundarios,
+		&a.TipoLogradouro, &a.Logradouro, &a.Numero, &a.Complemento, &a.Bairro, &a.CEP, &a.UF,
+		&a.Municipio.Codigo, &a.Municipio.Descricao, &ddd1, &tel1, &ddd2, &tel2, &e.Email,
+		&e.RazaoSocial, &e.NaturezaJuridica.Codigo, &e.NaturezaJuridica.Descricao, &porte,
+	)
+	err := db.QueryRowContext(ctx, rebind(d, `SELECT
+  e.identificador_matriz_filial, e.nome_fantasia, e.situacao_cadastral, e.data_situacao_cadastral,
+  e.motivo_situacao_cadastral, mo.descricao, e.data_inicio_atividade, e.cnae_fiscal_principal, c.descricao,
+  e.cnae_fiscal_secundaria, e.tipo_logradouro, e.logradouro, e.numero, e.complemento, e.bairro, e.cep, e.uf,
+  e.municipio, mu.descricao, e.ddd_1, e.telefone_1, e.ddd_2, e.telefone_2, e.correio_eletronico,
+  m.razao_social, m.natureza_juridica, n.descricao, m.porte, m.capital_social
+FROM rf_estabelecimentos e
+LEFT JOIN rf_empresas m ON m.cnpj_basico = e.cnpj_basico
+LEFT JOIN rf_motivos mo ON mo.codigo = e.motivo_situacao_cadastral
+LEFT JOIN rf_cnaes c ON c.codigo = e.cnae_fiscal_principal
+LEFT JOIN rf_municipios mu ON mu.codigo = e.municipio
+LEFT JOIN rf_naturezas n ON n.codigo = m.natureza_juridica
+WHERE e.cnpj_basico = ? AND e.cnpj_ordem = ? AND e.cnpj_dv = ?`), basico, ordem, dv).Scan(append(dest, &capital)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Empresa{}, ErrNaoEncontrado
+	}
+	if err != nil {
+		return Empresa{}, err
+	}
+
+	e.Matriz = matriz == "1"
+	e.Situacao = domain(situacoes, situacao)
+	e.Porte = domain(portes, porte)
+	e.CapitalSocial = capital.Float64
+	for _, tel := range [][2]string{{ddd1, tel1}, {ddd2, tel2}} {
+		if tel[1] != "" {
+			e.Telefones = append(e.Telefones, strings.TrimSpace(tel[0]+" "+tel[1]))
+		}
+	}
+
+	for _, code := range strings.Split(secundarios, ",") {
+		if code = strings.TrimSpace(code); code == "" {
+			continue
+		}
+		c := Codigo{Codigo: code}
+		err := db.QueryRowContext(ctx, rebind(d, "SELECT descricao FROM rf_cnaes WHERE codigo = ?"), code).Scan(text{&c.Descricao})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return Empresa{}, err
+		}
+		e.CNAESecundarios = append(e.CNAESecundarios, c)
+	}
+
+	if e.Simples, err = findSimples(ctx, db, d, basico); err != nil {
+		return Empresa{}, err
+	}
+	if e.Socios, err = findSocios(ctx, db, d, basico); err != nil {
+		return Empresa{}, err
+	}
+	return e, nil
+}
+
+// findSimples retorna nil se a empresa não consta do arquivo do Simples
+func findSimples(ctx context.Context, db *sql.DB, d sqlddl.Dialect, basico string) (*SimplesNacional, error) {
+	var (
+		s            SimplesNacional
+		simples, mei string
+	)
+	err := db.QueryRowContext(ctx, rebind(d, `SELECT opcao_simples, data_opcao_simples, data_exclusao_simples,
+  opcao_mei, data_opcao_mei, data_exclusao_mei FROM rf_simples WHERE cnpj_basico = ?`), basico).
+		Scan(texts(&simples, &s.DataOpcao, &s.DataExclusao, &mei, &s.DataOpcaoMEI, &s.DataExclusaoMEI)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Optante, s.MEI = simples == "S", mei == "S"
+	return &s, nil
+}
+
+func findSocios(ctx context.Context, db *sql.DB, d sqlddl.Dialect, basico string) ([]Socio, error) {
+	rows, err := db.QueryContext(ctx, rebind(d, `SELECT s.nome_socio, s.cpf_cnpj_socio, s.identificador_socio,
+  s.qualificacao_socio, q.descricao, s.data_entrada_sociedade, s.faixa_etaria
+FROM rf_socios s
+LEFT JOIN rf_qualificacoes q ON q.codigo = s.qualificacao_socio
+WHERE s.cnpj_basico = ?
+ORDER BY s.data_entrada_sociedade, s.nome_socio`), basico)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := []Socio{}
+	for rows.Next() {
+		var s Socio
+		var tipo, faixa string
+		if err := rows.Scan(texts(&s.Nome, &s.CPFCNPJ, &tipo, &s.Qualificacao.Codigo, &s.Qualificacao.Descricao, &s.DataEntrada, &faixa)...); err != nil {
+			return nil, err
+		}
+		s.Tipo, s.FaixaEtaria = domain(tiposSocio, tipo), domain(faixasEtaria, faixa)
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
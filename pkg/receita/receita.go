@@ -0,0 +1,212 @@
+// Package receita importa os arquivos de Dados Abertos do CNPJ publicados pela
+// Receita Federal (Empresas, Estabelecimentos, Sócios, Simples e as tabelas de
+// códigos, como CNAEs e municípios) para tabelas rf_* no PostgreSQL ou no
+// SQLite, e consulta a empresa de um CNPJ nos dados importados, sem depender de
+// APIs externas.
+//
+// Os arquivos são CSVs em Latin-1, separados por ponto e vírgula, sem cabeçalho
+// e distribuídos em ZIPs; o tipo de cada um é reconhecido pelo nome.
+package receita
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+)
+
+// DefaultBatch é o número de linhas por transação usado quando nenhum é informado
+const DefaultBatch = 5000
+
+// Kind é o tipo de um arquivo de Dados Abertos
+type Kind string
+
+const (
+	Empresas         Kind = "empresas"
+	Estabelecimentos Kind = "estabelecimentos"
+	Socios           Kind = "socios"
+	Simples          Kind = "simples"
+	Cnaes            Kind = "cnaes"
+	Municipios       Kind = "municipios"
+	Naturezas        Kind = "naturezas"
+	Qualificacoes    Kind = "qualificacoes"
+	Paises           Kind = "paises"
+	Motivos          Kind = "motivos"
+)
+
+// Kinds lista os tipos suportados, na ordem em que as tabelas são criadas
+var Kinds = []Kind{Empresas, Estabelecimentos, Socios, Simples, Cnaes, Municipios, Naturezas, Qualificacoes, Paises, Motivos}
+
+// markers são os sufixos dos arquivos de dentro dos ZIPs, como
+// K3241.K03200Y0.D40511.ESTABELE ou F.K03200$Z.D40511.CNAECSV
+var markers = []struct {
+	marker string
+	kind   Kind
+}{
+	{"EMPRECSV", Empresas}, {"ESTABELE", Estabelecimentos}, {"SOCIOCSV", Socios}, {"SIMPLES", Simples},
+	{"CNAECSV", Cnaes}, {"MUNICCSV", Municipios}, {"NATJUCSV", Naturezas}, {"QUALSCSV", Qualificacoes},
+	{"PAISCSV", Paises}, {"MOTICSV", Motivos},
+}
+
+// DetectKind reconhece o tipo pelo nome do arquivo: o sufixo dos arquivos de
+// dentro dos ZIPs ou o nome do próprio ZIP (Empresas0.zip, Cnaes.zip...)
+func DetectKind(name string) (Kind, bool) {
+	base := filepath.Base(name)
+	upper := strings.ToUpper(base)
+	for _, m := range markers {
+		if strings.Contains(upper, m.marker) {
+			return m.kind, true
+		}
+	}
+	lower := strings.ToLower(base)
+	for _, k := range Kinds {
+		if strings.HasPrefix(lower, string(k)) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// column é uma coluna da tabela, na ordem em que aparece no arquivo
+type column struct {
+	name string
+	typ  string
+}
+
+// table descreve o leiaute de um arquivo e a tabela que o recebe
+type table struct {
+	name    string
+	columns []column
+	// key é a chave primária; sem ela as linhas são apenas inseridas
+	key []string
+}
+
+var tables = map[Kind]table{
+	Empresas: {
+		name: "rf_empresas",
+		columns: []column{
+			{"cnpj_basico", "VARCHAR(8)"}, {"razao_social", "VARCHAR(200)"}, {"natureza_juridica", "VARCHAR(4)"},
+			{"qualificacao_responsavel", "VARCHAR(2)"}, {"capital_social", "NUMERIC(18,2)"}, {"porte", "VARCHAR(2)"},
+			{"ente_federativo", "VARCHAR(100)"},
+		},
+		key: []string{"cnpj_basico"},
+	},
+	Estabelecimentos: {
+		name: "rf_estabelecimentos",
+		columns: []column{
+			{"cnpj_basico", "VARCHAR(8)"}, {"cnpj_ordem", "VARCHAR(4)"}, {"cnpj_dv", "VARCHAR(2)"},
+			{"identificador_matriz_filial", "VARCHAR(1)"}, {"nome_fantasia", "VARCHAR(200)"},
+			{"situacao_cadastral", "VARCHAR(2)"}, {"data_situacao_cadastral", "DATE"},
+			{"motivo_situacao_cadastral", "VARCHAR(2)"}, {"nome_cidade_exterior", "VARCHAR(100)"}, {"pais", "VARCHAR(3)"},
+			{"data_inicio_atividade", "DATE"}, {"cnae_fiscal_principal", "VARCHAR(7)"}, {"cnae_fiscal_secundaria", "TEXT"},
+			{"tipo_logradouro", "VARCHAR(30)"}, {"logradouro", "VARCHAR(200)"}, {"numero", "VARCHAR(20)"},
+			{"complemento", "VARCHAR(200)"}, {"bairro", "VARCHAR(100)"}, {"cep", "VARCHAR(8)"}, {"uf", "VARCHAR(2)"},
+			{"municipio", "VARCHAR(4)"}, {"ddd_1", "VARCHAR(4)"}, {"telefone_1", "VARCHAR(9)"}, {"ddd_2", "VARCHAR(4)"},
+			{"telefone_2", "VARCHAR(9)"}, {"ddd_fax", "VARCHAR(4)"}, {"fax", "VARCHAR(9)"},
+			{"correio_eletronico", "VARCHAR(200)"}, {"situacao_especial", "VARCHAR(100)"}, {"data_situacao_especial", "DATE"},
+		},
+		key: []string{"cnpj_basico", "cnpj_ordem", "cnpj_dv"},
+	},
+	Socios: {
+		name: "rf_socios",
+		columns: []column{
+			{"cnpj_basico", "VARCHAR(8)"}, {"identificador_socio", "VARCHAR(1)"}, {"nome_socio", "VARCHAR(200)"},
+			{"cpf_cnpj_socio", "VARCHAR(14)"}, {"qualificacao_socio", "VARCHAR(2)"}, {"data_entrada_sociedade", "DATE"},
+			{"pais", "VARCHAR(3)"}, {"representante_legal", "VARCHAR(11)"}, {"nome_representante", "VARCHAR(200)"},
+			{"qualificacao_representante", "VARCHAR(2)"}, {"faixa_etaria", "VARCHAR(1)"},
+		},
+	},
+	Simples: {
+		name: "rf_simples",
+		columns: []column{
+			{"cnpj_basico", "VARCHAR(8)"}, {"opcao_simples", "VARCHAR(1)"}, {"data_opcao_simples", "DATE"},
+			{"data_exclusao_simples", "DATE"}, {"opcao_mei", "VARCHAR(1)"}, {"data_opcao_mei", "DATE"},
+			{"data_exclusao_mei", "DATE"},
+		},
+		key: []string{"cnpj_basico"},
+	},
+	Cnaes:         codeTable("rf_cnaes"),
+	Municipios:    codeTable("rf_municipios"),
+	Naturezas:     codeTable("rf_naturezas"),
+	Qualificacoes: codeTable("rf_qualificacoes"),
+	Paises:        codeTable("rf_paises"),
+	Motivos:       codeTable("rf_motivos"),
+}
+
+// codeTable é o leiaute comum das tabelas de códigos: código e descrição
+func codeTable(name string) table {
+	return table{
+		name:    name,
+		columns: []column{{"codigo", "VARCHAR(7)"}, {"descricao", "VARCHAR(200)"}},
+		key:     []string{"codigo"},
+	}
+}
+
+// indexes são criados por CreateIndexes, depois da carga, para não atrasá-la
+var indexes = []string{
+	"CREATE INDEX IF NOT EXISTS rf_estabelecimentos_cnae ON rf_estabelecimentos (cnae_fiscal_principal)",
+	"CREATE INDEX IF NOT EXISTS rf_estabelecimentos_municipio ON rf_estabelecimentos (uf, municipio)",
+	"CREATE INDEX IF NOT EXISTS rf_socios_cnpj_basico ON rf_socios (cnpj_basico)",
+	"CREATE INDEX IF NOT EXISTS rf_socios_cpf_cnpj ON rf_socios (cpf_cnpj_socio)",
+}
+
+// createTable retorna o CREATE TABLE da tabela
+func (t table) createTable() string {
+	defs := make([]string, 0, len(t.columns)+1)
+	for _, c := range t.columns {
+		defs = append(defs, c.name+" "+c.typ)
+	}
+	if len(t.key) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(t.key, ", ")+")")
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n  %s\n)", t.name, strings.Join(defs, ",\n  "))
+}
+
+// insert retorna o INSERT de uma linha. Nas tabelas com chave, uma linha já
+// importada é atualizada, de modo que importar a publicação do mês seguinte
+// sobre a anterior a atualiza.
+func (t table) insert(d sqlddl.Dialect) string {
+	names := make([]string, len(t.columns))
+	marks := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+		marks[i] = placeholder(d, i+1)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.name, strings.Join(names, ", "), strings.Join(marks, ", "))
+	if len(t.key) == 0 {
+		return query
+	}
+
+	var set []string
+	for _, c := range t.columns[len(t.key):] {
+		set = append(set, c.name+" = excluded."+c.name)
+	}
+	return query + fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(t.key, ", "), strings.Join(set, ", "))
+}
+
+// placeholder retorna o marcador do n-ésimo parâmetro (a partir de 1)
+func placeholder(d sqlddl.Dialect, n int) string {
+	if d == sqlddl.Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// rebind troca os marcadores ? da consulta pelos do dialeto
+func rebind(d sqlddl.Dialect, query string) string {
+	if d != sqlddl.Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(placeholder(d, n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package receita
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/csvcnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlddl"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/sqlitecnpj"
+)
+
+// Amostras no leiaute dos Dados Abertos, uma por arquivo
+var samples = map[string]string{
+	"K3241.K03200Y0.D40511.EMPRECSV": `"12ABC345";"PADARIA SÃO JOÃO LTDA";"2062";"49";"15000,50";"01";""
+"11222333";"COMÉRCIO ANTIGO S.A.";"2054";"16";"1000000,00";"05";""
+`,
+	"K3241.K03200Y0.D40511.ESTABELE": `"12ABC345";"01DE";"35";"1";"PÃO QUENTE";"02";"20260715";"00";"";"";"20260701";"1091102";"4721102,5611201,9999999";"RUA";"DAS FLORES";"100";"SALA 2";"CENTRO";"01001000";"SP";"7107";"11";"30001234";"";"";"";"";"contato@paoquente.com.br";"";""
+"11222333";"0001";"81";"1";"";"08";"20200110";"01";"";"";"19950301";"4711302";"";"AVENIDA";"BRASIL";"S/N";"";"BOA VISTA";"20000000";"RJ";"6001";"";"";"";"";"";"";"";"";"00000000"
+`,
+	"K3241.K03200Y0.D40511.SOCIOCSV": `"12ABC345";"2";"MARIA DA CONCEIÇÃO";"***123456**";"49";"20260701";"";"***000000**";"";"00";"5"
+"12ABC345";"2";"JOSÉ SILVA";"***654321**";"22";"20260801";"";"***000000**";"";"00";"4"
+`,
+	"F.K03200$W.SIMPLES.CSV.D40511": `"12ABC345";"S";"20260701";"00000000";"N";"00000000";"00000000"
+`,
+	"F.K03200$Z.D40511.CNAECSV": `"1091102";"Fabricação de produtos de padaria e confeitaria com predominância de produção própria"
+"4721102";"Padaria e confeitaria com predominância de revenda"
+"5611201";"Restaurantes e similares"
+`,
+	"F.K03200$Z.D40511.MUNICCSV": `"7107";"SAO PAULO"
+`,
+	"F.K03200$Z.D40511.NATJUCSV": `"2062";"Sociedade Empresária Limitada"
+`,
+	"F.K03200$Z.D40511.QUALSCSV": `"49";"Sócio-Administrador"
+"22";"Sócio"
+`,
+}
+
+// writeZip grava os arquivos em Latin-1 dentro de um ZIP
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := csvcnpj.NewEncoder(w, csvcnpj.Latin1).Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqlitecnpj.Open(filepath.Join(t.TempDir(), "rf.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := Prepare(context.Background(), db, false); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// TestDetectKind tests the recognition of the published file names
+func TestDetectKind(t *testing.T) {
+	cases := map[string]Kind{
+		"K3241.K03200Y0.D40511.EMPRECSV": Empresas,
+		"dados/Estabelecimentos3.zip":    Estabelecimentos,
+		"Socios0.zip":                    Socios,
+		"F.K03200$W.SIMPLES.CSV.D40511":  Simples,
+		"Cnaes.zip":                      Cnaes,
+		"F.K03200$Z.D40511.MOTICSV":      Motivos,
+	}
+	for name, want := range cases {
+		if got, ok := DetectKind(name); !ok || got != want {
+			t.Errorf("DetectKind(%s) = %s, %v; want %s", name, got, ok, want)
+		}
+	}
+	if _, ok := DetectKind("leiame.txt"); ok {
+		t.Error("unrelated file should not be recognized")
+	}
+}
+
+// TestImportAndFind tests a zipped import followed by lookups
+func TestImportAndFind(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	path := filepath.Join(t.TempDir(), "dados.zip")
+	writeZip(t, path, samples)
+
+	results, err := ImportFile(ctx, db, sqlddl.SQLite, path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := map[Kind]int64{}
+	for _, r := range results {
+		lines[r.Tipo] += r.Linhas
+	}
+	if want := (map[Kind]int64{Empresas: 2, Estabelecimentos: 2, Socios: 2, Simples: 1, Cnaes: 3, Municipios: 1, Naturezas: 1, Qualificacoes: 2}); !reflect.DeepEqual(lines, want) {
+		t.Errorf("imported lines = %v, want %v", lines, want)
+	}
+	if err := CreateIndexes(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := Find(ctx, db, sqlddl.SQLite, "12.ABC.345/01DE-35")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.CNPJ != "12ABC34501DE35" || !e.Matriz || e.RazaoSocial != "PADARIA SÃO JOÃO LTDA" || e.NomeFantasia != "PÃO QUENTE" {
+		t.Errorf("identification = %+v", e)
+	}
+	if e.Situacao != (Codigo{"02", "ATIVA"}) || e.DataSituacao != "2026-07-15" || e.DataInicioAtividade != "2026-07-01" {
+		t.Errorf("situation = %v %s %s", e.Situacao, e.DataSituacao, e.DataInicioAtividade)
+	}
+	if e.NaturezaJuridica.String() != "2062 - Sociedade Empresária Limitada" || e.Porte.Descricao != "MICRO EMPRESA" || e.CapitalSocial != 15000.50 {
+		t.Errorf("company = %v %v %v", e.NaturezaJuridica, e.Porte, e.CapitalSocial)
+	}
+	wantCNAEs := []Codigo{{"4721102", "Padaria e confeitaria com predominância de revenda"}, {"5611201", "Restaurantes e similares"}, {"9999999", ""}}
+	if e.CNAEPrincipal.Codigo != "1091102" || !reflect.DeepEqual(e.CNAESecundarios, wantCNAEs) {
+		t.Errorf("CNAEs = %v %v", e.CNAEPrincipal, e.CNAESecundarios)
+	}
+	if e.Endereco.Municipio.String() != "7107 - SAO PAULO" || e.Endereco.CEP != "01001000" || !reflect.DeepEqual(e.Telefones, []string{"11 30001234"}) {
+		t.Errorf("address = %+v %v", e.Endereco, e.Telefones)
+	}
+	if e.Simples == nil || !e.Simples.Optante || e.Simples.MEI || e.Simples.DataOpcao != "2026-07-01" || e.Simples.DataExclusao != "" {
+		t.Errorf("simples = %+v", e.Simples)
+	}
+	if len(e.Socios) != 2 || e.Socios[0].Nome != "MARIA DA CONCEIÇÃO" || e.Socios[0].Qualificacao.Descricao != "Sócio-Administrador" ||
+		e.Socios[0].Tipo.Descricao != "PESSOA FÍSICA" || e.Socios[1].FaixaEtaria.Descricao != "31 A 40 ANOS" {
+		t.Errorf("partners = %+v", e.Socios)
+	}
+
+	e, err = Find(ctx, db, sqlddl.SQLite, "11222333000181")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Situacao.Descricao != "BAIXADA" || e.Simples != nil || len(e.Socios) != 0 || len(e.CNAESecundarios) != 0 || e.Endereco.Municipio.Descricao != "" {
+		t.Errorf("second company = %+v", e)
+	}
+
+	if _, err := Find(ctx, db, sqlddl.SQLite, cnpj.GenerateCNPJ()); !errors.Is(err, ErrNaoEncontrado) {
+		t.Errorf("missing CNPJ: err = %v", err)
+	}
+	if _, err := Find(ctx, db, sqlddl.SQLite, "12ABC34501DE36"); !errors.Is(err, cnpj.ErroDVIncorreto) {
+		t.Errorf("invalid CNPJ: err = %v", err)
+	}
+}
+
+// TestImport_Upsert tests that importing a newer release updates the rows instead of duplicating them
+func TestImport_Upsert(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	for _, name := range []string{"PADARIA ANTIGA LTDA", "PADARIA NOVA LTDA"} {
+		line := `"12ABC345";"` + name + `";"2062";"49";"0";"01";""` + "\n"
+		if _, err := Import(ctx, db, sqlddl.SQLite, Empresas, strings.NewReader(line), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var count int
+	var name string
+	if err := db.QueryRowContext(ctx, "SELECT count(*), max(razao_social) FROM rf_empresas").Scan(&count, &name); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || name != "PADARIA NOVA LTDA" {
+		t.Errorf("%d rows, razão social %q", count, name)
+	}
+}
+
+// TestImport_Layout tests that a line with the wrong number of fields stops the import
+// after the batches already committed
+func TestImport_Layout(t *testing.T) {
+	db := openDB(t)
+	in := `"1091102";"Padaria"` + "\n" + `"4721102";"Padaria";"extra"` + "\n"
+	n, err := Import(context.Background(), db, sqlddl.SQLite, Cnaes, strings.NewReader(in), 1)
+	if err == nil || !strings.Contains(err.Error(), "line 2") || n != 1 {
+		t.Errorf("Import = %d, %v; want the first line committed and an error on line 2", n, err)
+	}
+}
+
+// TestRebind tests the placeholders of each dialect
+func TestRebind(t *testing.T) {
+	q := "SELECT 1 WHERE a = ? AND b = ?"
+	if got := rebind(sqlddl.Postgres, q); got != "SELECT 1 WHERE a = $1 AND b = $2" {
+		t.Errorf("postgres: %s", got)
+	}
+	if got := rebind(sqlddl.SQLite, q); got != q {
+		t.Errorf("sqlite: %s", got)
+	}
+	insert := tables[Simples].insert(sqlddl.Postgres)
+	if !strings.Contains(insert, "$7)") || !strings.Contains(insert, "ON CONFLICT (cnpj_basico) DO UPDATE SET opcao_simples = excluded.opcao_simples") {
+		t.Errorf("insert = %s", insert)
+	}
+}
@@ -0,0 +1,221 @@
+// Package redact mascara CNPJs em textos e fluxos arbitrariamente grandes, como
+// logs e dumps, usando memória constante.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Policy define como cada CNPJ encontrado é substituído
+type Policy int
+
+const (
+	// Full troca todos os caracteres por *, mantendo a máscara: **.***.***/****-**
+	Full Policy = iota
+	// Partial mantém a raiz (8 primeiros caracteres) e mascara ordem e DV: 12.ABC.345/****-**
+	Partial
+	// HMAC troca o valor por um token derivado de uma chave: [CNPJ:3f9a...]
+	HMAC
+	// Fake troca o valor por um CNPJ fictício válido, sempre o mesmo para a mesma
+	// entrada e chave; CNPJs da mesma raiz continuam com a mesma raiz fictícia
+	Fake
+)
+
+// tokenSize é a quantidade de caracteres hexadecimais dos tokens HMAC
+const tokenSize = 16
+
+// chunkSize é o tamanho das leituras de Copy
+const chunkSize = 64 * 1024
+
+// fakeKey é usada por Fake quando nenhuma chave é informada
+var fakeKey = []byte("alfanumeric-cnpj/redact")
+
+// regexCandidato casa tudo que tem a forma de um CNPJ, com máscara completa,
+// parcial ou sem máscara, em qualquer caixa e sem conferir o DV: um valor com
+// DV errado ou em minúsculas continua sendo um dado a esconder
+var regexCandidato = cnpj.NewTextPattern(`(?i)[A-Z\d]{2}\.?[A-Z\d]{3}\.?[A-Z\d]{3}/?[A-Z\d]{4}-?\d{2}`)
+
+var (
+	ErrPoliticaDesconhecida = errors.New("política de mascaramento desconhecida")
+	ErrChaveObrigatoria     = errors.New("a política hmac exige uma chave")
+)
+
+// ParsePolicy reconhece full, partial, hmac e fake
+func ParsePolicy(name string) (Policy, error) {
+	switch name {
+	case "full":
+		return Full, nil
+	case "partial":
+		return Partial, nil
+	case "hmac":
+		return HMAC, nil
+	case "fake":
+		return Fake, nil
+	}
+	return Full, ErrPoliticaDesconhecida
+}
+
+// Redactor substitui os CNPJs encontrados conforme a política
+type Redactor struct {
+	policy Policy
+	key    []byte
+}
+
+// New cria um Redactor; a chave é obrigatória para HMAC e opcional para Fake
+func New(policy Policy, key []byte) (*Redactor, error) {
+	if policy < Full || policy > Fake {
+		return nil, ErrPoliticaDesconhecida
+	}
+	if policy == HMAC && len(key) == 0 {
+		return nil, ErrChaveObrigatoria
+	}
+	if policy == Fake && len(key) == 0 {
+		key = fakeKey
+	}
+	return &Redactor{policy: policy, key: key}, nil
+}
+
+// Replace retorna o valor que substitui um CNPJ, com ou sem máscara
+func (r *Redactor) Replace(value string) string {
+	switch r.policy {
+	case Partial:
+		return maskFrom(value, 8)
+	case HMAC:
+		return "[CNPJ:" + hex.EncodeToString(r.sum(cnpj.UnformattedCNPJ(value)))[:tokenSize] + "]"
+	case Fake:
+		return r.fake(value)
+	default:
+		return maskFrom(value, 0)
+	}
+}
+
+// maskFrom troca por * os caracteres a partir da posição keep, sem contar a máscara
+func maskFrom(value string, keep int) string {
+	b := []byte(value)
+	n := 0
+	for i, c := range b {
+		if c == '.' || c == '/' || c == '-' {
+			continue
+		}
+		if n >= keep {
+			b[i] = '*'
+		}
+		n++
+	}
+	return string(b)
+}
+
+func (r *Redactor) sum(value string) []byte {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// fake deriva a raiz fictícia apenas da raiz original e mantém a ordem, para
+// preservar a relação entre matriz e filiais no texto mascarado
+func (r *Redactor) fake(value string) string {
+	v := cnpj.UnformattedCNPJ(value)
+	raiz, ordem := v[:8], v[8:12]
+
+	alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	if strings.Trim(raiz, "0123456789") == "" {
+		alphabet = alphabet[:10]
+	}
+
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(r.sum(raiz)))))
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+
+	base := string(b) + ordem
+	dv, _ := cnpj.CalculateDV(base)
+	out := base + dv
+	if len(value) != len(v) {
+		out = cnpj.FormatCNPJ(out)
+	}
+	return out
+}
+
+// String substitui todos os candidatos a CNPJ do texto, válidos ou não, e
+// retorna quantas substituições fez
+func (r *Redactor) String(text string) (string, int) {
+	matches := regexCandidato.FindAllIndex(text)
+	if len(matches) == 0 {
+		return text, 0
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(r.Replace(text[m[0]:m[1]]))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String(), len(matches)
+}
+
+// Copy copia src para dst substituindo os CNPJs e retorna quantas substituições
+// fez. A leitura é feita em blocos cortados em separadores, de modo que um CNPJ
+// nunca fica dividido entre dois blocos e o uso de memória não depende do
+// tamanho da entrada nem do comprimento das linhas.
+func (r *Redactor) Copy(dst io.Writer, src io.Reader) (int, error) {
+	total := 0
+	buf := make([]byte, 0, 2*chunkSize)
+	chunk := make([]byte, chunkSize)
+
+	flush := func(data []byte) error {
+		out, n := r.String(string(data))
+		total += n
+		_, err := io.WriteString(dst, out)
+		return err
+	}
+
+	for {
+		n, err := src.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+
+		if err == io.EOF {
+			return total, flush(buf)
+		}
+		if err != nil {
+			return total, err
+		}
+
+		cut := lastSeparator(buf)
+		if cut < 0 {
+			if len(buf) < chunkSize {
+				continue
+			}
+			// Um trecho enorme sem espaços nem pontuação é cortado onde estiver,
+			// para manter o limite de memória
+			cut = len(buf) - 1
+		}
+		if err := flush(buf[:cut+1]); err != nil {
+			return total, err
+		}
+		buf = append(buf[:0], buf[cut+1:]...)
+	}
+}
+
+// lastSeparator retorna a posição do último byte que não pode fazer parte de um CNPJ
+func lastSeparator(data []byte) int {
+	for i := len(data) - 1; i >= 0; i-- {
+		c := data[i]
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '_' || c == '.' || c == '/' || c == '-') {
+			return i
+		}
+	}
+	return -1
+}
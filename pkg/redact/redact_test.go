@@ -0,0 +1,136 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+const logLine = "pedido 1 emitente=12.ABC.345/01DE-35 cliente=11222333000181 filial=12.ABC.345/0002-16 nota=123\n"
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		policy   Policy
+		value    string
+		expected string
+	}{
+		{Full, "12.ABC.345/01DE-35", "**.***.***/****-**"},
+		{Full, "11222333000181", "**************"},
+		{Partial, "12.ABC.345/01DE-35", "12.ABC.345/****-**"},
+		{Partial, "11222333000181", "11222333******"},
+	}
+	for _, tt := range tests {
+		r, _ := New(tt.policy, nil)
+		if got := r.Replace(tt.value); got != tt.expected {
+			t.Errorf("policy %d Replace(%q) = %q, expected %q", tt.policy, tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	if _, err := New(HMAC, nil); !errors.Is(err, ErrChaveObrigatoria) {
+		t.Errorf("expected ErrChaveObrigatoria, got %v", err)
+	}
+
+	a, _ := New(HMAC, []byte("k1"))
+	b, _ := New(HMAC, []byte("k2"))
+	masked, plain := a.Replace("12.ABC.345/01DE-35"), a.Replace("12ABC34501DE35")
+	if masked != plain || !strings.HasPrefix(masked, "[CNPJ:") || len(masked) != len("[CNPJ:]")+tokenSize {
+		t.Errorf("unexpected tokens %q and %q", masked, plain)
+	}
+	if masked == b.Replace("12ABC34501DE35") {
+		t.Error("tokens must depend on the key")
+	}
+}
+
+func TestFake(t *testing.T) {
+	r, _ := New(Fake, nil)
+
+	matriz := r.Replace("12.ABC.345/0001-63")
+	filial := r.Replace("12.ABC.345/0002-44")
+	numeric := r.Replace("11222333000181")
+
+	for _, v := range []string{matriz, filial, numeric} {
+		if !cnpj.IsValid(v) {
+			t.Errorf("fake value %q is not valid", v)
+		}
+	}
+	if matriz[:10] != filial[:10] || matriz[10:15] != "/0001" || matriz == "12.ABC.345/0001-63" {
+		t.Errorf("expected same fake raiz and original ordem: %q, %q", matriz, filial)
+	}
+	if strings.Trim(numeric, "0123456789") != "" || len(numeric) != 14 {
+		t.Errorf("expected unmasked numeric fake, got %q", numeric)
+	}
+	if r.Replace("11222333000181") != numeric {
+		t.Error("fake values must be consistent")
+	}
+}
+
+func TestString(t *testing.T) {
+	r, _ := New(Partial, nil)
+	got, n := r.String(logLine)
+	expected := "pedido 1 emitente=12.ABC.345/****-** cliente=11222333****** filial=12.ABC.345/****-** nota=123\n"
+	if got != expected || n != 3 {
+		t.Errorf("String() = %q (%d), expected %q", got, n, expected)
+	}
+}
+
+// TestStringCandidates tests that values are redacted regardless of DV, case and partial masks
+func TestStringCandidates(t *testing.T) {
+	r, _ := New(Full, nil)
+	got, n := r.String("doc 11222333000182 e 12abc34501de35, 12.abc.345/01de-99 e 12ABC345/01DE-35; id ABCDEFGHIJKLMNOP e 1122233300018\n")
+	expected := "doc ************** e **************, **.***.***/****-** e ********/****-**; id ABCDEFGHIJKLMNOP e 1122233300018\n"
+	if got != expected || n != 4 {
+		t.Errorf("String() = %q (%d), expected %q", got, n, expected)
+	}
+
+	// o _ não faz parte do CNPJ, ao contrário do que diz o \b das regexes
+	got, n = r.String("cnpj_11222333000181 id_12ABC34501DE35_x\n")
+	expected = "cnpj_************** id_**************_x\n"
+	if got != expected || n != 2 {
+		t.Errorf("String() = %q (%d), expected %q", got, n, expected)
+	}
+
+	h, _ := New(HMAC, []byte("k"))
+	if h.Replace("12abc34501de35") != h.Replace("12.ABC.345/01DE-35") {
+		t.Error("HMAC tokens must not depend on case or mask")
+	}
+	f, _ := New(Fake, nil)
+	if v := f.Replace("12abc34501de99"); !cnpj.IsValid(v) {
+		t.Errorf("fake value %q for an invalid lowercase input is not valid", v)
+	}
+}
+
+// oneByteReader força Copy a lidar com CNPJs divididos entre leituras
+type oneByteReader struct{ r io.Reader }
+
+func (o oneByteReader) Read(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return o.r.Read(p)
+}
+
+func TestCopy(t *testing.T) {
+	r, _ := New(Full, nil)
+	input := strings.Repeat(logLine, 2000)
+	expected, count := r.String(input)
+
+	for name, src := range map[string]io.Reader{
+		"bulk":     strings.NewReader(input),
+		"one-byte": oneByteReader{strings.NewReader(input)},
+	} {
+		var out bytes.Buffer
+		n, err := r.Copy(&out, src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != expected || n != count {
+			t.Errorf("%s: Copy differs from String (%d vs %d replacements)", name, n, count)
+		}
+	}
+}
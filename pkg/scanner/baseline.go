@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// BaselineVersion é a versão do formato do arquivo de baseline
+const BaselineVersion = 1
+
+var ErrBaselineVersao = errors.New("versão de baseline não suportada")
+
+// Baseline guarda os fingerprints de achados já conhecidos. Os valores não são
+// gravados, para que o próprio baseline não exponha os CNPJs.
+type Baseline struct {
+	Version      int      `json:"versao"`
+	Fingerprints []string `json:"fingerprints"`
+
+	set map[string]bool
+}
+
+// NewBaseline cria um baseline com os fingerprints dos achados, sem repetições
+func NewBaseline(findings []Finding) *Baseline {
+	b := &Baseline{Version: BaselineVersion, set: map[string]bool{}}
+	for _, f := range findings {
+		if !b.set[f.Fingerprint] {
+			b.set[f.Fingerprint] = true
+			b.Fingerprints = append(b.Fingerprints, f.Fingerprint)
+		}
+	}
+	sort.Strings(b.Fingerprints)
+	return b
+}
+
+// LoadBaseline lê um baseline gravado por WriteTo
+func LoadBaseline(r io.Reader) (*Baseline, error) {
+	var b Baseline
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, err
+	}
+	if b.Version != BaselineVersion {
+		return nil, fmt.Errorf("%w: %d", ErrBaselineVersao, b.Version)
+	}
+
+	b.set = make(map[string]bool, len(b.Fingerprints))
+	for _, fp := range b.Fingerprints {
+		b.set[fp] = true
+	}
+	return &b, nil
+}
+
+// Contains indica se o fingerprint já está no baseline
+func (b *Baseline) Contains(fingerprint string) bool {
+	return b.set[fingerprint]
+}
+
+// WriteTo grava o baseline em JSON indentado
+func (b *Baseline) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
@@ -0,0 +1,178 @@
+package scanner
+
+import (
+	"bufio"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule é uma linha de um .gitignore
+type ignoreRule struct {
+	// base é o diretório do .gitignore, relativo à raiz, com barras
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Ignore acumula as regras dos arquivos .gitignore encontrados durante a varredura
+type Ignore struct {
+	rules []ignoreRule
+}
+
+// AddPatterns acrescenta as regras de um .gitignore localizado em base (relativo à raiz)
+func (ig *Ignore) AddPatterns(base string, r io.Reader) error {
+	base = strings.Trim(filepath.ToSlash(base), "/")
+	if base == "." {
+		base = ""
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		// Padrões com barra (exceto no fim) são relativos ao diretório do .gitignore;
+		// os demais valem em qualquer nível abaixo dele
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		expr := globToRegexp(line)
+		if anchored {
+			expr = "^" + expr + "$"
+		} else {
+			expr = "(^|/)" + expr + "$"
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			continue
+		}
+		rule.re = re
+		ig.rules = append(ig.rules, rule)
+	}
+	return sc.Err()
+}
+
+// Match indica se o caminho (relativo à raiz) é ignorado; a última regra que casa prevalece
+func (ig *Ignore) Match(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	ignored := false
+	for _, r := range ig.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if r.base != "" {
+			if !strings.HasPrefix(rel, r.base+"/") {
+				continue
+			}
+			sub = rel[len(r.base)+1:]
+		}
+		if r.re.MatchString(sub) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// globToRegexp converte um padrão do .gitignore em expressão regular
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Walk percorre root chamando fn para cada arquivo regular que não é ignorado
+// pelos .gitignore da árvore. O diretório .git nunca é percorrido. Se root for
+// um arquivo, fn é chamada apenas para ele.
+func Walk(root string, fn func(path string) error) error {
+	ig := &Ignore{}
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, relErr := filepath.Rel(root, p)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if rel != "." && ig.Match(rel, true) {
+				return filepath.SkipDir
+			}
+			if f, err := os.Open(filepath.Join(p, ".gitignore")); err == nil {
+				err = ig.AddPatterns(path.Clean(rel), f)
+				_ = f.Close()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || (rel != "." && ig.Match(rel, false)) {
+			return nil
+		}
+		return fn(p)
+	})
+}
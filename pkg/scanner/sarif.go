@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// Identificadores das regras no relatório SARIF
+const (
+	RuleValid   = "CNPJ001"
+	RuleInvalid = "CNPJ002"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	DefaultConfig    sarifConfig  `json:"defaultConfiguration"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysical `json:"physicalLocation"`
+}
+
+type sarifPhysical struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           sarifRegion   `json:"region"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// WriteSARIF grava os achados no formato SARIF 2.1.0, aceito por serviços de
+// code scanning. As mensagens não repetem o valor encontrado, para que o
+// relatório publicado não exponha o CNPJ.
+func WriteSARIF(w io.Writer, findings []Finding, version string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "AlfanumericCNPJ",
+			Version:        version,
+			InformationURI: "https://github.com/dyammarcano/alfanumeric-cnpj",
+			Rules: []sarifRule{
+				{ID: RuleValid, Name: "CNPJValido", ShortDescription: sarifMessage{Text: "CNPJ com DV válido, possivelmente um dado real"}, DefaultConfig: sarifConfig{Level: "error"}},
+				{ID: RuleInvalid, Name: "CNPJComMascara", ShortDescription: sarifMessage{Text: "Valor com máscara de CNPJ e DV incorreto"}, DefaultConfig: sarifConfig{Level: "note"}},
+			},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, f := range findings {
+		res := sarifResult{
+			RuleID:  RuleInvalid,
+			Level:   "note",
+			Message: sarifMessage{Text: "Valor com máscara de CNPJ e DV incorreto"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysical{
+				ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(f.Path)},
+				Region:           sarifRegion{StartLine: f.Line, StartColumn: f.Column, EndColumn: f.Column + len(f.Value)},
+			}}},
+			PartialFingerprints: map[string]string{"cnpj/v1": f.Fingerprint},
+		}
+		if f.Valid {
+			res.RuleID, res.Level = RuleValid, "error"
+			res.Message.Text = "CNPJ com DV válido; use um valor fictício ou adicione-o à allowlist"
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
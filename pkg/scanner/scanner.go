@@ -0,0 +1,156 @@
+// Package scanner procura CNPJs em arquivos e repositórios para evitar que dados
+// reais de clientes sejam versionados em fixtures e logs.
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// DefaultMaxFileSize é o tamanho a partir do qual um arquivo é ignorado
+const DefaultMaxFileSize = 10 << 20
+
+// binarySample é quanto do início do arquivo é lido para detectar conteúdo binário
+const binarySample = 8000
+
+// Finding é um CNPJ encontrado em um arquivo
+type Finding struct {
+	Path   string `json:"arquivo"`
+	Line   int    `json:"linha"`
+	Column int    `json:"coluna"`
+	Value  string `json:"valor"`
+	// Normalized é o valor sem máscara, usado na allowlist
+	Normalized string `json:"normalizado"`
+	Masked     bool   `json:"mascarado"`
+	// Valid indica DV correto, isto é, um possível CNPJ real
+	Valid bool `json:"valido"`
+	// Fingerprint identifica o achado no baseline sem guardar o valor em claro
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Fingerprint combina o caminho e o valor normalizado; não depende da linha,
+// para que o baseline continue valendo quando o arquivo é editado
+func Fingerprint(path, normalized string) string {
+	sum := sha256.Sum256([]byte(filepath.ToSlash(path) + "\x00" + normalized))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Options controla quais achados são reportados
+type Options struct {
+	// Allowlist contém valores sabidamente fictícios, ignorados em qualquer arquivo
+	Allowlist Allowlist
+	// Baseline contém achados já conhecidos, ignorados até serem removidos
+	Baseline *Baseline
+	// MaxFileSize ignora arquivos maiores; zero usa DefaultMaxFileSize
+	MaxFileSize int64
+}
+
+func (o Options) keep(f Finding) bool {
+	if o.Allowlist.Contains(f.Normalized) {
+		return false
+	}
+	return o.Baseline == nil || !o.Baseline.Contains(f.Fingerprint)
+}
+
+// ScanReader procura CNPJs no conteúdo de um arquivo, linha a linha. Conteúdo
+// binário é ignorado. Colunas contam caracteres, a partir de 1.
+func ScanReader(path string, r io.Reader, opts Options) ([]Finding, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	if sample, _ := br.Peek(binarySample); bytes.IndexByte(sample, 0) >= 0 {
+		return nil, nil
+	}
+
+	var out []Finding
+	for line := 1; ; line++ {
+		text, err := br.ReadString('\n')
+		for _, m := range cnpj.Extract(text) {
+			f := Finding{
+				Path:       path,
+				Line:       line,
+				Column:     utf8.RuneCountInString(text[:m.Start]) + 1,
+				Value:      m.Value,
+				Normalized: cnpj.UnformattedCNPJ(m.Value),
+				Masked:     m.Masked,
+				Valid:      m.Valid,
+			}
+			f.Fingerprint = Fingerprint(path, f.Normalized)
+			if opts.keep(f) {
+				out = append(out, f)
+			}
+		}
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+	}
+}
+
+// ScanFile abre e examina um arquivo, ignorando os maiores que o limite
+func ScanFile(path string, opts Options) ([]Finding, error) {
+	limit := opts.MaxFileSize
+	if limit == 0 {
+		limit = DefaultMaxFileSize
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if st, err := f.Stat(); err != nil || st.Size() > limit {
+		return nil, err
+	}
+	return ScanReader(path, f, opts)
+}
+
+// ScanTree percorre os diretórios respeitando .gitignore e examina cada arquivo
+func ScanTree(root string, opts Options) ([]Finding, error) {
+	var out []Finding
+	err := Walk(root, func(path string) error {
+		found, err := ScanFile(path, opts)
+		if err != nil {
+			return err
+		}
+		out = append(out, found...)
+		return nil
+	})
+	return out, err
+}
+
+// Allowlist é um conjunto de CNPJs sabidamente fictícios, sem máscara
+type Allowlist map[string]bool
+
+// LoadAllowlist lê um valor por linha; linhas vazias e iniciadas por # são ignoradas
+func LoadAllowlist(r io.Reader) (Allowlist, error) {
+	list := Allowlist{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line != "" {
+			list[cnpj.UnformattedCNPJ(line)] = true
+		}
+	}
+	return list, sc.Err()
+}
+
+// Contains indica se o valor, sem máscara, está na allowlist
+func (a Allowlist) Contains(normalized string) bool {
+	return a[normalized]
+}
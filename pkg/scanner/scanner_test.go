@@ -0,0 +1,161 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const fixture = "id,cnpj\n1,11222333000181\n2,\"12.ABC.345/01DE-36\"\nçã 12.ABC.345/01DE-35\n"
+
+func TestScanReader(t *testing.T) {
+	findings, err := ScanReader("dados.csv", strings.NewReader(fixture), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 findings, got %+v", findings)
+	}
+
+	f := findings[2]
+	if f.Line != 4 || f.Column != 4 || !f.Valid || !f.Masked || f.Normalized != "12ABC34501DE35" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if findings[1].Valid {
+		t.Errorf("expected invalid DV for %s", findings[1].Value)
+	}
+	if f.Fingerprint != Fingerprint("dados.csv", "12ABC34501DE35") {
+		t.Error("fingerprint mismatch")
+	}
+}
+
+func TestScanReaderSkipsBinary(t *testing.T) {
+	findings, _ := ScanReader("bin", strings.NewReader("\x00\x0111222333000181"), Options{})
+	if len(findings) != 0 {
+		t.Errorf("expected binary content to be skipped, got %+v", findings)
+	}
+}
+
+func TestAllowlistAndBaseline(t *testing.T) {
+	allow, err := LoadAllowlist(strings.NewReader("# fictícios\n11.222.333/0001-81 # exemplo da documentação\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, _ := ScanReader("dados.csv", strings.NewReader(fixture), Options{Allowlist: allow})
+	if len(findings) != 2 {
+		t.Fatalf("expected allowlisted value to be dropped, got %+v", findings)
+	}
+
+	var buf bytes.Buffer
+	if _, err := NewBaseline(findings[:1]).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "12ABC") {
+		t.Error("baseline must not contain raw values")
+	}
+	baseline, err := LoadBaseline(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, _ = ScanReader("dados.csv", strings.NewReader(fixture), Options{Allowlist: allow, Baseline: baseline})
+	if len(findings) != 1 || findings[0].Normalized != "12ABC34501DE35" {
+		t.Errorf("expected only the new finding, got %+v", findings)
+	}
+
+	if _, err := LoadBaseline(strings.NewReader(`{"versao": 9}`)); err == nil {
+		t.Error("expected error for unsupported baseline version")
+	}
+}
+
+func TestIgnore(t *testing.T) {
+	var ig Ignore
+	_ = ig.AddPatterns("", strings.NewReader("*.log\n/build\ndocs/**/*.md\n!docs/keep.md\nvendor/\n"))
+	_ = ig.AddPatterns("sub", strings.NewReader("local.txt\n"))
+
+	tests := []struct {
+		path  string
+		dir   bool
+		match bool
+	}{
+		{"app.log", false, true},
+		{"a/b/app.log", false, true},
+		{"build", true, true},
+		{"a/build", true, false},
+		{"docs/x/y.md", false, true},
+		{"docs/y.md", false, true},
+		{"docs/keep.md", false, false},
+		{"vendor", true, true},
+		{"vendor", false, false},
+		{"sub/local.txt", false, true},
+		{"local.txt", false, false},
+	}
+	for _, tt := range tests {
+		if got := ig.Match(tt.path, tt.dir); got != tt.match {
+			t.Errorf("Match(%q, dir=%v) = %v, expected %v", tt.path, tt.dir, got, tt.match)
+		}
+	}
+}
+
+func TestScanTree(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":         "logs/\n*.tmp\n",
+		"fixtures/a.json":    `{"cnpj": "11.222.333/0001-81"}`,
+		"logs/app.log":       "11222333000181",
+		"cache.tmp":          "11222333000181",
+		".git/objects/x":     "11222333000181",
+		"src/main.go":        "// nada aqui",
+		"src/.gitignore":     "gerado.go\n",
+		"src/gerado.go":      "11222333000181",
+		"docs/exemplo.txt":   "CNPJ 12ABC34501DE35",
+		"docs/sem-match.txt": "12ABC34501DE36",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(p), 0o755)
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err := ScanTree(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range findings {
+		rel, _ := filepath.Rel(root, f.Path)
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	sort.Strings(paths)
+	if strings.Join(paths, ",") != "docs/exemplo.txt,fixtures/a.json" {
+		t.Errorf("unexpected files: %v", paths)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	findings, _ := ScanReader("dados.csv", strings.NewReader(fixture), Options{})
+
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, findings, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "11222333000181") {
+		t.Error("SARIF must not contain raw values")
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	results := log.Runs[0].Results
+	if log.Version != "2.1.0" || len(results) != 3 || results[0].RuleID != RuleValid || results[1].Level != "note" {
+		t.Errorf("unexpected SARIF: %s", buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+package sqlddl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Report é o resultado de DryRun: o que aconteceria ao validar a restrição
+type Report struct {
+	Tabela    string         `json:"tabela"`
+	Coluna    string         `json:"coluna"`
+	Total     int            `json:"total"`
+	Nulos     int            `json:"nulos"`
+	Validos   int            `json:"validos"`
+	Invalidos int            `json:"invalidos"`
+	Motivos   map[string]int `json:"motivos"`
+	// Exemplos traz até o limite pedido de valores inválidos, na ordem lida
+	Exemplos []string `json:"exemplos"`
+}
+
+// DryRun lê a coluna e valida cada valor com a biblioteca, sem alterar o banco
+// nem depender das funções SQL instaladas
+func DryRun(ctx context.Context, db *sql.DB, table, column string, limit int) (Report, error) {
+	report := Report{Tabela: table, Coluna: column, Motivos: map[string]int{}, Exemplos: []string{}}
+	if !ValidIdentifier(table) || !ValidIdentifier(column) || len(column) == 0 {
+		return report, ErrIdentificadorInvalido
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s", column, table))
+	if err != nil {
+		return report, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var v sql.NullString
+		if err := rows.Scan(&v); err != nil {
+			return report, err
+		}
+
+		report.Total++
+		if !v.Valid {
+			report.Nulos++
+			continue
+		}
+		err := cnpj.Validate(v.String)
+		if err == nil {
+			report.Validos++
+			continue
+		}
+
+		report.Invalidos++
+		code := "CNPJ_INVALIDO"
+		var e *cnpj.Error
+		if errors.As(err, &e) {
+			code = e.Code
+		}
+		report.Motivos[code]++
+		if len(report.Exemplos) < limit {
+			report.Exemplos = append(report.Exemplos, v.String)
+		}
+	}
+	return report, rows.Err()
+}
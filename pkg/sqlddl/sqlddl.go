@@ -0,0 +1,174 @@
+// Package sqlddl gera DDL para que PostgreSQL e SQLite rejeitem CNPJs inválidos
+// por conta própria: funções cnpj_is_valid, cnpj_dv e cnpj_format equivalentes a
+// IsValid, CalculateDV e FormatCNPJ, o domínio cnpj e migrações de colunas.
+package sqlddl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Dialect é o banco de destino do SQL gerado
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+var (
+	ErrDialetoDesconhecido   = &cnpj.Error{Code: "SQL_DIALETO_DESCONHECIDO", Message: "dialeto SQL desconhecido (use postgres ou sqlite)"}
+	ErrIdentificadorInvalido = &cnpj.Error{Code: "SQL_IDENTIFICADOR_INVALIDO", Message: "nome de tabela ou coluna inválido"}
+)
+
+// pesosDV são os mesmos pesos de CalculateDV: o primeiro DV usa pesosDV[1:13] e o segundo pesosDV[0:13]
+var pesosDV = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+var regexIdentificador = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ParseDialect reconhece postgres (ou postgresql, pg) e sqlite (ou sqlite3)
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "postgres", "postgresql", "pg":
+		return Postgres, nil
+	case "sqlite", "sqlite3":
+		return SQLite, nil
+	}
+	return "", ErrDialetoDesconhecido
+}
+
+// ValidIdentifier indica se o nome é um identificador simples, com schema opcional
+func ValidIdentifier(name string) bool {
+	return regexIdentificador.MatchString(name)
+}
+
+// strip remove a máscara, como removeMascaraCNPJ (as minúsculas já foram rejeitadas antes)
+func strip(d Dialect, v string) string {
+	if d == Postgres {
+		return fmt.Sprintf("translate(%s, './-', '')", v)
+	}
+	return fmt.Sprintf("replace(replace(replace(%s, '.', ''), '/', ''), '-', '')", v)
+}
+
+// charsetOK equivale a isValidCharSet: apenas A-Z, dígitos e a máscara
+func charsetOK(d Dialect, v string) string {
+	if d == Postgres {
+		return fmt.Sprintf("%s !~ '[^0-9A-Z./-]'", v)
+	}
+	return fmt.Sprintf("%s NOT GLOB '*[^0-9A-Z./-]*'", v)
+}
+
+// shape verifica o formato sem máscara: base alfanumérica e, se dv, dois dígitos no fim
+func shape(d Dialect, s string, dv bool) string {
+	if d == Postgres {
+		if dv {
+			return fmt.Sprintf("%s ~ '^[0-9A-Z]{12}[0-9]{2}$'", s)
+		}
+		return fmt.Sprintf("%s ~ '^[0-9A-Z]{12}$'", s)
+	}
+	pattern := strings.Repeat("[0-9A-Z]", 12)
+	if dv {
+		pattern += "[0-9][0-9]"
+	}
+	return fmt.Sprintf("%s GLOB '%s'", s, pattern)
+}
+
+// digit calcula um DV a partir dos 12 primeiros caracteres de s; o segundo DV
+// recebe o primeiro em prev
+func digit(d Dialect, s string, weights []int, prev string) string {
+	ascii := "unicode"
+	if d == Postgres {
+		ascii = "ascii"
+	}
+
+	terms := make([]string, 0, 13)
+	for i := 0; i < 12; i++ {
+		terms = append(terms, fmt.Sprintf("(%s(substr(%s, %d, 1)) - 48) * %d", ascii, s, i+1, weights[i]))
+	}
+	if prev != "" {
+		terms = append(terms, fmt.Sprintf("%s * %d", prev, weights[12]))
+	}
+	sum := "(" + strings.Join(terms, " + ") + ")"
+	return fmt.Sprintf("(CASE WHEN %s %% 11 < 2 THEN 0 ELSE 11 - %s %% 11 END)", sum, sum)
+}
+
+// dvText retorna os dois DVs como texto, a partir dos 12 primeiros caracteres de s
+func dvText(d Dialect, s string) string {
+	dv1 := digit(d, s, pesosDV[1:], "")
+	dv2 := digit(d, s, pesosDV, dv1)
+	return fmt.Sprintf("CAST(%s AS TEXT) || CAST(%s AS TEXT)", dv1, dv2)
+}
+
+// IsValidExpr retorna uma expressão booleana equivalente a IsValid aplicada a v,
+// que pode ser uma coluna ou um parâmetro. NULL resulta em NULL, que um CHECK aceita.
+func IsValidExpr(d Dialect, v string) string {
+	s := strip(d, v)
+	return fmt.Sprintf("(%s AND %s AND substr(%s, 1, 12) <> '%s' AND substr(%s, 13, 2) = %s)",
+		charsetOK(d, v), shape(d, s, true), s, zeros, s, dvText(d, s))
+}
+
+// DVExpr retorna uma expressão equivalente a CalculateDV aplicada a v: os dois
+// DVs como texto, ou NULL quando CalculateDV retornaria erro
+func DVExpr(d Dialect, v string) string {
+	s := strip(d, v)
+	return fmt.Sprintf("(CASE WHEN %s AND (%s OR (length(%s) = 14 AND %s)) AND substr(%s, 1, 12) <> '%s' THEN %s END)",
+		charsetOK(d, v), shape(d, s, false), s, shape(d, "substr("+s+", 1, 12)", false), s, zeros, dvText(d, s))
+}
+
+// FormatExpr retorna uma expressão equivalente a FormatCNPJ aplicada a v
+func FormatExpr(d Dialect, v string) string {
+	s := "upper(" + strip(d, v) + ")"
+	return fmt.Sprintf("(CASE WHEN length(%s) = 14 THEN substr(%s, 1, 2) || '.' || substr(%s, 3, 3) || '.' || substr(%s, 6, 3) || '/' || substr(%s, 9, 4) || '-' || substr(%s, 13, 2) ELSE '%s' END)",
+		s, s, s, s, s, s, cnpj.MensagemFormatoInvalido)
+}
+
+const zeros = "000000000000"
+
+// Functions gera as funções cnpj_is_valid, cnpj_dv e cnpj_format e o domínio
+// cnpj. No PostgreSQL as funções são SQL puro ou, com plpgsql, PL/pgSQL. O
+// SQLite não permite criar funções em SQL, então o resultado traz as expressões
+// equivalentes para a coluna informada, prontas para um CHECK.
+func Functions(d Dialect, plpgsql bool, column string) (string, error) {
+	switch d {
+	case Postgres:
+		if plpgsql {
+			return postgresPLpgSQL, nil
+		}
+		return fmt.Sprintf(postgresSQL, DVExpr(d, "v"), IsValidExpr(d, "v"), FormatExpr(d, "v")) + postgresDomain, nil
+	case SQLite:
+		if !ValidIdentifier(column) {
+			return "", ErrIdentificadorInvalido
+		}
+		return fmt.Sprintf(sqliteExpressions, column, IsValidExpr(d, column), DVExpr(d, column), FormatExpr(d, column), column, IsValidExpr(d, column)), nil
+	}
+	return "", ErrDialetoDesconhecido
+}
+
+// Migration gera a migração que restringe uma coluna existente a CNPJs válidos.
+// No PostgreSQL a restrição é criada NOT VALID e validada em seguida, para não
+// bloquear a tabela durante a verificação; no SQLite, que não altera restrições
+// de tabelas existentes, são criados gatilhos de INSERT e UPDATE.
+func Migration(d Dialect, table, column string) (string, error) {
+	if !ValidIdentifier(table) || !ValidIdentifier(column) || strings.Contains(column, ".") {
+		return "", ErrIdentificadorInvalido
+	}
+	name := strings.ReplaceAll(table, ".", "_") + "_" + column + "_cnpj"
+
+	switch d {
+	case Postgres:
+		return fmt.Sprintf(postgresMigration, table, column, column, table, column, table, name, column, table, name), nil
+	case SQLite:
+		// Gatilhos do SQLite não aceitam tabelas qualificadas pelo schema
+		if strings.Contains(table, ".") {
+			return "", ErrIdentificadorInvalido
+		}
+		expr := IsValidExpr(d, "NEW."+column)
+		return fmt.Sprintf(sqliteMigration, table, column, column, table, column,
+			name, table, column, expr, table, column,
+			name, column, table, column, expr, table, column), nil
+	}
+	return "", ErrDialetoDesconhecido
+}
@@ -0,0 +1,182 @@
+package sqlddl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj/cnpjtest"
+	_ "modernc.org/sqlite"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// inputs reúne valores válidos, inválidos e casos de borda para comparar SQL e Go
+func inputs() []string {
+	values := []string{
+		"", "12ABC34501DE35", "12.ABC.345/01DE-35", "12abc34501de35", "12ABC34501DE36",
+		"12ABC34501DE", "12.ABC.345/01DE", "000000000000", "00000000000000", "00000000000191",
+		"12ABC34501DEAB", "12ABC 34501DE35", "12ABC34501DE355", "-./", "ABCDEFGHIJKL", "11222333000181",
+		"12ABC34501D", "1.2.A.B.C.3.4.5.0.1.D.E.3.5", "12ABC34501DE3", "[]ABC34501DE35", "*2ABC34501DE35",
+	}
+	for _, c := range cnpjtest.Golden() {
+		values = append(values, c.Input)
+	}
+
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 300; i++ {
+		v := cnpj.GenerateWith(r, cnpj.GenerateOptions{Numeric: i%3 == 0})
+		switch i % 4 {
+		case 1:
+			v = cnpj.FormatCNPJ(v)
+		case 2:
+			v = v[:13] + string(rune('0'+(int(v[13]-'0')+1)%10))
+		case 3:
+			v = v[:12]
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestSQLiteExpressionsMatchGo(t *testing.T) {
+	db := openSQLite(t)
+
+	query := fmt.Sprintf("WITH t(v) AS (SELECT ?) SELECT %s, %s, %s FROM t",
+		IsValidExpr(SQLite, "v"), DVExpr(SQLite, "v"), FormatExpr(SQLite, "v"))
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, v := range inputs() {
+		var (
+			valid  sql.NullBool
+			dv     sql.NullString
+			format string
+		)
+		if err := stmt.QueryRow(v).Scan(&valid, &dv, &format); err != nil {
+			t.Fatalf("%q: %v", v, err)
+		}
+
+		if valid.Bool != cnpj.IsValid(v) {
+			t.Errorf("cnpj_is_valid(%q) = %v, Go = %v", v, valid.Bool, cnpj.IsValid(v))
+		}
+		goDV, goErr := cnpj.CalculateDV(v)
+		if dv.Valid != (goErr == nil) || dv.String != goDV {
+			t.Errorf("cnpj_dv(%q) = %v, Go = %q (%v)", v, dv, goDV, goErr)
+		}
+		if format != cnpj.FormatCNPJ(v) {
+			t.Errorf("cnpj_format(%q) = %q, Go = %q", v, format, cnpj.FormatCNPJ(v))
+		}
+	}
+}
+
+func TestSQLiteMigration(t *testing.T) {
+	db := openSQLite(t)
+	ctx := context.Background()
+
+	if _, err := db.Exec(`CREATE TABLE fornecedores (id INTEGER PRIMARY KEY, documento TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []any{"12ABC34501DE35", "12ABC34501DE36", nil, "abc", "11.222.333/0001-81"} {
+		if _, err := db.Exec(`INSERT INTO fornecedores (documento) VALUES (?)`, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := DryRun(ctx, db, "fornecedores", "documento", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 5 || report.Nulos != 1 || report.Validos != 2 || report.Invalidos != 2 ||
+		report.Motivos["CNPJ_DV_INCORRETO"] != 1 || len(report.Exemplos) != 2 {
+		t.Errorf("unexpected dry-run report: %+v", report)
+	}
+
+	ddl, err := Migration(SQLite, "fornecedores", "documento")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(ddl); err != nil {
+		t.Fatalf("migration failed: %v\n%s", err, ddl)
+	}
+
+	if _, err := db.Exec(`INSERT INTO fornecedores (documento) VALUES ('12ABC34501DE35')`); err != nil {
+		t.Errorf("valid insert rejected: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO fornecedores (documento) VALUES (NULL)`); err != nil {
+		t.Errorf("NULL insert rejected: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO fornecedores (documento) VALUES ('12ABC34501DE36')`); err == nil || !strings.Contains(err.Error(), "CNPJ inválido") {
+		t.Errorf("invalid insert accepted: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE fornecedores SET documento = 'X' WHERE id = 1`); err == nil {
+		t.Error("invalid update accepted")
+	}
+}
+
+func TestSQLiteCheckConstraint(t *testing.T) {
+	db := openSQLite(t)
+
+	ddl := fmt.Sprintf("CREATE TABLE t (cnpj TEXT CHECK %s)", IsValidExpr(SQLite, "cnpj"))
+	if _, err := db.Exec(ddl); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO t VALUES ('12.ABC.345/01DE-35')`); err != nil {
+		t.Errorf("valid value rejected: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO t VALUES ('12.ABC.345/01DE-36')`); err == nil {
+		t.Error("invalid value accepted")
+	}
+}
+
+func TestFunctionsAndMigrationText(t *testing.T) {
+	for _, plpgsql := range []bool{false, true} {
+		ddl, err := Functions(Postgres, plpgsql, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{"FUNCTION cnpj_dv(v text)", "FUNCTION cnpj_is_valid(v text)", "FUNCTION cnpj_format(v text)", "CREATE DOMAIN cnpj"} {
+			if !strings.Contains(ddl, expected) {
+				t.Errorf("plpgsql=%v: missing %q", plpgsql, expected)
+			}
+		}
+		if strings.Contains(ddl, "%!") {
+			t.Errorf("plpgsql=%v: formatting error in DDL", plpgsql)
+		}
+	}
+
+	migration, err := Migration(Postgres, "public.fornecedores", "documento")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(migration, "ALTER TABLE public.fornecedores ADD CONSTRAINT public_fornecedores_documento_cnpj CHECK (cnpj_is_valid(documento)) NOT VALID;") ||
+		!strings.Contains(migration, "VALIDATE CONSTRAINT public_fornecedores_documento_cnpj;") {
+		t.Errorf("unexpected migration:\n%s", migration)
+	}
+
+	if _, err := Functions(SQLite, false, "cnpj; DROP TABLE x"); err != ErrIdentificadorInvalido {
+		t.Errorf("expected ErrIdentificadorInvalido, got %v", err)
+	}
+	if _, err := Migration(SQLite, "main.t", "c"); err != ErrIdentificadorInvalido {
+		t.Errorf("expected ErrIdentificadorInvalido, got %v", err)
+	}
+	if _, err := ParseDialect("oracle"); err != ErrDialetoDesconhecido {
+		t.Errorf("expected ErrDialetoDesconhecido, got %v", err)
+	}
+}
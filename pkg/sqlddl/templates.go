@@ -0,0 +1,163 @@
+package sqlddl
+
+// postgresSQL recebe as expressões de cnpj_dv, cnpj_is_valid e cnpj_format sobre o parâmetro v
+const postgresSQL = `-- Funções de CNPJ alfanumérico em SQL puro, equivalentes a CalculateDV, IsValid e FormatCNPJ.
+-- STRICT: entradas NULL resultam em NULL.
+
+CREATE OR REPLACE FUNCTION cnpj_dv(v text) RETURNS text
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+SELECT %s
+$$;
+
+CREATE OR REPLACE FUNCTION cnpj_is_valid(v text) RETURNS boolean
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+SELECT %s
+$$;
+
+CREATE OR REPLACE FUNCTION cnpj_format(v text) RETURNS text
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+SELECT %s
+$$;
+`
+
+const postgresPLpgSQL = `-- Funções de CNPJ alfanumérico em PL/pgSQL, equivalentes a CalculateDV, IsValid e FormatCNPJ.
+-- STRICT: entradas NULL resultam em NULL.
+
+CREATE OR REPLACE FUNCTION cnpj_dv(v text) RETURNS text
+LANGUAGE plpgsql IMMUTABLE STRICT PARALLEL SAFE AS $$
+DECLARE
+    s     text;
+    pesos int[] := ARRAY[6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2];
+    soma1 int := 0;
+    soma2 int := 0;
+    valor int;
+    dv1   int;
+    dv2   int;
+BEGIN
+    IF v ~ '[^0-9A-Z./-]' THEN
+        RETURN NULL;
+    END IF;
+
+    s := translate(v, './-', '');
+    IF length(s) = 14 THEN
+        s := substr(s, 1, 12);
+    END IF;
+    IF s !~ '^[0-9A-Z]{12}$' OR s = '000000000000' THEN
+        RETURN NULL;
+    END IF;
+
+    -- Cada caractere vale o código ASCII menos 48: '0'..'9' = 0..9, 'A'..'Z' = 17..42
+    FOR i IN 1..12 LOOP
+        valor := ascii(substr(s, i, 1)) - 48;
+        soma1 := soma1 + valor * pesos[i + 1];
+        soma2 := soma2 + valor * pesos[i];
+    END LOOP;
+
+    dv1 := soma1 % 11;
+    dv1 := CASE WHEN dv1 < 2 THEN 0 ELSE 11 - dv1 END;
+    soma2 := soma2 + dv1 * pesos[13];
+    dv2 := soma2 % 11;
+    dv2 := CASE WHEN dv2 < 2 THEN 0 ELSE 11 - dv2 END;
+
+    RETURN dv1::text || dv2::text;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION cnpj_is_valid(v text) RETURNS boolean
+LANGUAGE plpgsql IMMUTABLE STRICT PARALLEL SAFE AS $$
+DECLARE
+    s text;
+BEGIN
+    IF v ~ '[^0-9A-Z./-]' THEN
+        RETURN false;
+    END IF;
+
+    s := translate(v, './-', '');
+    IF s !~ '^[0-9A-Z]{12}[0-9]{2}$' OR substr(s, 1, 12) = '000000000000' THEN
+        RETURN false;
+    END IF;
+
+    RETURN substr(s, 13, 2) = cnpj_dv(substr(s, 1, 12));
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION cnpj_format(v text) RETURNS text
+LANGUAGE plpgsql IMMUTABLE STRICT PARALLEL SAFE AS $$
+DECLARE
+    s text := upper(translate(v, './-', ''));
+BEGIN
+    IF length(s) <> 14 THEN
+        RETURN 'CNPJ inválido';
+    END IF;
+    RETURN substr(s, 1, 2) || '.' || substr(s, 3, 3) || '.' || substr(s, 6, 3) || '/' || substr(s, 9, 4) || '-' || substr(s, 13, 2);
+END;
+$$;
+` + postgresDomain
+
+const postgresDomain = `
+-- Domínio para colunas novas: CREATE TABLE fornecedores (cnpj cnpj NOT NULL, ...)
+DO $$
+BEGIN
+    CREATE DOMAIN cnpj AS text CHECK (cnpj_is_valid(VALUE));
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END;
+$$;
+`
+
+// sqliteExpressions recebe a coluna e as expressões de cnpj_is_valid, cnpj_dv e
+// cnpj_format, seguidas da coluna e da expressão do exemplo de CHECK
+const sqliteExpressions = `-- O SQLite não permite criar funções em SQL. As expressões abaixo, sobre a coluna %s,
+-- são equivalentes a IsValid, CalculateDV e FormatCNPJ e podem ser usadas em CHECK,
+-- consultas e gatilhos. Para outra coluna, gere novamente com --column.
+
+-- cnpj_is_valid
+-- %s
+
+-- cnpj_dv
+-- %s
+
+-- cnpj_format
+-- %s
+
+-- Exemplo de tabela nova:
+-- CREATE TABLE fornecedores (
+--     %s TEXT NOT NULL CHECK %s
+-- );
+`
+
+// postgresMigration recebe tabela e coluna (cabeçalho), coluna, tabela e coluna
+// (verificação), tabela, restrição e coluna (ADD) e tabela e restrição (VALIDATE)
+const postgresMigration = `-- Migração: restringe %s.%s a CNPJs válidos.
+-- Requer as funções de 'sql ddl --dialect postgres'.
+
+-- 1. Valores que impediriam a validação (ou use 'sql migrate --dry-run'):
+--    SELECT %s, count(*) FROM %s WHERE NOT cnpj_is_valid(%s) GROUP BY 1;
+
+-- 2. Novas linhas passam a ser verificadas imediatamente, sem varrer a tabela:
+ALTER TABLE %s ADD CONSTRAINT %s CHECK (cnpj_is_valid(%s)) NOT VALID;
+
+-- 3. Depois de corrigir os valores existentes, valide a restrição:
+ALTER TABLE %s VALIDATE CONSTRAINT %s;
+`
+
+// sqliteMigration recebe tabela e coluna (cabeçalho), coluna, tabela e coluna
+// (verificação) e, para cada gatilho, nome, tabela, coluna, expressão, tabela e coluna
+const sqliteMigration = `-- Migração: restringe %s.%s a CNPJs válidos.
+-- O SQLite não altera restrições de tabelas existentes; a verificação é feita por gatilhos.
+
+-- Valores que seriam rejeitados (ou use 'sql migrate --dry-run'):
+--    SELECT %s FROM %s WHERE NOT <cnpj_is_valid(%s)>;
+
+CREATE TRIGGER IF NOT EXISTS %s_insert BEFORE INSERT ON %s
+WHEN NEW.%s IS NOT NULL AND NOT %s
+BEGIN
+    SELECT RAISE(ABORT, 'CNPJ inválido em %s.%s');
+END;
+
+CREATE TRIGGER IF NOT EXISTS %s_update BEFORE UPDATE OF %s ON %s
+WHEN NEW.%s IS NOT NULL AND NOT %s
+BEGIN
+    SELECT RAISE(ABORT, 'CNPJ inválido em %s.%s');
+END;
+`
@@ -0,0 +1,144 @@
+package sqlitecnpj
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"modernc.org/sqlite/vtab"
+)
+
+// Colunas de cnpj_generate_series; n e semente são os argumentos da função
+const (
+	colCNPJ = iota
+	colFormatado
+	colN
+	colSemente
+)
+
+// Bits de IdxNum indicando quais argumentos foram informados
+const (
+	argN = 1 << iota
+	argSemente
+)
+
+var ErrSerieSemTamanho = errors.New("cnpj_generate_series: informe a quantidade, ex.: cnpj_generate_series(10)")
+
+type seriesModule struct{}
+
+func (seriesModule) Create(ctx vtab.Context, _ []string) (vtab.Table, error) {
+	return declare(ctx)
+}
+
+func (seriesModule) Connect(ctx vtab.Context, _ []string) (vtab.Table, error) {
+	return declare(ctx)
+}
+
+func declare(ctx vtab.Context) (vtab.Table, error) {
+	err := ctx.Declare("CREATE TABLE x(cnpj TEXT, formatado TEXT, n HIDDEN, semente HIDDEN)")
+	return seriesTable{}, err
+}
+
+type seriesTable struct{}
+
+// BestIndex repassa n e semente ao cursor; sem n, o plano fica caro o bastante
+// para o SQLite preferir outro e Filter recusa a consulta
+func (seriesTable) BestIndex(info *vtab.IndexInfo) error {
+	next := 0
+	for _, col := range []int{colN, colSemente} {
+		for i := range info.Constraints {
+			c := &info.Constraints[i]
+			if c.Column != col || c.Op != vtab.OpEQ || !c.Usable {
+				continue
+			}
+			c.ArgIndex, c.Omit = next, true
+			next++
+			if col == colN {
+				info.IdxNum |= argN
+			} else {
+				info.IdxNum |= argSemente
+			}
+			break
+		}
+	}
+
+	info.EstimatedCost, info.EstimatedRows = 1e12, 1<<40
+	if info.IdxNum&argN != 0 {
+		info.EstimatedCost, info.EstimatedRows = 1000, 1000
+	}
+	return nil
+}
+
+func (seriesTable) Open() (vtab.Cursor, error) {
+	return &seriesCursor{}, nil
+}
+
+func (seriesTable) Disconnect() error { return nil }
+
+func (seriesTable) Destroy() error { return nil }
+
+type seriesCursor struct {
+	r       *rand.Rand
+	n, row  int64
+	semente any
+	current string
+}
+
+func (c *seriesCursor) Filter(idxNum int, _ string, vals []vtab.Value) error {
+	if idxNum&argN == 0 {
+		return ErrSerieSemTamanho
+	}
+	n, ok := vals[0].(int64)
+	if !ok {
+		return ErrSerieSemTamanho
+	}
+
+	seed := time.Now().UnixNano()
+	c.semente = nil
+	if idxNum&argSemente != 0 {
+		s, ok := vals[1].(int64)
+		if !ok {
+			return errors.New("cnpj_generate_series: a semente deve ser um inteiro")
+		}
+		seed, c.semente = s, s
+	}
+
+	c.r = rand.New(rand.NewSource(seed))
+	c.n, c.row = n, 0
+	return c.Next()
+}
+
+func (c *seriesCursor) Next() error {
+	c.row++
+	if c.row <= c.n {
+		c.current = cnpj.GenerateWith(c.r, cnpj.GenerateOptions{})
+	}
+	return nil
+}
+
+func (c *seriesCursor) Eof() bool {
+	return c.row > c.n
+}
+
+func (c *seriesCursor) Column(col int) (vtab.Value, error) {
+	switch col {
+	case colCNPJ:
+		return c.current, nil
+	case colFormatado:
+		return cnpj.FormatCNPJ(c.current), nil
+	case colN:
+		return c.n, nil
+	case colSemente:
+		return c.semente, nil
+	}
+	return nil, nil
+}
+
+func (c *seriesCursor) Rowid() (int64, error) {
+	return c.row, nil
+}
+
+func (c *seriesCursor) Close() error {
+	return nil
+}
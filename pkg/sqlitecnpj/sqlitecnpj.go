@@ -0,0 +1,147 @@
+// Package sqlitecnpj registra funções de CNPJ no driver SQLite embutido
+// (modernc.org/sqlite), para consultas como
+//
+//	SELECT * FROM fornecedores WHERE NOT cnpj_valid(doc)
+//
+// As funções escalares são cnpj_valid, cnpj_dv, cnpj_format, cnpj_normalize e
+// cnpj_raiz, com a mesma semântica de IsValid, CalculateDV, FormatCNPJ,
+// UnformattedCNPJ e Raiz. A função de tabela cnpj_generate_series(n [, semente])
+// gera n CNPJs válidos.
+package sqlitecnpj
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"modernc.org/sqlite"
+	"modernc.org/sqlite/vtab"
+)
+
+// DriverName é o nome do driver usado em sql.Open
+const DriverName = "sqlite"
+
+// SeriesTable é o nome da função de tabela que gera CNPJs
+const SeriesTable = "cnpj_generate_series"
+
+var (
+	registerOnce sync.Once
+	registerErr  error
+)
+
+// scalar é a implementação de uma função escalar sobre um único texto; ok
+// falso resulta em NULL
+type scalar func(value string) (result any, ok bool)
+
+var scalars = map[string]scalar{
+	"cnpj_valid": func(v string) (any, bool) {
+		return cnpj.IsValid(v), true
+	},
+	"cnpj_dv": func(v string) (any, bool) {
+		dv, err := cnpj.CalculateDV(v)
+		return dv, err == nil
+	},
+	"cnpj_format": func(v string) (any, bool) {
+		return cnpj.FormatCNPJ(v), true
+	},
+	"cnpj_normalize": func(v string) (any, bool) {
+		return cnpj.UnformattedCNPJ(v), true
+	},
+	"cnpj_raiz": func(v string) (any, bool) {
+		raiz := cnpj.Raiz(v)
+		return raiz, raiz != ""
+	},
+}
+
+// Register registra as funções no driver. As funções só ficam disponíveis nas
+// conexões abertas depois da chamada; chamadas repetidas não têm efeito.
+func Register() error {
+	registerOnce.Do(func() {
+		for name, fn := range scalars {
+			if registerErr = sqlite.RegisterDeterministicScalarFunction(name, 1, wrap(fn)); registerErr != nil {
+				return
+			}
+		}
+		registerErr = vtab.RegisterModule(nil, SeriesTable, seriesModule{})
+	})
+	return registerErr
+}
+
+// wrap adapta uma função escalar à interface do driver; NULL resulta em NULL
+func wrap(fn scalar) func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+	return func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		value, ok := text(args[0])
+		if !ok {
+			return nil, nil
+		}
+		if result, ok := fn(value); ok {
+			return result, nil
+		}
+		return nil, nil
+	}
+}
+
+// text converte o argumento recebido do SQLite; números viram texto
+func text(v driver.Value) (string, bool) {
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case []byte:
+		return string(t), true
+	case int64:
+		return strconv.FormatInt(t, 10), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case nil:
+		return "", false
+	}
+	return fmt.Sprint(v), true
+}
+
+// Open registra as funções e abre o banco. Cada conexão do pool ganha a tabela
+// temporária cnpj_generate_series, necessária porque o driver não cria funções
+// de tabela sem um CREATE VIRTUAL TABLE.
+func Open(dsn string) (*sql.DB, error) {
+	if err := Register(); err != nil {
+		return nil, err
+	}
+
+	// O driver global é o único que conhece as funções registradas
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector{dsn: dsn, driver: d}), nil
+}
+
+// connector abre conexões do driver global e instala a função de tabela em cada uma
+type connector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	create := fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS temp.%s USING %s", SeriesTable, SeriesTable)
+	if _, err := conn.(driver.ExecerContext).ExecContext(ctx, create, nil); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,142 @@
+package sqlitecnpj
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj/cnpjtest"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestScalarFunctions(t *testing.T) {
+	db := openDB(t)
+
+	values := []string{"", "12ABC34501DE35", "12.ABC.345/01DE-35", "12abc34501de35", "12ABC34501DE36", "12ABC34501DE", "00000000000000", "x"}
+	for _, c := range cnpjtest.Golden() {
+		values = append(values, c.Input)
+	}
+
+	for _, v := range values {
+		var (
+			valid            bool
+			dv, raiz         sql.NullString
+			format, normaliz string
+		)
+		err := db.QueryRow(`SELECT cnpj_valid(?1), cnpj_dv(?1), cnpj_format(?1), cnpj_normalize(?1), cnpj_raiz(?1)`, v).
+			Scan(&valid, &dv, &format, &normaliz, &raiz)
+		if err != nil {
+			t.Fatalf("%q: %v", v, err)
+		}
+
+		if valid != cnpj.IsValid(v) {
+			t.Errorf("cnpj_valid(%q) = %v", v, valid)
+		}
+		if goDV, err := cnpj.CalculateDV(v); dv.Valid != (err == nil) || dv.String != goDV {
+			t.Errorf("cnpj_dv(%q) = %v, expected %q (%v)", v, dv, goDV, err)
+		}
+		if format != cnpj.FormatCNPJ(v) {
+			t.Errorf("cnpj_format(%q) = %q", v, format)
+		}
+		if normaliz != cnpj.UnformattedCNPJ(v) {
+			t.Errorf("cnpj_normalize(%q) = %q", v, normaliz)
+		}
+		if raiz.String != cnpj.Raiz(v) || raiz.Valid != (cnpj.Raiz(v) != "") {
+			t.Errorf("cnpj_raiz(%q) = %v", v, raiz)
+		}
+	}
+
+	var valid sql.NullBool
+	if err := db.QueryRow(`SELECT cnpj_valid(NULL)`).Scan(&valid); err != nil || valid.Valid {
+		t.Errorf("cnpj_valid(NULL) = %v, %v; expected NULL", valid, err)
+	}
+}
+
+func TestQueryOverTable(t *testing.T) {
+	db := openDB(t)
+
+	if _, err := db.Exec(`CREATE TABLE fornecedores (doc TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO fornecedores VALUES ('12ABC34501DE35'), ('12ABC34501DE36'), ('12.ABC.345/0002-xx')`); err != nil {
+		t.Fatal(err)
+	}
+
+	var invalid int
+	if err := db.QueryRow(`SELECT count(*) FROM fornecedores WHERE NOT cnpj_valid(doc)`).Scan(&invalid); err != nil {
+		t.Fatal(err)
+	}
+	if invalid != 2 {
+		t.Errorf("expected 2 invalid rows, got %d", invalid)
+	}
+
+	var empresas int
+	if err := db.QueryRow(`SELECT count(DISTINCT cnpj_raiz(doc)) FROM fornecedores`).Scan(&empresas); err != nil {
+		t.Fatal(err)
+	}
+	if empresas != 1 {
+		t.Errorf("expected 1 raiz, got %d", empresas)
+	}
+}
+
+func TestGenerateSeries(t *testing.T) {
+	db := openDB(t)
+
+	series := func(query string, args ...any) []string {
+		t.Helper()
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		var out []string
+		for rows.Next() {
+			var v, formatado string
+			if err := rows.Scan(&v, &formatado); err != nil {
+				t.Fatal(err)
+			}
+			if !cnpj.IsValid(v) || formatado != cnpj.FormatCNPJ(v) {
+				t.Errorf("invalid generated row %q %q", v, formatado)
+			}
+			out = append(out, v)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	if got := series(`SELECT cnpj, formatado FROM cnpj_generate_series(25)`); len(got) != 25 {
+		t.Errorf("expected 25 rows, got %d", len(got))
+	}
+
+	a := series(`SELECT cnpj, formatado FROM cnpj_generate_series(5, 42)`)
+	b := series(`SELECT cnpj, formatado FROM cnpj_generate_series(?, ?)`, 5, 42)
+	if len(a) != 5 || len(b) != 5 {
+		t.Fatalf("unexpected sizes %d %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("same seed produced different series: %v %v", a, b)
+			break
+		}
+	}
+
+	if _, err := db.Exec(`CREATE TABLE seed AS SELECT cnpj FROM cnpj_generate_series(10)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Query(`SELECT * FROM cnpj_generate_series`); err == nil {
+		t.Error("expected error without n")
+	}
+}
@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"html/template"
+	"io"
+	"sort"
+)
+
+// Labels são os textos do relatório HTML, para que quem chama escolha o idioma
+type Labels struct {
+	Titulo, Total, Validos, Invalidos, Vazios, Motivos       string
+	Numericos, Alfanumericos, ComMascara, SemMascara         string
+	Distintos, Duplicados, MaisRepetidos, Empresas, Posicoes string
+	Posicao, Quantidade, Estabelecimentos, Valor, Caractere  string
+	Motivo                                                   string
+	MotivoTexto                                              func(code string) string
+}
+
+// DefaultLabels são os textos em português
+var DefaultLabels = Labels{
+	Titulo: "Perfil da coluna de CNPJ", Total: "Total", Validos: "Válidos", Invalidos: "Inválidos",
+	Vazios: "Vazios", Motivos: "Inválidos por motivo", Numericos: "Numéricos (legado)",
+	Alfanumericos: "Alfanuméricos", ComMascara: "Com máscara", SemMascara: "Sem máscara",
+	Distintos: "Distintos", Duplicados: "Duplicados", MaisRepetidos: "Mais repetidos",
+	Empresas: "Empresas com mais estabelecimentos", Posicoes: "Frequência de caracteres por posição",
+	Posicao: "Posição", Quantidade: "Quantidade", Estabelecimentos: "Estabelecimentos",
+	Valor: "Valor", Caractere: "Caractere", Motivo: "Motivo",
+	MotivoTexto: func(code string) string { return code },
+}
+
+type htmlBar struct {
+	Label   string
+	Count   int
+	Percent float64
+}
+
+type htmlData struct {
+	L       Labels
+	R       Report
+	Geral   []htmlBar
+	Motivos []htmlBar
+}
+
+// WriteHTML grava o relatório como um arquivo HTML autocontido, sem scripts nem
+// recursos externos, com barras proporcionais desenhadas em CSS
+func WriteHTML(w io.Writer, r Report, labels Labels) error {
+	if labels.MotivoTexto == nil {
+		labels.MotivoTexto = DefaultLabels.MotivoTexto
+	}
+
+	data := htmlData{L: labels, R: r}
+	valued := r.Total - r.Vazios
+	data.Geral = []htmlBar{
+		{labels.Validos, r.Validos, Percent(r.Validos, r.Total)},
+		{labels.Invalidos, r.Invalidos, Percent(r.Invalidos, r.Total)},
+		{labels.Vazios, r.Vazios, Percent(r.Vazios, r.Total)},
+		{labels.Numericos, r.Numericos, Percent(r.Numericos, r.Numericos+r.Alfanumericos)},
+		{labels.Alfanumericos, r.Alfanumericos, Percent(r.Alfanumericos, r.Numericos+r.Alfanumericos)},
+		{labels.ComMascara, r.ComMascara, Percent(r.ComMascara, valued)},
+		{labels.SemMascara, r.SemMascara, Percent(r.SemMascara, valued)},
+		{labels.Duplicados, r.Duplicados, Percent(r.Duplicados, valued)},
+	}
+
+	codes := make([]string, 0, len(r.Motivos))
+	for code := range r.Motivos {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return r.Motivos[codes[i]] > r.Motivos[codes[j]] })
+	for _, code := range codes {
+		data.Motivos = append(data.Motivos, htmlBar{labels.MotivoTexto(code), r.Motivos[code], Percent(r.Motivos[code], r.Invalidos)})
+	}
+
+	return htmlReport.Execute(w, data)
+}
+
+var htmlReport = template.Must(template.New("stats").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>{{.L.Titulo}}</title>
+<style>
+body{font-family:system-ui,sans-serif;margin:2rem auto;max-width:960px;color:#222}
+h1{font-size:1.5rem}h2{font-size:1.1rem;margin-top:2rem;border-bottom:1px solid #ddd}
+table{border-collapse:collapse;width:100%}td,th{padding:.25rem .5rem;text-align:left;font-size:.9rem}
+th{background:#f4f4f4}.num{text-align:right;font-variant-numeric:tabular-nums}
+.bar{background:#e8eef7;width:40%}.bar span{display:block;height:.8rem;background:#3b6fb6}
+.kpi{display:inline-block;margin:0 1.5rem 1rem 0}.kpi b{display:block;font-size:1.6rem}
+code{font-size:.95rem}
+</style>
+</head>
+<body>
+<h1>{{.L.Titulo}}</h1>
+<div class="kpi"><b>{{.R.Total}}</b>{{.L.Total}}</div>
+<div class="kpi"><b>{{.R.Validos}}</b>{{.L.Validos}}</div>
+<div class="kpi"><b>{{.R.Invalidos}}</b>{{.L.Invalidos}}</div>
+<div class="kpi"><b>{{.R.Distintos}}</b>{{.L.Distintos}}</div>
+<div class="kpi"><b>{{.R.Duplicados}}</b>{{.L.Duplicados}}</div>
+
+<table>
+{{range .Geral}}<tr><td>{{.Label}}</td><td class="num">{{.Count}}</td><td class="num">{{printf "%.1f" .Percent}}%</td><td class="bar"><span style="width:{{printf "%.1f" .Percent}}%"></span></td></tr>
+{{end}}</table>
+
+{{if .Motivos}}<h2>{{.L.Motivos}}</h2>
+<table><tr><th>{{.L.Motivo}}</th><th class="num">{{.L.Quantidade}}</th><th class="num">%</th><th></th></tr>
+{{range .Motivos}}<tr><td>{{.Label}}</td><td class="num">{{.Count}}</td><td class="num">{{printf "%.1f" .Percent}}%</td><td class="bar"><span style="width:{{printf "%.1f" .Percent}}%"></span></td></tr>
+{{end}}</table>{{end}}
+
+{{if .R.MaisRepetidos}}<h2>{{.L.MaisRepetidos}}</h2>
+<table><tr><th>{{.L.Valor}}</th><th class="num">{{.L.Quantidade}}</th></tr>
+{{range .R.MaisRepetidos}}<tr><td><code>{{.Valor}}</code></td><td class="num">{{.Quantidade}}</td></tr>
+{{end}}</table>{{end}}
+
+{{if .R.Empresas}}<h2>{{.L.Empresas}}</h2>
+<table><tr><th>Raiz</th><th class="num">{{.L.Estabelecimentos}}</th></tr>
+{{range .R.Empresas}}<tr><td><code>{{.Valor}}</code></td><td class="num">{{.Quantidade}}</td></tr>
+{{end}}</table>{{end}}
+
+<h2>{{.L.Posicoes}}</h2>
+<table><tr><th>{{.L.Posicao}}</th><th>{{.L.Caractere}}</th></tr>
+{{range .R.Posicoes}}<tr><td class="num">{{.Posicao}}</td><td>{{range .Frequencias}}<code>{{.Valor}}</code> {{.Quantidade}} &nbsp; {{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,176 @@
+// Package stats traça o perfil de uma coluna de CNPJs antes de uma migração:
+// validade por motivo, participação de numéricos e alfanuméricos, máscara,
+// duplicidades, empresas com mais estabelecimentos e frequência por posição.
+package stats
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+)
+
+// Count é um valor e quantas vezes ele aparece
+type Count struct {
+	Valor      string `json:"valor"`
+	Quantidade int    `json:"quantidade"`
+}
+
+// Position é a frequência dos caracteres em uma posição do CNPJ, da mais à menos comum
+type Position struct {
+	Posicao     int     `json:"posicao"`
+	Frequencias []Count `json:"frequencias"`
+}
+
+// Report é o perfil de uma lista de CNPJs
+type Report struct {
+	Total     int            `json:"total"`
+	Vazios    int            `json:"vazios"`
+	Validos   int            `json:"validos"`
+	Invalidos int            `json:"invalidos"`
+	Motivos   map[string]int `json:"motivos"`
+	// Numericos e Alfanumericos classificam os valores com 14 caracteres pela base
+	Numericos     int `json:"numericos"`
+	Alfanumericos int `json:"alfanumericos"`
+	ComMascara    int `json:"com_mascara"`
+	SemMascara    int `json:"sem_mascara"`
+	Distintos     int `json:"distintos"`
+	// Duplicados é quantos valores repetem um valor já visto
+	Duplicados    int        `json:"duplicados"`
+	MaisRepetidos []Count    `json:"mais_repetidos"`
+	Empresas      []Count    `json:"empresas"`
+	Posicoes      []Position `json:"posicoes"`
+}
+
+// Collector acumula os valores de uma lista; o zero não está pronto, use New
+type Collector struct {
+	r        Report
+	seen     map[string]int
+	raizes   map[string]map[string]bool
+	position [14]map[byte]int
+}
+
+// New cria um Collector vazio
+func New() *Collector {
+	c := &Collector{
+		r:      Report{Motivos: map[string]int{}},
+		seen:   map[string]int{},
+		raizes: map[string]map[string]bool{},
+	}
+	for i := range c.position {
+		c.position[i] = map[byte]int{}
+	}
+	return c
+}
+
+// Add acrescenta um valor, com ou sem máscara
+func (c *Collector) Add(value string) {
+	c.r.Total++
+	value = strings.TrimSpace(value)
+	v := cnpj.UnformattedCNPJ(value)
+	if v == "" {
+		c.r.Vazios++
+		c.r.Invalidos++
+		c.r.Motivos[cnpj.ErroVazio.Code]++
+		return
+	}
+
+	if err := cnpj.Validate(value); err != nil {
+		c.r.Invalidos++
+		code := "CNPJ_INVALIDO"
+		var e *cnpj.Error
+		if errors.As(err, &e) {
+			code = e.Code
+		}
+		c.r.Motivos[code]++
+	} else {
+		c.r.Validos++
+	}
+
+	if strings.ContainsAny(value, "./-") {
+		c.r.ComMascara++
+	} else {
+		c.r.SemMascara++
+	}
+
+	c.seen[v]++
+	if c.seen[v] > 1 {
+		c.r.Duplicados++
+		return
+	}
+	if len(v) != 14 {
+		return
+	}
+
+	if strings.Trim(v[:12], "0123456789") == "" {
+		c.r.Numericos++
+	} else {
+		c.r.Alfanumericos++
+	}
+
+	raiz := cnpj.Raiz(v)
+	if c.raizes[raiz] == nil {
+		c.raizes[raiz] = map[string]bool{}
+	}
+	c.raizes[raiz][cnpj.Ordem(v)] = true
+
+	for i := 0; i < 14; i++ {
+		c.position[i][v[i]]++
+	}
+}
+
+// Report retorna o perfil acumulado, com até top itens nas listas de repetidos,
+// de empresas e de caracteres por posição
+func (c *Collector) Report(top int) Report {
+	r := c.r
+	r.Distintos = len(c.seen)
+
+	r.MaisRepetidos = []Count{}
+	for v, n := range c.seen {
+		if n > 1 {
+			r.MaisRepetidos = append(r.MaisRepetidos, Count{Valor: v, Quantidade: n})
+		}
+	}
+	r.MaisRepetidos = topCounts(r.MaisRepetidos, top)
+
+	r.Empresas = []Count{}
+	for raiz, ordens := range c.raizes {
+		if len(ordens) > 1 {
+			r.Empresas = append(r.Empresas, Count{Valor: raiz, Quantidade: len(ordens)})
+		}
+	}
+	r.Empresas = topCounts(r.Empresas, top)
+
+	r.Posicoes = make([]Position, 14)
+	for i, freq := range c.position {
+		counts := make([]Count, 0, len(freq))
+		for ch, n := range freq {
+			counts = append(counts, Count{Valor: string(ch), Quantidade: n})
+		}
+		r.Posicoes[i] = Position{Posicao: i + 1, Frequencias: topCounts(counts, top)}
+	}
+	return r
+}
+
+// topCounts ordena por quantidade decrescente e valor, e corta em top (0 não corta)
+func topCounts(counts []Count, top int) []Count {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Quantidade != counts[j].Quantidade {
+			return counts[i].Quantidade > counts[j].Quantidade
+		}
+		return counts[i].Valor < counts[j].Valor
+	})
+	if top > 0 && len(counts) > top {
+		counts = counts[:top]
+	}
+	return counts
+}
+
+// Percent retorna n como porcentagem de total, zero quando total é zero
+func Percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func collect(values ...string) Report {
+	c := New()
+	for _, v := range values {
+		c.Add(v)
+	}
+	return c.Report(3)
+}
+
+func TestReport(t *testing.T) {
+	r := collect(
+		"11.222.333/0001-81",
+		"11222333000181",
+		"11.222.333/0002-62",
+		"12ABC34501DE35",
+		"12abc34501de35",
+		"12ABC34501DE36",
+		"",
+		"123",
+	)
+
+	// minúsculas são inválidas, como em validate, mas contam como o mesmo valor
+	if r.Total != 8 || r.Vazios != 1 || r.Validos != 4 || r.Invalidos != 4 {
+		t.Errorf("unexpected totals: %+v", r)
+	}
+	if r.Motivos["CNPJ_CARACTERE_INVALIDO"] != 1 || r.Motivos["CNPJ_DV_INCORRETO"] != 1 || r.Motivos["CNPJ_VAZIO"] != 1 || r.Motivos["CNPJ_TAMANHO_INVALIDO"] != 1 {
+		t.Errorf("unexpected reasons: %v", r.Motivos)
+	}
+	if r.ComMascara != 2 || r.SemMascara != 5 {
+		t.Errorf("unexpected mask share: %d/%d", r.ComMascara, r.SemMascara)
+	}
+	if r.Distintos != 5 || r.Duplicados != 2 {
+		t.Errorf("unexpected distinct/duplicates: %d/%d", r.Distintos, r.Duplicados)
+	}
+	if r.Numericos != 2 || r.Alfanumericos != 2 {
+		t.Errorf("unexpected numeric share: %d/%d", r.Numericos, r.Alfanumericos)
+	}
+	if len(r.MaisRepetidos) != 2 || r.MaisRepetidos[0].Quantidade != 2 {
+		t.Errorf("unexpected duplicates: %+v", r.MaisRepetidos)
+	}
+	if len(r.Empresas) != 1 || r.Empresas[0] != (Count{Valor: "11222333", Quantidade: 2}) {
+		t.Errorf("unexpected companies: %+v", r.Empresas)
+	}
+
+	first := r.Posicoes[0].Frequencias
+	if len(r.Posicoes) != 14 || first[0] != (Count{Valor: "1", Quantidade: 4}) {
+		t.Errorf("unexpected position frequency: %+v", r.Posicoes[0])
+	}
+}
+
+func TestPercent(t *testing.T) {
+	if Percent(1, 0) != 0 || Percent(1, 4) != 25 {
+		t.Error("unexpected Percent result")
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := collect("11222333000181", "11222333000181", "<script>")
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, r, DefaultLabels); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, expected := range []string{"<!DOCTYPE html>", DefaultLabels.Titulo, "<code>11222333000181</code>", "CNPJ_CARACTERE_INVALIDO"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("missing %q in HTML", expected)
+		}
+	}
+	if strings.Contains(html, "http://") || strings.Contains(html, "<script") {
+		t.Error("HTML must be self-contained and escaped")
+	}
+}
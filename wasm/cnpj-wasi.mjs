@@ -0,0 +1,54 @@
+// Wrapper Node para o build WASI (GOOS=wasip1 GOARCH=wasm -buildmode=c-shared)
+// de pkg/cnpj. Segue as regras de memória descritas em main_wasip1.go.
+import { readFile } from "node:fs/promises";
+import { WASI } from "node:wasi";
+import { CNPJError } from "./cnpj.mjs";
+
+const encoder = new TextEncoder();
+const decoder = new TextDecoder();
+
+export async function loadWASI(source = new URL("./cnpj-wasi.wasm", import.meta.url)) {
+  const wasi = new WASI({ version: "preview1" });
+  const module = await WebAssembly.compile(await readFile(source));
+  const instance = await WebAssembly.instantiate(module, wasi.getImportObject());
+  wasi.initialize(instance);
+
+  const ex = instance.exports;
+
+  function withInput(value, fn) {
+    const bytes = encoder.encode(String(value));
+    const ptr = ex.cnpj_alloc(bytes.length);
+    new Uint8Array(ex.memory.buffer, ptr, bytes.length).set(bytes);
+    try {
+      return fn(ptr, bytes.length);
+    } finally {
+      ex.cnpj_free(ptr);
+    }
+  }
+
+  function takeString(packed) {
+    const ptr = Number(BigInt.asUintN(64, packed) >> 32n);
+    const len = Number(BigInt.asUintN(64, packed) & 0xffffffffn);
+    if (len === 0) {
+      return "";
+    }
+    const s = decoder.decode(new Uint8Array(ex.memory.buffer, ptr, len));
+    ex.cnpj_free(ptr);
+    return s;
+  }
+
+  return {
+    isValid: (value) => withInput(value, (p, n) => ex.cnpj_is_valid(p, n) === 1),
+    calculateDV(value) {
+      const dv = withInput(value, (p, n) => takeString(ex.cnpj_calculate_dv(p, n)));
+      if (dv === "") {
+        const code = takeString(ex.cnpj_last_error_code());
+        throw new CNPJError("CNPJ inválido", code);
+      }
+      return dv;
+    },
+    format: (value) => withInput(value, (p, n) => takeString(ex.cnpj_format(p, n))),
+    formatPartial: (value) => withInput(value, (p, n) => takeString(ex.cnpj_format_partial(p, n))),
+    generate: () => takeString(ex.cnpj_generate()),
+  };
+}
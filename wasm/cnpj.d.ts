@@ -0,0 +1,28 @@
+// Tipos dos wrappers cnpj.mjs (GOOS=js) e cnpj-wasi.mjs (WASI).
+
+export declare class CNPJError extends Error {
+  /** Código estável do erro, por exemplo "CNPJ_DV_INVALIDO". */
+  readonly code: string;
+}
+
+export interface CNPJ {
+  /** Valida um CNPJ alfanumérico, com ou sem máscara. */
+  isValid(value: string): boolean;
+  /** Calcula os dois dígitos verificadores; lança CNPJError se a base for inválida. */
+  calculateDV(value: string): string;
+  /** Aplica a máscara ##.###.###/####-## em um CNPJ completo. */
+  format(value: string): string;
+  /** Aplica a máscara de forma incremental, enquanto o valor é digitado. */
+  formatPartial(value: string): string;
+  /** Gera um CNPJ alfanumérico válido, sem máscara. */
+  generate(): string;
+}
+
+export interface CNPJBrowser extends CNPJ {
+  /** Remove a máscara e converte para maiúsculas. */
+  unformat(value: string): string;
+}
+
+export declare function load(source?: string | URL | WebAssembly.Module): Promise<CNPJBrowser>;
+
+export declare function loadWASI(source?: string | URL): Promise<CNPJ>;
@@ -0,0 +1,62 @@
+// Wrapper JavaScript para o build GOOS=js GOARCH=wasm de pkg/cnpj.
+// Funciona no navegador e no Node; requer wasm_exec.js ao lado deste arquivo
+// (copiado de $(go env GOROOT)/lib/wasm pelo alvo `make wasm`).
+import "./wasm_exec.js";
+
+export class CNPJError extends Error {
+  constructor(message, code) {
+    super(message);
+    this.name = "CNPJError";
+    this.code = code;
+  }
+}
+
+function wrap(api) {
+  return {
+    isValid: (value) => api.isValid(String(value)),
+    calculateDV(value) {
+      const res = api.calculateDV(String(value));
+      if (res.error) {
+        throw new CNPJError(res.error, res.code);
+      }
+      return res.dv;
+    },
+    format: (value) => api.format(String(value)),
+    formatPartial: (value) => api.formatPartial(String(value)),
+    unformat: (value) => api.unformat(String(value)),
+    generate: () => api.generate(),
+  };
+}
+
+async function instantiate(source, imports) {
+  if (source instanceof WebAssembly.Module) {
+    return { instance: await WebAssembly.instantiate(source, imports) };
+  }
+
+  const isNode = typeof process !== "undefined" && process.versions?.node;
+  const isFile = typeof source === "string" || (source instanceof URL && source.protocol === "file:");
+  if (isNode && isFile) {
+    const { readFile } = await import("node:fs/promises");
+    return WebAssembly.instantiate(await readFile(source), imports);
+  }
+
+  if (WebAssembly.instantiateStreaming) {
+    return WebAssembly.instantiateStreaming(fetch(source), imports);
+  }
+  const res = await fetch(source);
+  return WebAssembly.instantiate(await res.arrayBuffer(), imports);
+}
+
+let loaded;
+
+// load instancia o módulo uma única vez e retorna a API tipada
+export function load(source = new URL("./cnpj.wasm", import.meta.url)) {
+  loaded ??= (async () => {
+    const go = new globalThis.Go();
+    const { instance } = await instantiate(source, go.importObject);
+    // O main do Go fica bloqueado atendendo as chamadas, por isso não é aguardado
+    go.run(instance);
+    return wrap(globalThis.alfanumericCNPJ);
+  })();
+  return loaded;
+}
@@ -0,0 +1,22 @@
+// Executado por `make wasm-test` com node --test após os builds js e WASI.
+import assert from "node:assert/strict";
+import { test } from "node:test";
+import { CNPJError, load } from "./cnpj.mjs";
+import { loadWASI } from "./cnpj-wasi.mjs";
+
+for (const [name, loader] of [["js", load], ["wasi", loadWASI]]) {
+  test(`${name}: mesma lógica de pkg/cnpj`, async () => {
+    const cnpj = await loader();
+
+    assert.equal(cnpj.isValid("12.ABC.345/01DE-35"), true);
+    assert.equal(cnpj.isValid("12.ABC.345/01DE-36"), false);
+    assert.equal(cnpj.calculateDV("12ABC34501DE"), "35");
+    assert.equal(cnpj.format("12ABC34501DE35"), "12.ABC.345/01DE-35");
+    assert.equal(cnpj.formatPartial("12abc3"), "12.ABC.3");
+    assert.equal(cnpj.isValid(cnpj.generate()), true);
+
+    assert.throws(() => cnpj.calculateDV("000000000000"), (err) => {
+      return err instanceof CNPJError && err.code === "CNPJ_DV_INVALIDO";
+    });
+  });
+}
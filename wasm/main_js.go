@@ -0,0 +1,50 @@
+//go:build js && wasm
+
+// Comando wasm expõe pkg/cnpj para JavaScript (navegador e Node) quando
+// compilado com GOOS=js GOARCH=wasm. As funções ficam no objeto global
+// alfanumericCNPJ e devem ser usadas pelo wrapper cnpj.mjs.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+)
+
+func main() {
+	js.Global().Set("alfanumericCNPJ", js.ValueOf(map[string]any{
+		"isValid": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return cnpj.IsValid(stringArg(args))
+		}),
+		"calculateDV": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			dv, err := cnpj.CalculateDV(stringArg(args))
+			if err != nil {
+				return map[string]any{"error": err.Error(), "code": i18n.Code(err)}
+			}
+			return map[string]any{"dv": dv}
+		}),
+		"format": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return cnpj.FormatCNPJ(stringArg(args))
+		}),
+		"formatPartial": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return cnpj.FormatPartial(stringArg(args))
+		}),
+		"unformat": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return cnpj.UnformattedCNPJ(stringArg(args))
+		}),
+		"generate": js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			return cnpj.GenerateCNPJ()
+		}),
+	}))
+
+	// Mantém o runtime vivo para atender as chamadas vindas do JavaScript
+	select {}
+}
+
+func stringArg(args []js.Value) string {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return ""
+	}
+	return args[0].String()
+}
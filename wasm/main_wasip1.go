@@ -0,0 +1,100 @@
+//go:build wasip1
+
+// Comando wasm, quando compilado com GOOS=wasip1 GOARCH=wasm -buildmode=c-shared,
+// gera um módulo WASI (reactor) que exporta pkg/cnpj por meio de //go:wasmexport.
+//
+// Strings trafegam pela memória linear: o chamador reserva um buffer com
+// cnpj_alloc (apenas esses buffers são aceitos como entrada), copia os bytes UTF-8 da entrada e passa ponteiro e tamanho.
+// Funções que retornam texto devolvem um uint64 com o ponteiro nos 32 bits
+// altos e o tamanho nos 32 bits baixos; esse buffer pertence ao chamador e
+// deve ser liberado com cnpj_free. Tamanho zero em cnpj_calculate_dv indica
+// erro, e cnpj_last_error_code devolve o código estável correspondente.
+package main
+
+import (
+	"unsafe"
+
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/cnpj"
+	"github.com/dyammarcano/alfanumeric-cnpj/pkg/i18n"
+)
+
+func main() {}
+
+// buffers mantém vivos os buffers entregues ao chamador até cnpj_free
+var buffers = map[uint32][]byte{}
+
+var lastErrorCode string
+
+//go:wasmexport cnpj_alloc
+func alloc(size uint32) uint32 {
+	if size == 0 {
+		size = 1
+	}
+	buf := make([]byte, size)
+	ptr := uint32(uintptr(unsafe.Pointer(&buf[0])))
+	buffers[ptr] = buf
+	return ptr
+}
+
+//go:wasmexport cnpj_free
+func free(ptr uint32) {
+	delete(buffers, ptr)
+}
+
+//go:wasmexport cnpj_is_valid
+func isValid(ptr, size uint32) uint32 {
+	if cnpj.IsValid(readString(ptr, size)) {
+		return 1
+	}
+	return 0
+}
+
+//go:wasmexport cnpj_calculate_dv
+func calculateDV(ptr, size uint32) uint64 {
+	dv, err := cnpj.CalculateDV(readString(ptr, size))
+	if err != nil {
+		lastErrorCode = i18n.Code(err)
+		return 0
+	}
+	lastErrorCode = ""
+	return writeString(dv)
+}
+
+//go:wasmexport cnpj_last_error_code
+func lastError() uint64 {
+	return writeString(lastErrorCode)
+}
+
+//go:wasmexport cnpj_format
+func format(ptr, size uint32) uint64 {
+	return writeString(cnpj.FormatCNPJ(readString(ptr, size)))
+}
+
+//go:wasmexport cnpj_format_partial
+func formatPartial(ptr, size uint32) uint64 {
+	return writeString(cnpj.FormatPartial(readString(ptr, size)))
+}
+
+//go:wasmexport cnpj_generate
+func generate() uint64 {
+	return writeString(cnpj.GenerateCNPJ())
+}
+
+// readString lê a entrada de um buffer obtido com cnpj_alloc; ponteiros
+// desconhecidos ou tamanhos maiores que o buffer resultam em string vazia
+func readString(ptr, size uint32) string {
+	buf, ok := buffers[ptr]
+	if !ok || int(size) > len(buf) {
+		return ""
+	}
+	return string(buf[:size])
+}
+
+func writeString(s string) uint64 {
+	if s == "" {
+		return 0
+	}
+	ptr := alloc(uint32(len(s)))
+	copy(buffers[ptr], s)
+	return uint64(ptr)<<32 | uint64(len(s))
+}